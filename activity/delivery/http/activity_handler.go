@@ -1,35 +1,54 @@
 package http
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo"
 	"github.com/sirupsen/logrus"
-	validator "gopkg.in/go-playground/validator.v9"
 
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/mergepatch"
+	"github.com/bxcodec/go-clean-arch/pkg/timezone"
+	"github.com/bxcodec/go-clean-arch/pkg/validation"
 )
 
 // ResponseError represent the reseponse error struct
 type ResponseError struct {
-	Message string `json:"message"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
 }
 
 // ArticleHandler  represent the httphandler for article
 type ArticleHandler struct {
 	AUsecase domain.ActivityUsecase
+	TUsecase domain.TodoUsecase
+	// Location is the time zone of the callers not telling theirs, UTC when it is nil
+	Location *time.Location
 }
 
 // NewArticleHandler will initialize the articles/ resources endpoint
-func NewArticleHandler(e *echo.Echo, us domain.ActivityUsecase) {
+func NewArticleHandler(e *echo.Echo, us domain.ActivityUsecase, tu domain.TodoUsecase, loc *time.Location) {
 	handler := &ArticleHandler{
 		AUsecase: us,
+		TUsecase: tu,
+		Location: loc,
 	}
 	e.GET("/activity", handler.FetchActivity)
 	e.POST("/activity", handler.Store)
 	e.GET("/activity/:id", handler.GetByID)
+	e.PUT("/activity/:id", handler.Update)
+	e.PATCH("/activity/:id", handler.Patch)
 	e.DELETE("/activity/:id", handler.Delete)
+	e.POST("/activity/:id/duplicate", handler.Duplicate)
+	e.POST("/activity/:id/restore", handler.Restore)
+	e.POST("/activity/:id/archive", handler.Archive)
+	e.POST("/activity/:id/unarchive", handler.Unarchive)
+	e.GET("/activity/:id/todos", handler.FetchTodo)
+	e.POST("/activity/:id/todos", handler.StoreTodo)
 }
 
 // FetchArticle will fetch the article based on given params
@@ -37,14 +56,23 @@ func (a *ArticleHandler) FetchActivity(c echo.Context) error {
 	numS := c.QueryParam("num")
 	num, _ := strconv.Atoi(numS)
 	cursor := c.QueryParam("cursor")
+	var filter domain.ActivityFilter
+	if includeArchived := c.QueryParam("include_archived"); includeArchived != "" {
+		var err error
+		filter.IncludeArchived, err = strconv.ParseBool(includeArchived)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ResponseError{Message: domain.ErrBadParamInput.Error()})
+		}
+	}
 	ctx := c.Request().Context()
 
-	listAr, nextCursor, err := a.AUsecase.Fetch(ctx, cursor, int64(num))
+	listAr, nextCursor, prevCursor, err := a.AUsecase.Fetch(ctx, cursor, int64(num), filter)
 	if err != nil {
 		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
 	}
 
 	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	c.Response().Header().Set(`X-Prev-Cursor`, prevCursor)
 	return c.JSON(http.StatusOK, listAr)
 }
 
@@ -66,13 +94,15 @@ func (a *ArticleHandler) GetByID(c echo.Context) error {
 	return c.JSON(http.StatusOK, art)
 }
 
-func isRequestValid(m *domain.Activity) (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(m)
+func isRequestValid(m interface{}) (bool, ResponseError) {
+	fields, err := validation.Struct(m)
 	if err != nil {
-		return false, err
+		return false, ResponseError{Message: err.Error()}
+	}
+	if len(fields) > 0 {
+		return false, ResponseError{Message: domain.ErrBadParamInput.Error(), Errors: fields}
 	}
-	return true, nil
+	return true, ResponseError{}
 }
 
 // Store will store the article by given request body
@@ -83,9 +113,8 @@ func (a *ArticleHandler) Store(c echo.Context) (err error) {
 		return c.JSON(http.StatusUnprocessableEntity, err.Error())
 	}
 
-	var ok bool
-	if ok, err = isRequestValid(&article); !ok {
-		return c.JSON(http.StatusBadRequest, err.Error())
+	if ok, resErr := isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
 	}
 
 	ctx := c.Request().Context()
@@ -97,6 +126,94 @@ func (a *ArticleHandler) Store(c echo.Context) (err error) {
 	return c.JSON(http.StatusCreated, article)
 }
 
+// Update will replace the article by given param and request body
+func (a *ArticleHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var article domain.Activity
+	err = c.Bind(&article)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	article.ID = int64(idP)
+
+	if ok, resErr := isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	err = a.AUsecase.Update(ctx, &article)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return a.stored(c, article.ID)
+}
+
+// stored will respond with the activity as it is stored, the update keeps the archived flag whatever the request says
+func (a *ArticleHandler) stored(c echo.Context, id int64) error {
+	res, err := a.AUsecase.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Patch will partially update the article by given param and JSON merge-patch body
+func (a *ArticleHandler) Patch(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	if !mergepatch.IsMediaType(c.Request().Header.Get(echo.HeaderContentType)) {
+		return c.JSON(http.StatusUnsupportedMediaType, ResponseError{Message: mergepatch.ErrMediaType.Error()})
+	}
+	patch, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	id := int64(idP)
+	ctx := c.Request().Context()
+
+	existed, err := a.AUsecase.GetByID(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	original, err := json.Marshal(existed)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseError{Message: err.Error()})
+	}
+
+	patched, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var article domain.Activity
+	err = json.Unmarshal(patched, &article)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	article.ID = id
+
+	if ok, resErr := isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	err = a.AUsecase.Update(ctx, &article)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return a.stored(c, article.ID)
+}
+
 // Delete will delete article by given param
 func (a *ArticleHandler) Delete(c echo.Context) error {
 	idP, err := strconv.Atoi(c.Param("id"))
@@ -115,6 +232,149 @@ func (a *ArticleHandler) Delete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// Restore will bring the article given by param back from the trash, along with the todos deleted with it
+func (a *ArticleHandler) Restore(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	article, err := a.AUsecase.Restore(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
+// Archive will make the todos of the article given by param read-only and hide it from the listing
+func (a *ArticleHandler) Archive(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	article, err := a.AUsecase.Archive(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
+// Unarchive will undo the archive of the article given by param
+func (a *ArticleHandler) Unarchive(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	article, err := a.AUsecase.Unarchive(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
+// Duplicate will copy the article given by param along with its todos, the request body is optional
+func (a *ArticleHandler) Duplicate(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var dup domain.ActivityDuplicate
+	if c.Request().ContentLength != 0 {
+		err = c.Bind(&dup)
+		if err != nil {
+			return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		}
+	}
+	if ok, resErr := isRequestValid(&dup); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	article, err := a.AUsecase.Duplicate(ctx, int64(idP), dup)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, article)
+}
+
+// FetchTodo will fetch the todos of the article given by param
+func (a *ArticleHandler) FetchTodo(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	numS := c.QueryParam("num")
+	num, _ := strconv.Atoi(numS)
+	cursor := c.QueryParam("cursor")
+	ctx := c.Request().Context()
+
+	sort, err := domain.ParseTodoSort(c.QueryParam("sort"))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	art, err := a.AUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	filter := domain.TodoFilter{ActivityGroupID: art.ID}
+	listTodo, nextCursor, prevCursor, err := a.TUsecase.Fetch(ctx, cursor, int64(num), filter, sort)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	c.Response().Header().Set(`X-Prev-Cursor`, prevCursor)
+	return c.JSON(http.StatusOK, listTodo)
+}
+
+// StoreTodo will store the todo by given request body under the article given by param
+func (a *ArticleHandler) StoreTodo(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var todo domain.Todo
+	err = c.Bind(&todo)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	art, err := a.AUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	todo.ActivityGroupID = art
+
+	if ok, resErr := isRequestValid(&todo); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+	if err = timezone.Recurrence(c, &todo, a.Location); err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	err = a.TUsecase.Store(ctx, &todo)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, todo)
+}
+
 func getStatusCode(err error) int {
 	if err == nil {
 		return http.StatusOK
@@ -128,6 +388,16 @@ func getStatusCode(err error) int {
 		return http.StatusNotFound
 	case domain.ErrConflict:
 		return http.StatusConflict
+	case domain.ErrBadParamInput:
+		return http.StatusBadRequest
+	case domain.ErrUnknownActivity:
+		return http.StatusUnprocessableEntity
+	case domain.ErrActivityHasTodos:
+		return http.StatusConflict
+	case domain.ErrInvalidTransition:
+		return http.StatusConflict
+	case domain.ErrActivityArchived:
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
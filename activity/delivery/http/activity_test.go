@@ -29,7 +29,7 @@ func TestFetch(t *testing.T) {
 	mockListArticle = append(mockListArticle, mockArticle)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(mockListArticle, "10", nil)
+	mockUCase.On("Fetch", mock.Anything, cursor, int64(num), domain.ActivityFilter{}).Return(mockListArticle, "10", "8", nil)
 
 	e := echo.New()
 	req, err := http.NewRequest(echo.GET, "/activity?num=1&cursor="+cursor, strings.NewReader(""))
@@ -45,6 +45,7 @@ func TestFetch(t *testing.T) {
 
 	responseCursor := rec.Header().Get("X-Cursor")
 	assert.Equal(t, "10", responseCursor)
+	assert.Equal(t, "8", rec.Header().Get("X-Prev-Cursor"))
 	assert.Equal(t, http.StatusOK, rec.Code)
 	mockUCase.AssertExpectations(t)
 }
@@ -53,7 +54,7 @@ func TestFetchError(t *testing.T) {
 	mockUCase := new(mocks.ActivityUsecaseMock)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(nil, "", domain.ErrInternalServerError)
+	mockUCase.On("Fetch", mock.Anything, cursor, int64(num), domain.ActivityFilter{}).Return(nil, "", "", domain.ErrInternalServerError)
 
 	e := echo.New()
 	req, err := http.NewRequest(echo.GET, "/activity?num=1&cursor="+cursor, strings.NewReader(""))
@@ -73,6 +74,34 @@ func TestFetchError(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 }
 
+func TestFetchIncludeArchived(t *testing.T) {
+	mockUCase := new(mocks.ActivityUsecaseMock)
+	mockUCase.On("Fetch", mock.Anything, "", int64(0), domain.ActivityFilter{IncludeArchived: true}).Return([]domain.Activity{{ID: 2, Archived: true}}, "", "", nil).Once()
+
+	e := echo.New()
+	handler := activityHTTP.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		query string
+		code  int
+	}{
+		{query: "include_archived=true", code: http.StatusOK},
+		{query: "include_archived=maybe", code: http.StatusBadRequest},
+	} {
+		req, err := http.NewRequest(echo.GET, "/activity?"+tt.query, strings.NewReader(""))
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err = handler.FetchActivity(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.query)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
 func TestGetByID(t *testing.T) {
 	var mockArticle domain.Activity
 	err := faker.FakeData(&mockArticle)
@@ -139,6 +168,82 @@ func TestStore(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 }
 
+func TestUpdate(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    12,
+		Email: "Content",
+		Title: "Title",
+	}
+
+	mockUCase := new(mocks.ActivityUsecaseMock)
+
+	// archiving is not an update, the response tells the flag as it is stored
+	requested := mockArticle
+	requested.Archived = true
+	j, err := json.Marshal(requested)
+	assert.NoError(t, err)
+
+	mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*domain.Activity")).Return(nil)
+	mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/activity/12", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("activity/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+
+	handler := activityHTTP.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Update(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res domain.Activity
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.False(t, res.Archived)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestPatch(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    12,
+		Email: "Content",
+		Title: "Title",
+	}
+
+	mockUCase := new(mocks.ActivityUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil)
+	mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(ar *domain.Activity) bool {
+		return ar.ID == 12 && ar.Title == "Patched" && ar.Email == "Content"
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/activity/12", strings.NewReader(`{"title":"Patched"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("activity/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+
+	handler := activityHTTP.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
 func TestDelete(t *testing.T) {
 	var mockArticle domain.Activity
 	err := faker.FakeData(&mockArticle)
@@ -169,3 +274,303 @@ func TestDelete(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 
 }
+
+func TestRestore(t *testing.T) {
+	mockUCase := new(mocks.ActivityUsecaseMock)
+	mockUCase.On("Restore", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Work"}, nil).Once()
+	mockUCase.On("Restore", mock.Anything, int64(3)).Return(domain.Activity{}, domain.ErrConflict).Once()
+	mockUCase.On("Restore", mock.Anything, int64(9)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	handler := activityHTTP.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		id   string
+		code int
+	}{
+		{id: "2", code: http.StatusOK},
+		{id: "3", code: http.StatusConflict},
+		{id: "9", code: http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(echo.POST, "/activity/"+tt.id+"/restore", strings.NewReader(""))
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/restore")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		err = handler.Restore(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestArchive(t *testing.T) {
+	mockUCase := new(mocks.ActivityUsecaseMock)
+	mockUCase.On("Archive", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Work", Archived: true}, nil).Once()
+	mockUCase.On("Archive", mock.Anything, int64(9)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	handler := activityHTTP.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		id   string
+		code int
+	}{
+		{id: "2", code: http.StatusOK},
+		{id: "9", code: http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(echo.POST, "/activity/"+tt.id+"/archive", strings.NewReader(""))
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/archive")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		err = handler.Archive(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchTodo(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    12,
+		Email: "Content",
+		Title: "Title",
+	}
+	mockListTodo := []domain.Todo{
+		{ID: 1, ActivityGroupID: mockArticle, Title: "Todo"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(mocks.ActivityUsecaseMock)
+		mockTUCase := new(mocks.TodoUsecaseMock)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil)
+		sort := domain.TodoSort{{Field: "priority", Descending: true}}
+		mockTUCase.On("Fetch", mock.Anything, "", int64(5), domain.TodoFilter{ActivityGroupID: 12}, sort).
+			Return(mockListTodo, "10", "", nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/activity/12/todos?num=5&sort=-priority", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/todos")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := activityHTTP.ArticleHandler{
+			AUsecase: mockUCase,
+			TUsecase: mockTUCase,
+		}
+		err = handler.FetchTodo(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "10", rec.Header().Get("X-Cursor"))
+		mockUCase.AssertExpectations(t)
+		mockTUCase.AssertExpectations(t)
+	})
+
+	t.Run("article-is-not-exist", func(t *testing.T) {
+		mockUCase := new(mocks.ActivityUsecaseMock)
+		mockTUCase := new(mocks.TodoUsecaseMock)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(domain.Activity{}, domain.ErrNotFound)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/activity/12/todos", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/todos")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := activityHTTP.ArticleHandler{
+			AUsecase: mockUCase,
+			TUsecase: mockTUCase,
+		}
+		err = handler.FetchTodo(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUCase.AssertExpectations(t)
+		mockTUCase.AssertNotCalled(t, "Fetch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestStoreTodo(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    12,
+		Email: "Content",
+		Title: "Title",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(mocks.ActivityUsecaseMock)
+		mockTUCase := new(mocks.TodoUsecaseMock)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil)
+		mockTUCase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.ActivityGroupID.ID == 12 && td.Title == "Todo"
+		})).Return(nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/activity/12/todos", strings.NewReader(`{"title":"Todo","status":"todo","priority":3}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/todos")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := activityHTTP.ArticleHandler{
+			AUsecase: mockUCase,
+			TUsecase: mockTUCase,
+		}
+		err = handler.StoreTodo(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockUCase.AssertExpectations(t)
+		mockTUCase.AssertExpectations(t)
+	})
+
+	t.Run("recurrence-in-caller-time-zone", func(t *testing.T) {
+		mockUCase := new(mocks.ActivityUsecaseMock)
+		mockTUCase := new(mocks.TodoUsecaseMock)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil)
+		mockTUCase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Recurrence == "FREQ=WEEKLY" && td.TimeZone == "Asia/Jakarta"
+		})).Return(nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/activity/12/todos", strings.NewReader(`{"title":"Chore","priority":3,"recurrence":"FREQ=WEEKLY"}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("X-Timezone", "Asia/Jakarta")
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/todos")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := activityHTTP.ArticleHandler{
+			AUsecase: mockUCase,
+			TUsecase: mockTUCase,
+		}
+		err = handler.StoreTodo(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockTUCase.AssertExpectations(t)
+	})
+
+	t.Run("article-is-not-exist", func(t *testing.T) {
+		mockUCase := new(mocks.ActivityUsecaseMock)
+		mockTUCase := new(mocks.TodoUsecaseMock)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(domain.Activity{}, domain.ErrNotFound)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/activity/12/todos", strings.NewReader(`{"title":"Todo","status":"todo","priority":3}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/todos")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := activityHTTP.ArticleHandler{
+			AUsecase: mockUCase,
+			TUsecase: mockTUCase,
+		}
+		err = handler.StoreTodo(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockTUCase.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid-todo", func(t *testing.T) {
+		mockUCase := new(mocks.ActivityUsecaseMock)
+		mockTUCase := new(mocks.TodoUsecaseMock)
+		mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockArticle, nil)
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.POST, "/activity/12/todos", strings.NewReader(`{"status":"todo","priority":3}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/todos")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		handler := activityHTTP.ArticleHandler{
+			AUsecase: mockUCase,
+			TUsecase: mockTUCase,
+		}
+		err = handler.StoreTodo(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var res activityHTTP.ResponseError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, domain.ErrBadParamInput.Error(), res.Message)
+		assert.Contains(t, res.Errors, "title")
+		mockTUCase.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDuplicate(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    4,
+		Title: "Sprint (copy)",
+		Email: "team@example.com",
+	}
+
+	mockUCase := new(mocks.ActivityUsecaseMock)
+	mockUCase.On("Duplicate", mock.Anything, int64(2), domain.ActivityDuplicate{}).Return(mockArticle, nil).Once()
+	mockUCase.On("Duplicate", mock.Anything, int64(2), domain.ActivityDuplicate{Title: "Sprint 5", ResetStatus: true}).Return(mockArticle, nil).Once()
+	mockUCase.On("Duplicate", mock.Anything, int64(9), domain.ActivityDuplicate{}).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	handler := activityHTTP.ArticleHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		id   string
+		body string
+		code int
+	}{
+		{id: "2", code: http.StatusCreated},
+		{id: "2", body: `{"title":"Sprint 5","reset_status":true}`, code: http.StatusCreated},
+		{id: "9", code: http.StatusNotFound},
+		{id: "2", body: `{"title":"` + strings.Repeat("a", 46) + `"}`, code: http.StatusBadRequest},
+	} {
+		req, err := http.NewRequest(echo.POST, "/activity/"+tt.id+"/duplicate", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/:id/duplicate")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		err = handler.Duplicate(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// TemplateHandler  represent the httphandler for activity template
+type TemplateHandler struct {
+	TUsecase domain.TemplateUsecase
+}
+
+// NewTemplateHandler will initialize the template/ resources endpoint and the instantiation of the activities
+func NewTemplateHandler(e *echo.Echo, us domain.TemplateUsecase) {
+	handler := &TemplateHandler{
+		TUsecase: us,
+	}
+	e.GET("/template", handler.FetchTemplate)
+	e.POST("/template", handler.Store)
+	e.GET("/template/:id", handler.GetByID)
+	e.PUT("/template/:id", handler.Update)
+	e.DELETE("/template/:id", handler.Delete)
+	e.POST("/activity/:id/template", handler.FromActivity)
+	e.POST("/activity/from-template/:templateID", handler.Instantiate)
+}
+
+// FetchTemplate will fetch every template ordered by title
+func (a *TemplateHandler) FetchTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	listTemplate, err := a.TUsecase.Fetch(ctx)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, listTemplate)
+}
+
+// GetByID will get template by given id
+func (a *TemplateHandler) GetByID(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	template, err := a.TUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+// Store will store the template by given request body
+func (a *TemplateHandler) Store(c echo.Context) (err error) {
+	var template domain.Template
+	err = c.Bind(&template)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&template); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	err = a.TUsecase.Store(ctx, &template)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, template)
+}
+
+// Update will replace the template and its todos by given param and request body
+func (a *TemplateHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var template domain.Template
+	err = c.Bind(&template)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	template.ID = int64(idP)
+
+	if ok, resErr := isRequestValid(&template); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	err = a.TUsecase.Update(ctx, &template)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+// Delete will delete template by given param
+func (a *TemplateHandler) Delete(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	err = a.TUsecase.Delete(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// FromActivity will save the article given by param and its todos as a template
+func (a *TemplateHandler) FromActivity(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	template, err := a.TUsecase.FromActivity(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, template)
+}
+
+// Instantiate will store a new article with its todos from the template given by param
+func (a *TemplateHandler) Instantiate(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	article, err := a.TUsecase.Instantiate(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, article)
+}
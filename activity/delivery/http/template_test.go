@@ -0,0 +1,107 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	activityHTTP "github.com/bxcodec/go-clean-arch/activity/delivery/http"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+)
+
+func TestStoreTemplate(t *testing.T) {
+	mockUCase := new(mocks.TemplateUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(tp *domain.Template) bool {
+		return tp.Title == "Sprint {{week}}" && len(tp.Todos) == 1 && tp.Todos[0].Priority == domain.PriorityHigh
+	})).Return(nil).Once()
+
+	e := echo.New()
+	handler := activityHTTP.TemplateHandler{
+		TUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		body string
+		code int
+	}{
+		{body: `{"title":"Sprint {{week}}","email":"team@example.com","todos":[{"title":"Plan","priority":"high"}]}`, code: http.StatusCreated},
+		{body: `{"title":"Sprint {{week}}","email":"team@example.com","todos":[{"priority":"high"}]}`, code: http.StatusBadRequest},
+		{body: `{"title":"Sprint {{week}}","todos":[]}`, code: http.StatusBadRequest},
+		{body: `{"title":"` + strings.Repeat("a", 46) + `","email":"team@example.com","todos":[]}`, code: http.StatusBadRequest},
+		{body: `{"title":"Sprint {{week}}","email":"team@example.com","todos":[{"title":"` + strings.Repeat("a", 46) + `","priority":"high"}]}`, code: http.StatusBadRequest},
+	} {
+		req, err := http.NewRequest(echo.POST, "/template", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/template")
+		err = handler.Store(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	mockUCase := new(mocks.TemplateUsecaseMock)
+	mockUCase.On("Delete", mock.Anything, int64(3)).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/template/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("template/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	handler := activityHTTP.TemplateHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestInstantiate(t *testing.T) {
+	mockUCase := new(mocks.TemplateUsecaseMock)
+	mockUCase.On("Instantiate", mock.Anything, int64(3)).Return(domain.Activity{ID: 4, Title: "Sprint 2021-W10"}, nil).Once()
+	mockUCase.On("Instantiate", mock.Anything, int64(9)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	handler := activityHTTP.TemplateHandler{
+		TUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		id   string
+		code int
+	}{
+		{id: "3", code: http.StatusCreated},
+		{id: "9", code: http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(echo.POST, "/activity/from-template/"+tt.id, strings.NewReader(""))
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("activity/from-template/:templateID")
+		c.SetParamNames("templateID")
+		c.SetParamValues(tt.id)
+		err = handler.Instantiate(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
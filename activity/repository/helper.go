@@ -1,30 +1,45 @@
 package repository
 
 import (
-	"encoding/base64"
 	"time"
-)
 
-const (
-	timeFormat = "2006-01-02T15:04:05.999Z07:00" // reduce precision from RFC3339Nano as date format
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
 )
 
-// DecodeCursor will decode cursor from user for mysql
-func DecodeCursor(encodedTime string) (time.Time, error) {
-	byt, err := base64.StdEncoding.DecodeString(encodedTime)
-	if err != nil {
-		return time.Time{}, err
-	}
+// DefaultSort is the order of the activity listing, the id breaks the tie between rows created at the same time
+const DefaultSort = "created_at,id"
 
-	timeString := string(byt)
-	t, err := time.Parse(timeFormat, timeString)
+// FilterKey will tell apart the cursors of the listings with and without the archived activities
+func FilterKey(filter domain.ActivityFilter) string {
+	if filter.IncludeArchived {
+		return "include_archived=true"
+	}
+	return ""
+}
 
-	return t, err
+// CursorOf will encode the cursor pointing at the given activity of the listing with the given filter
+func CursorOf(a domain.Activity, direction string, filter domain.ActivityFilter) string {
+	return pagination.EncodeCursor(pagination.Cursor{
+		Direction: direction,
+		Values:    []string{pagination.EncodeTime(a.CreatedAt)},
+		ID:        a.ID,
+		Sort:      DefaultSort,
+		Filter:    FilterKey(filter),
+	})
 }
 
-// EncodeCursor will encode cursor from mysql to user
-func EncodeCursor(t time.Time) string {
-	timeString := t.Format(timeFormat)
+// ParseCursor will decode the cursor of the listing with the given filter along with the creation time of its row,
+// the cursor of another listing is rejected
+func ParseCursor(cursor string, filter domain.ActivityFilter) (c pagination.Cursor, createdAt time.Time, err error) {
+	c, err = pagination.DecodeCursor(cursor)
+	if err != nil || c.Filter != FilterKey(filter) || c.Sort != DefaultSort || len(c.Values) != 1 {
+		return pagination.Cursor{}, time.Time{}, domain.ErrBadParamInput
+	}
 
-	return base64.StdEncoding.EncodeToString([]byte(timeString))
+	createdAt, err = pagination.DecodeTime(c.Values[0])
+	if err != nil {
+		return pagination.Cursor{}, time.Time{}, domain.ErrBadParamInput
+	}
+	return
 }
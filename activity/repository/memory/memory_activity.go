@@ -0,0 +1,243 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/activity/repository"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+)
+
+type memoryActivityRepository struct {
+	DB *memdb.DB
+}
+
+// NewMemoryActivityRepository will create an object that represent the domain.ActivityRepository interface,
+// the activities are kept in the given in-memory database
+func NewMemoryActivityRepository(db *memdb.DB) domain.ActivityRepository {
+	return &memoryActivityRepository{db}
+}
+
+// listedBefore will tell whether the first activity comes before the second one in the listing order
+func listedBefore(a, b domain.Activity) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+func (m *memoryActivityRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.ActivityFilter) (res []domain.Activity, nextCursor string, prevCursor string, err error) {
+	direction := pagination.DirectionNext
+	var boundary domain.Activity
+	if cursor != "" {
+		c, createdAt, err := repository.ParseCursor(cursor, filter)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		direction = c.Direction
+		boundary = domain.Activity{ID: c.ID, CreatedAt: createdAt}
+	}
+
+	res = make([]domain.Activity, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, a := range t.Activities {
+			if a.DeletedAt != nil || (a.Archived && !filter.IncludeArchived) {
+				continue
+			}
+			if cursor != "" && ((direction == pagination.DirectionNext && !listedBefore(boundary, a)) ||
+				(direction == pagination.DirectionPrev && !listedBefore(a, boundary))) {
+				continue
+			}
+			res = append(res, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// the rows of the previous page are taken backward from the cursor like the DESC order of the query does
+	sort.Slice(res, func(i, j int) bool {
+		if direction == pagination.DirectionPrev {
+			return listedBefore(res[j], res[i])
+		}
+		return listedBefore(res[i], res[j])
+	})
+
+	hasMore := len(res) > int(num)
+	if hasMore {
+		res = res[:num]
+	}
+	if direction == pagination.DirectionPrev {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	if len(res) == 0 {
+		return
+	}
+
+	if hasMore || direction == pagination.DirectionPrev {
+		nextCursor = repository.CursorOf(res[len(res)-1], pagination.DirectionNext, filter)
+	}
+	if (hasMore && direction == pagination.DirectionPrev) || (cursor != "" && direction == pagination.DirectionNext) {
+		prevCursor = repository.CursorOf(res[0], pagination.DirectionPrev, filter)
+	}
+
+	return
+}
+
+// getOne will give the activity with the lowest id among the ones matching, in or out of the trash as asked
+func (m *memoryActivityRepository) getOne(ctx context.Context, deleted bool, match func(a domain.Activity) bool) (res domain.Activity, err error) {
+	found := false
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, a := range t.Activities {
+			if (a.DeletedAt != nil) != deleted || !match(a) {
+				continue
+			}
+			if !found || a.ID < res.ID {
+				res = a
+				found = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	if !found {
+		return domain.Activity{}, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *memoryActivityRepository) GetByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	return m.getOne(ctx, false, func(a domain.Activity) bool {
+		return a.ID == id
+	})
+}
+
+// GetByTitle will match the title regardless of case, like the collation of the title column does
+func (m *memoryActivityRepository) GetByTitle(ctx context.Context, title string) (res domain.Activity, err error) {
+	return m.getOne(ctx, false, func(a domain.Activity) bool {
+		return strings.EqualFold(a.Title, title)
+	})
+}
+
+func (m *memoryActivityRepository) Store(ctx context.Context, a *domain.Activity) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		id := t.NextID("activity")
+		t.Activities[id] = domain.Activity{
+			ID:        id,
+			Email:     a.Email,
+			Title:     a.Title,
+			UpdatedAt: memdb.Time(a.UpdatedAt),
+			CreatedAt: memdb.Time(a.CreatedAt),
+		}
+		a.ID = id
+		return nil
+	})
+}
+
+// update will write the change to the activity out of the trash, a missing one is reported the way the mysql repository does
+func (m *memoryActivityRepository) update(ctx context.Context, id int64, change func(a *domain.Activity)) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		a, ok := t.Activities[id]
+		if !ok || a.DeletedAt != nil {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		change(&a)
+		t.Activities[id] = a
+		return nil
+	})
+}
+
+func (m *memoryActivityRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	return m.update(ctx, id, func(a *domain.Activity) {
+		a.DeletedAt = memdb.TimePtr(&deletedAt)
+	})
+}
+
+func (m *memoryActivityRepository) Update(ctx context.Context, ar *domain.Activity) (err error) {
+	return m.update(ctx, ar.ID, func(a *domain.Activity) {
+		a.Email = ar.Email
+		a.Title = ar.Title
+		a.UpdatedAt = memdb.Time(ar.UpdatedAt)
+	})
+}
+
+func (m *memoryActivityRepository) SetArchived(ctx context.Context, id int64, archived bool, updatedAt time.Time) (err error) {
+	return m.update(ctx, id, func(a *domain.Activity) {
+		a.Archived = archived
+		a.UpdatedAt = memdb.Time(updatedAt)
+	})
+}
+
+func (m *memoryActivityRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	return m.getOne(ctx, true, func(a domain.Activity) bool {
+		return a.ID == id
+	})
+}
+
+func (m *memoryActivityRepository) FetchDeleted(ctx context.Context) (res []domain.Activity, err error) {
+	res = make([]domain.Activity, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, a := range t.Activities {
+			if a.DeletedAt != nil {
+				res = append(res, a)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].DeletedAt.Equal(*res[j].DeletedAt) {
+			return res[i].DeletedAt.After(*res[j].DeletedAt)
+		}
+		return res[i].ID > res[j].ID
+	})
+	return
+}
+
+func (m *memoryActivityRepository) Restore(ctx context.Context, id int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		a, ok := t.Activities[id]
+		if !ok || a.DeletedAt == nil {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		a.DeletedAt = nil
+		t.Activities[id] = a
+		return nil
+	})
+}
+
+// Purge will leave the activities still referred by a todo, even one in the trash, to a later purge
+func (m *memoryActivityRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		referred := map[int64]bool{}
+		for _, todo := range t.Todos {
+			referred[todo.ActivityGroupID.ID] = true
+		}
+
+		for id, a := range t.Activities {
+			if a.DeletedAt != nil && a.DeletedAt.Before(before) && !referred[id] {
+				delete(t.Activities, id)
+				purged++
+			}
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,249 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	activityMemoryRepo "github.com/bxcodec/go-clean-arch/activity/repository/memory"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+	todoMemoryRepo "github.com/bxcodec/go-clean-arch/todo/repository/memory"
+)
+
+func storeActivities(t *testing.T, repo domain.ActivityRepository, createdAt time.Time, titles ...string) []domain.Activity {
+	res := make([]domain.Activity, 0, len(titles))
+	for _, title := range titles {
+		a := domain.Activity{Email: "Bagus@gmail.com", Title: title, UpdatedAt: createdAt, CreatedAt: createdAt}
+		assert.NoError(t, repo.Store(context.TODO(), &a))
+		res = append(res, a)
+	}
+	return res
+}
+
+func titles(list []domain.Activity) []string {
+	res := make([]string, 0, len(list))
+	for _, a := range list {
+		res = append(res, a.Title)
+	}
+	return res
+}
+
+func TestFetch(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	// the first two share their creation time, the id breaks the tie
+	stored := storeActivities(t, repo, createdAt, "title 1", "title 2")
+	stored = append(stored, storeActivities(t, repo, createdAt.Add(time.Hour), "title 3")...)
+
+	t.Run("first-page", func(t *testing.T) {
+		list, nextCursor, prevCursor, err := repo.Fetch(context.TODO(), "", int64(2), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 1", "title 2"}, titles(list))
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+
+		list, nextCursor, prevCursor, err = repo.Fetch(context.TODO(), nextCursor, int64(2), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 3"}, titles(list))
+		assert.Empty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+
+		list, nextCursor, prevCursor, err = repo.Fetch(context.TODO(), prevCursor, int64(2), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 1", "title 2"}, titles(list))
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+	})
+
+	t.Run("prev-page-with-more", func(t *testing.T) {
+		_, nextCursor, _, err := repo.Fetch(context.TODO(), "", int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		_, nextCursor, _, err = repo.Fetch(context.TODO(), nextCursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		list, _, prevCursor, err := repo.Fetch(context.TODO(), nextCursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 3"}, titles(list))
+
+		list, nextCursor, prevCursor, err = repo.Fetch(context.TODO(), prevCursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 2"}, titles(list))
+		assert.NotEmpty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		assert.NoError(t, repo.SetArchived(context.TODO(), stored[0].ID, true, createdAt))
+		defer func() {
+			assert.NoError(t, repo.SetArchived(context.TODO(), stored[0].ID, false, createdAt))
+		}()
+
+		list, _, _, err := repo.Fetch(context.TODO(), "", int64(10), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 2", "title 3"}, titles(list))
+
+		list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), domain.ActivityFilter{IncludeArchived: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"title 1", "title 2", "title 3"}, titles(list))
+	})
+
+	t.Run("cursor-of-another-listing", func(t *testing.T) {
+		_, nextCursor, _, err := repo.Fetch(context.TODO(), "", int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+
+		_, _, _, err = repo.Fetch(context.TODO(), nextCursor, int64(1), domain.ActivityFilter{IncludeArchived: true})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		_, _, _, err = repo.Fetch(context.TODO(), "invalid", int64(1), domain.ActivityFilter{})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 500000000, time.FixedZone("WIB", 7*60*60))
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, createdAt, "title 1")
+
+	a, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "title 1", a.Title)
+	// stored the way a DATETIME column does
+	assert.Equal(t, time.Date(2021, 3, 1, 3, 0, 1, 0, time.UTC), a.CreatedAt)
+
+	_, err = repo.GetByID(context.TODO(), stored[0].ID+1)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestStore(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	a := &domain.Activity{Email: "Bagus@gmail.com", Title: "Judul", Archived: true}
+
+	assert.NoError(t, repo.Store(context.TODO(), a))
+	assert.Equal(t, int64(1), a.ID)
+
+	stored, err := repo.GetByID(context.TODO(), a.ID)
+	assert.NoError(t, err)
+	// the archived flag is only written by SetArchived
+	assert.False(t, stored.Archived)
+}
+
+func TestGetByTitle(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, time.Now(), "Judul")
+
+	a, err := repo.GetByTitle(context.TODO(), "judul")
+	assert.NoError(t, err)
+	assert.Equal(t, stored[0].ID, a.ID)
+
+	_, err = repo.GetByTitle(context.TODO(), "unknown")
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestDelete(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, time.Now(), "Judul")
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, time.Now()))
+	_, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repo.GetByTitle(context.TODO(), "Judul")
+	assert.Equal(t, domain.ErrNotFound, err)
+
+	assert.Error(t, repo.Delete(context.TODO(), stored[0].ID, time.Now()))
+}
+
+func TestUpdate(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, time.Now(), "Judul")
+	assert.NoError(t, repo.SetArchived(context.TODO(), stored[0].ID, true, time.Now()))
+
+	ar := &domain.Activity{ID: stored[0].ID, Email: "Iman@gmail.com", Title: "Judul baru", UpdatedAt: time.Now()}
+	assert.NoError(t, repo.Update(context.TODO(), ar))
+
+	a, err := repo.GetByID(context.TODO(), ar.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Iman@gmail.com", a.Email)
+	assert.Equal(t, "Judul baru", a.Title)
+	// the archived flag is left as it is
+	assert.True(t, a.Archived)
+
+	assert.Error(t, repo.Update(context.TODO(), &domain.Activity{ID: ar.ID + 1, Title: "unknown"}))
+}
+
+func TestSetArchived(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, time.Now(), "Judul")
+
+	assert.NoError(t, repo.SetArchived(context.TODO(), stored[0].ID, true, time.Now()))
+	a, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.True(t, a.Archived)
+
+	assert.Error(t, repo.SetArchived(context.TODO(), stored[0].ID+1, true, time.Now()))
+}
+
+func TestFetchDeleted(t *testing.T) {
+	deletedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, deletedAt, "title 1", "title 2", "title 3")
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, deletedAt.Add(time.Hour)))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[1].ID, deletedAt))
+
+	list, err := repo.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 1", "title 2"}, titles(list))
+	assert.Equal(t, deletedAt.Add(time.Hour), *list[0].DeletedAt)
+}
+
+func TestGetDeletedByID(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, time.Now(), "Judul")
+
+	_, err := repo.GetDeletedByID(context.TODO(), stored[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, time.Now()))
+	a, err := repo.GetDeletedByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, a.DeletedAt)
+}
+
+func TestRestore(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryActivityRepository(memdb.New())
+	stored := storeActivities(t, repo, time.Now(), "Judul")
+
+	assert.Error(t, repo.Restore(context.TODO(), stored[0].ID))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, time.Now()))
+	assert.NoError(t, repo.Restore(context.TODO(), stored[0].ID))
+
+	a, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Nil(t, a.DeletedAt)
+}
+
+func TestPurge(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	db := memdb.New()
+	repo := activityMemoryRepo.NewMemoryActivityRepository(db)
+	stored := storeActivities(t, repo, now, "title 1", "title 2", "title 3")
+
+	// still referred by a todo in the trash
+	todo := &domain.Todo{ActivityGroupID: stored[1], Title: "todo", Priority: domain.PriorityNormal}
+	todoRepo := todoMemoryRepo.NewMemoryTodoRepository(db)
+	assert.NoError(t, todoRepo.Store(context.TODO(), todo))
+	assert.NoError(t, todoRepo.Delete(context.TODO(), todo.ID, now))
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, now))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[1].ID, now))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[2].ID, now.Add(time.Hour)))
+
+	purged, err := repo.Purge(context.TODO(), now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	list, err := repo.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 3", "title 2"}, titles(list))
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+)
+
+type memoryTemplateRepository struct {
+	DB *memdb.DB
+}
+
+// NewMemoryTemplateRepository will create an object that represent the domain.TemplateRepository interface,
+// the templates are kept in the given in-memory database
+func NewMemoryTemplateRepository(db *memdb.DB) domain.TemplateRepository {
+	return &memoryTemplateRepository{db}
+}
+
+// storedTemplate will copy the template as a row, so the caller keeps its todos to itself
+func storedTemplate(t domain.Template) domain.Template {
+	t.Todos = append(make([]domain.TemplateTodo, 0, len(t.Todos)), t.Todos...)
+	t.UpdatedAt = memdb.Time(t.UpdatedAt)
+	t.CreatedAt = memdb.Time(t.CreatedAt)
+	return t
+}
+
+func (m *memoryTemplateRepository) Fetch(ctx context.Context) (res []domain.Template, err error) {
+	res = make([]domain.Template, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, tpl := range t.Templates {
+			res = append(res, storedTemplate(tpl))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the titles compare regardless of case like the collation of the title column does
+	sort.Slice(res, func(i, j int) bool {
+		left, right := strings.ToLower(res[i].Title), strings.ToLower(res[j].Title)
+		if left != right {
+			return left < right
+		}
+		return res[i].ID < res[j].ID
+	})
+	return
+}
+
+func (m *memoryTemplateRepository) GetByID(ctx context.Context, id int64) (res domain.Template, err error) {
+	found := false
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		res, found = t.Templates[id]
+		return nil
+	})
+	if err != nil {
+		return domain.Template{}, err
+	}
+
+	if !found {
+		return domain.Template{}, domain.ErrNotFound
+	}
+	return storedTemplate(res), nil
+}
+
+func (m *memoryTemplateRepository) Store(ctx context.Context, t *domain.Template) (err error) {
+	return m.DB.Exec(ctx, func(tables *memdb.Tables) error {
+		row := storedTemplate(*t)
+		row.ID = tables.NextID("template")
+		tables.Templates[row.ID] = row
+		t.ID = row.ID
+		return nil
+	})
+}
+
+// Update will replace the template along with every one of its todos
+func (m *memoryTemplateRepository) Update(ctx context.Context, t *domain.Template) (err error) {
+	return m.DB.Exec(ctx, func(tables *memdb.Tables) error {
+		row, ok := tables.Templates[t.ID]
+		if !ok {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		updated := storedTemplate(*t)
+		updated.CreatedAt = row.CreatedAt
+		tables.Templates[t.ID] = updated
+		return nil
+	})
+}
+
+// Delete will delete the template, its todos go along as they are stored with it
+func (m *memoryTemplateRepository) Delete(ctx context.Context, id int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		if _, ok := t.Templates[id]; !ok {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		delete(t.Templates, id)
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	activityMemoryRepo "github.com/bxcodec/go-clean-arch/activity/repository/memory"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+)
+
+func TestFetchTemplate(t *testing.T) {
+	now := time.Now()
+	repo := activityMemoryRepo.NewMemoryTemplateRepository(memdb.New())
+	review := &domain.Template{Title: "Weekly review", Email: "team@example.com", UpdatedAt: now, CreatedAt: now}
+	sprint := &domain.Template{
+		Title: "sprint {{week}}", Email: "team@example.com", UpdatedAt: now, CreatedAt: now,
+		Todos: []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}, {Title: "Demo {{date}}", Priority: domain.PriorityNormal}},
+	}
+	assert.NoError(t, repo.Store(context.TODO(), review))
+	assert.NoError(t, repo.Store(context.TODO(), sprint))
+
+	list, err := repo.Fetch(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		// the titles are ordered regardless of case
+		assert.Equal(t, sprint.ID, list[0].ID)
+		assert.Equal(t, sprint.Todos, list[0].Todos)
+		assert.Equal(t, []domain.TemplateTodo{}, list[1].Todos)
+	}
+}
+
+func TestGetTemplateByID(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryTemplateRepository(memdb.New())
+	tpl := &domain.Template{Title: "Sprint", Email: "team@example.com", Todos: []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}}}
+	assert.NoError(t, repo.Store(context.TODO(), tpl))
+
+	// the stored todos are not shared with the caller
+	tpl.Todos[0].Title = "changed"
+	res, err := repo.GetByID(context.TODO(), tpl.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Plan", res.Todos[0].Title)
+
+	_, err = repo.GetByID(context.TODO(), int64(5))
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestStoreTemplate(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryTemplateRepository(memdb.New())
+	first := &domain.Template{Title: "Sprint", Email: "team@example.com"}
+	second := &domain.Template{Title: "Sprint", Email: "team@example.com"}
+
+	assert.NoError(t, repo.Store(context.TODO(), first))
+	assert.NoError(t, repo.Store(context.TODO(), second))
+	assert.Equal(t, int64(1), first.ID)
+	assert.Equal(t, int64(2), second.ID)
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	repo := activityMemoryRepo.NewMemoryTemplateRepository(memdb.New())
+	tpl := &domain.Template{
+		Title: "Sprint", Email: "team@example.com", UpdatedAt: createdAt, CreatedAt: createdAt,
+		Todos: []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}, {Title: "Demo", Priority: domain.PriorityNormal}},
+	}
+	assert.NoError(t, repo.Store(context.TODO(), tpl))
+
+	updated := &domain.Template{
+		ID: tpl.ID, Title: "Sprint {{week}}", Email: "team@example.com", UpdatedAt: createdAt.Add(time.Hour),
+		Todos: []domain.TemplateTodo{{Title: "Retro", Priority: domain.PriorityLow}},
+	}
+	assert.NoError(t, repo.Update(context.TODO(), updated))
+
+	res, err := repo.GetByID(context.TODO(), tpl.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Sprint {{week}}", res.Title)
+	assert.Equal(t, updated.Todos, res.Todos)
+	assert.Equal(t, createdAt, res.CreatedAt)
+
+	assert.Error(t, repo.Update(context.TODO(), &domain.Template{ID: tpl.ID + 1}))
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	repo := activityMemoryRepo.NewMemoryTemplateRepository(memdb.New())
+	tpl := &domain.Template{Title: "Sprint", Email: "team@example.com"}
+	assert.NoError(t, repo.Store(context.TODO(), tpl))
+
+	assert.NoError(t, repo.Delete(context.TODO(), tpl.ID))
+	_, err := repo.GetByID(context.TODO(), tpl.ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Error(t, repo.Delete(context.TODO(), tpl.ID))
+}
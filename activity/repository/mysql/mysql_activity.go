@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/bxcodec/go-clean-arch/activity/repository"
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
 )
 
 type mysqlArticleRepository struct {
@@ -21,7 +24,7 @@ func NewMysqlActivityRepository(Conn *sql.DB) domain.ActivityRepository {
 }
 
 func (m *mysqlArticleRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Activity, err error) {
-	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
 	if err != nil {
 		logrus.Error(err)
 		return nil, err
@@ -41,6 +44,10 @@ func (m *mysqlArticleRepository) fetch(ctx context.Context, query string, args .
 			&t.ID,
 			&t.Email,
 			&t.Title,
+			&t.Archived,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.DeletedAt,
 		)
 
 		if err != nil {
@@ -53,29 +60,70 @@ func (m *mysqlArticleRepository) fetch(ctx context.Context, query string, args .
 	return result, nil
 }
 
-func (m *mysqlArticleRepository) Fetch(ctx context.Context, cursor string, num int64) (res []domain.Activity, nextCursor string, err error) {
-	query := `SELECT id, email, title
-  						FROM activity WHERE created_at > ? ORDER BY created_at LIMIT ? `
+func (m *mysqlArticleRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.ActivityFilter) (res []domain.Activity, nextCursor string, prevCursor string, err error) {
+	where := " WHERE deleted_at IS NULL"
+	if !filter.IncludeArchived {
+		where += " AND archived = 0"
+	}
+	args := make([]interface{}, 0)
+
+	direction := pagination.DirectionNext
+	if cursor != "" {
+		c, createdAt, err := repository.ParseCursor(cursor, filter)
+		if err != nil {
+			return nil, "", "", err
+		}
 
-	decodedCursor, err := repository.DecodeCursor(cursor)
-	if err != nil && cursor != "" {
-		return nil, "", domain.ErrBadParamInput
+		direction = c.Direction
+		operator := ">"
+		if direction == pagination.DirectionPrev {
+			operator = "<"
+		}
+		where += " AND (created_at " + operator + " ? OR (created_at = ? AND id " + operator + " ?))"
+		args = append(args, createdAt, createdAt, c.ID)
 	}
 
-	res, err = m.fetch(ctx, query, decodedCursor, num)
+	order := "ASC"
+	if direction == pagination.DirectionPrev {
+		order = "DESC"
+	}
+
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity` + where + ` ORDER BY created_at ` + order + `, id ` + order + ` LIMIT ? `
+
+	// fetch one more row than requested to know whether there is another page in the same direction
+	args = append(args, num+1)
+	res, err = m.fetch(ctx, query, args...)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
+	}
+
+	hasMore := len(res) > int(num)
+	if hasMore {
+		res = res[:num]
+	}
+	if direction == pagination.DirectionPrev {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	if len(res) == 0 {
+		return
 	}
 
-	if len(res) == int(num) {
-		nextCursor = repository.EncodeCursor(res[len(res)-1].CreatedAt)
+	if hasMore || direction == pagination.DirectionPrev {
+		nextCursor = repository.CursorOf(res[len(res)-1], pagination.DirectionNext, filter)
+	}
+	if (hasMore && direction == pagination.DirectionPrev) || (cursor != "" && direction == pagination.DirectionNext) {
+		prevCursor = repository.CursorOf(res[0], pagination.DirectionPrev, filter)
 	}
 
 	return
 }
+
 func (m *mysqlArticleRepository) GetByID(ctx context.Context, id int64) (res domain.Activity, err error) {
-	query := `SELECT id, email, title
-  						FROM activity WHERE ID = ?`
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE ID = ? AND deleted_at IS NULL`
 
 	list, err := m.fetch(ctx, query, id)
 	if err != nil {
@@ -92,8 +140,8 @@ func (m *mysqlArticleRepository) GetByID(ctx context.Context, id int64) (res dom
 }
 
 func (m *mysqlArticleRepository) GetByTitle(ctx context.Context, title string) (res domain.Activity, err error) {
-	query := `SELECT id, email, title
-  						FROM activity WHERE title = ?`
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE title = ? AND deleted_at IS NULL`
 
 	list, err := m.fetch(ctx, query, title)
 	if err != nil {
@@ -109,13 +157,13 @@ func (m *mysqlArticleRepository) GetByTitle(ctx context.Context, title string) (
 }
 
 func (m *mysqlArticleRepository) Store(ctx context.Context, a *domain.Activity) (err error) {
-	query := `INSERT activity SET email=?, title=?`
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	query := `INSERT activity SET email=?, title=?, updated_at=?, created_at=?`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, a.Email, a.Title)
+	res, err := stmt.ExecContext(ctx, a.Email, a.Title, a.UpdatedAt, a.CreatedAt)
 	if err != nil {
 		return
 	}
@@ -127,15 +175,15 @@ func (m *mysqlArticleRepository) Store(ctx context.Context, a *domain.Activity)
 	return
 }
 
-func (m *mysqlArticleRepository) Delete(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM activity WHERE id = ?"
+func (m *mysqlArticleRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE activity set deleted_at=? WHERE ID = ? AND deleted_at IS NULL"
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id)
 	if err != nil {
 		return
 	}
@@ -153,9 +201,9 @@ func (m *mysqlArticleRepository) Delete(ctx context.Context, id int64) (err erro
 	return
 }
 func (m *mysqlArticleRepository) Update(ctx context.Context, ar *domain.Activity) (err error) {
-	query := `UPDATE activity set email=?, title=?, updated_at=? WHERE ID = ?`
+	query := `UPDATE activity set email=?, title=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
@@ -175,3 +223,92 @@ func (m *mysqlArticleRepository) Update(ctx context.Context, ar *domain.Activity
 
 	return
 }
+
+func (m *mysqlArticleRepository) SetArchived(ctx context.Context, id int64, archived bool, updatedAt time.Time) (err error) {
+	query := `UPDATE activity set archived=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, archived, updatedAt, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *mysqlArticleRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *mysqlArticleRepository) FetchDeleted(ctx context.Context) (res []domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *mysqlArticleRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := `UPDATE activity set deleted_at=NULL WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+// Purge will leave the activities still referred by a todo, even one in the trash, to a later purge
+func (m *mysqlArticleRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	query := `DELETE FROM activity WHERE deleted_at < ? AND NOT EXISTS (SELECT 1 FROM todo WHERE todo.activity_group_id = activity.id)`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return
+	}
+	return res.RowsAffected()
+}
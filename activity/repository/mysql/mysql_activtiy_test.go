@@ -8,9 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 
-	"github.com/bxcodec/go-clean-arch/activity/repository"
 	activityMysqlRepo "github.com/bxcodec/go-clean-arch/activity/repository/mysql"
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
 )
 
 func TestFetch(t *testing.T) {
@@ -19,25 +19,115 @@ func TestFetch(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
 	mockArticles := []domain.Activity{
 		{
-			ID: 1, Email: "Bagus@gmail.com", Title: "title 1", UpdatedAt: time.Now(), CreatedAt: time.Now(),
+			ID: 1, Email: "Bagus@gmail.com", Title: "title 1", UpdatedAt: createdAt, CreatedAt: createdAt,
+		},
+		{
+			ID: 2, Email: "Bagus@gmail.com", Title: "title 2", UpdatedAt: createdAt, CreatedAt: createdAt,
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "email", "title", "updated_at", "created_at"}).
-		AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt)
-
-	query := "SELECT id, email, title, updated_at, created_at FROM activity WHERE created_at > \\? ORDER BY created_at LIMIT \\?"
-
-	mock.ExpectQuery(query).WillReturnRows(rows)
-	a := activityMysqlRepo.NewMysqlActivityRepository(db)
-	cursor := repository.EncodeCursor(mockArticles[0].CreatedAt)
-	num := int64(1)
-	list, nextCursor, err := a.Fetch(context.TODO(), cursor, num)
-	assert.NotEmpty(t, nextCursor)
-	assert.NoError(t, err)
-	assert.Len(t, list, 1)
+	t.Run("first-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, false, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, nil).
+			AddRow(mockArticles[1].ID, mockArticles[1].Email, mockArticles[1].Title, false, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE deleted_at IS NULL AND archived = 0 ORDER BY created_at ASC, id ASC LIMIT \\?"
+
+		mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+		a := activityMysqlRepo.NewMysqlActivityRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), "", int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 1)
+
+		decoded, err := pagination.DecodeCursor(nextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, mockArticles[0].ID, decoded.ID)
+		assert.Equal(t, pagination.DirectionNext, decoded.Direction)
+	})
+
+	t.Run("next-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[1].ID, mockArticles[1].Email, mockArticles[1].Title, false, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity " +
+			"WHERE deleted_at IS NULL AND archived = 0 AND \\(created_at > \\? OR \\(created_at = \\? AND id > \\?\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\?"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockArticles[0].ID,
+			Sort:      "created_at,id",
+		})
+		mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), mockArticles[0].ID, int64(2)).WillReturnRows(rows)
+		a := activityMysqlRepo.NewMysqlActivityRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("prev-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, false, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity " +
+			"WHERE deleted_at IS NULL AND archived = 0 AND \\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\?"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionPrev,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockArticles[1].ID,
+			Sort:      "created_at,id",
+		})
+		mock.ExpectQuery(query).WillReturnRows(rows)
+		a := activityMysqlRepo.NewMysqlActivityRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("tampered-cursor", func(t *testing.T) {
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockArticles[0].ID,
+			Sort:      "created_at,id",
+		})
+		a := activityMysqlRepo.NewMysqlActivityRepository(db)
+		_, _, _, err := a.Fetch(context.TODO(), "x"+cursor, int64(1), domain.ActivityFilter{})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	t.Run("include-archived", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, true, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, nil).
+			AddRow(mockArticles[1].ID, mockArticles[1].Email, mockArticles[1].Title, false, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE deleted_at IS NULL ORDER BY created_at ASC, id ASC LIMIT \\?"
+
+		mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+		a := activityMysqlRepo.NewMysqlActivityRepository(db)
+		filter := domain.ActivityFilter{IncludeArchived: true}
+		list, nextCursor, _, err := a.Fetch(context.TODO(), "", int64(1), filter)
+		assert.NoError(t, err)
+		if assert.Len(t, list, 1) {
+			assert.True(t, list[0].Archived)
+		}
+
+		// the cursor belongs to the listing it was given with
+		_, _, _, err = a.Fetch(context.TODO(), nextCursor, int64(1), domain.ActivityFilter{})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetByID(t *testing.T) {
@@ -46,10 +136,10 @@ func TestGetByID(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "email", "title", "updated_at", "created_at"}).
-		AddRow(1, "title 1", "Content 1", time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, "title 1", "Content 1", false, time.Now(), time.Now(), nil)
 
-	query := "SELECT id, email, title, updated_at, created_at FROM activity WHERE ID = \\?"
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE ID = \\? AND deleted_at IS NULL"
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	a := activityMysqlRepo.NewMysqlActivityRepository(db)
@@ -74,9 +164,9 @@ func TestStore(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "INSERT activity SET email =?, title=?, updated_at=?, created_at=?"
+	query := "INSERT activity SET email=\\?, title=\\?, updated_at=\\?, created_at=\\?"
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(ar.Email, ar.Title, ar.CreatedAt, ar.UpdatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(ar.Email, ar.Title, ar.UpdatedAt, ar.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	a := activityMysqlRepo.NewMysqlActivityRepository(db)
 
@@ -91,10 +181,10 @@ func TestGetByTitle(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "email", "title", "updated_at", "created_at"}).
-		AddRow(1, "title 1", "Content 1", time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, "title 1", "Content 1", false, time.Now(), time.Now(), nil)
 
-	query := "SELECT id, email, title, updated_at, created_at FROM activity WHERE title = \\?"
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE title = \\? AND deleted_at IS NULL"
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 	a := activityMysqlRepo.NewMysqlActivityRepository(db)
@@ -111,15 +201,16 @@ func TestDelete(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "DELETE FROM activity WHERE id = \\?"
+	now := time.Now()
+	query := "UPDATE activity set deleted_at=\\? WHERE ID = \\? AND deleted_at IS NULL"
 
 	prep := mock.ExpectPrepare(query)
-	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+	prep.ExpectExec().WithArgs(now, 12).WillReturnResult(sqlmock.NewResult(12, 1))
 
 	a := activityMysqlRepo.NewMysqlActivityRepository(db)
 
 	num := int64(12)
-	err = a.Delete(context.TODO(), num)
+	err = a.Delete(context.TODO(), num, now)
 	assert.NoError(t, err)
 }
 
@@ -138,7 +229,7 @@ func TestUpdate(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	query := "UPDATE activity set email=\\, title=\\?, updated_at=\\? WHERE ID = \\?"
+	query := "UPDATE activity set email=\\?, title=\\?, updated_at=\\? WHERE ID = \\? AND deleted_at IS NULL"
 
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().WithArgs(ar.Email, ar.Title, ar.UpdatedAt, ar.ID).WillReturnResult(sqlmock.NewResult(12, 1))
@@ -148,3 +239,99 @@ func TestUpdate(t *testing.T) {
 	err = a.Update(context.TODO(), ar)
 	assert.NoError(t, err)
 }
+
+func TestSetArchived(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	query := "UPDATE activity set archived=\\?, updated_at=\\? WHERE ID = \\? AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(true, now, 12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := activityMysqlRepo.NewMysqlActivityRepository(db)
+
+	err = a.SetArchived(context.TODO(), int64(12), true, now)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	deletedAt := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, "Bagus@gmail.com", "title 1", false, time.Now(), time.Now(), deletedAt)
+
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	a := activityMysqlRepo.NewMysqlActivityRepository(db)
+
+	list, err := a.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) && assert.NotNil(t, list[0].DeletedAt) {
+		assert.Equal(t, deletedAt, *list[0].DeletedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeletedByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE ID = \\? AND deleted_at IS NOT NULL"
+
+	mock.ExpectQuery(query).WithArgs(int64(5)).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}))
+	a := activityMysqlRepo.NewMysqlActivityRepository(db)
+
+	_, err = a.GetDeletedByID(context.TODO(), int64(5))
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE activity set deleted_at=NULL WHERE ID = \\? AND deleted_at IS NOT NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := activityMysqlRepo.NewMysqlActivityRepository(db)
+
+	err = a.Restore(context.TODO(), int64(12))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	before := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	query := "DELETE FROM activity WHERE deleted_at < \\? AND NOT EXISTS \\(SELECT 1 FROM todo WHERE todo.activity_group_id = activity.id\\)"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := activityMysqlRepo.NewMysqlActivityRepository(db)
+
+	purged, err := a.Purge(context.TODO(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,223 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type mysqlTemplateRepository struct {
+	Conn *sql.DB
+}
+
+// NewMysqlTemplateRepository will create an object that represent the domain.TemplateRepository interface
+func NewMysqlTemplateRepository(Conn *sql.DB) domain.TemplateRepository {
+	return &mysqlTemplateRepository{Conn}
+}
+
+func (m *mysqlTemplateRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Template, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Template, 0)
+	for rows.Next() {
+		t := domain.Template{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Title,
+			&t.Email,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err = m.fetchTodos(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fetchTodos will fill the todos of every given template with a single query
+func (m *mysqlTemplateRepository) fetchTodos(ctx context.Context, templates []domain.Template) error {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*domain.Template, len(templates))
+	args := make([]interface{}, 0, len(templates))
+	for i := range templates {
+		templates[i].Todos = []domain.TemplateTodo{}
+		byID[templates[i].ID] = &templates[i]
+		args = append(args, templates[i].ID)
+	}
+
+	query := `SELECT template_id, title, priority FROM template_todo WHERE template_id IN (` + placeholders(len(args)) + `) ORDER BY position ASC`
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		var templateID int64
+		todo := domain.TemplateTodo{}
+		if err = rows.Scan(&templateID, &todo.Title, &todo.Priority); err != nil {
+			logrus.Error(err)
+			return err
+		}
+		if t, ok := byID[templateID]; ok {
+			t.Todos = append(t.Todos, todo)
+		}
+	}
+	return rows.Err()
+}
+
+// storeTodos will insert the todos of the template keeping their order
+func (m *mysqlTemplateRepository) storeTodos(ctx context.Context, t *domain.Template) error {
+	if len(t.Todos) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(t.Todos))
+	args := make([]interface{}, 0, len(t.Todos)*4)
+	for i, todo := range t.Todos {
+		values = append(values, "(?, ?, ?, ?)")
+		args = append(args, t.ID, i, todo.Title, todo.Priority)
+	}
+
+	query := `INSERT INTO template_todo (template_id, position, title, priority) VALUES ` + strings.Join(values, ", ")
+	_, err := transaction.Conn(ctx, m.Conn).ExecContext(ctx, query, args...)
+	return err
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func (m *mysqlTemplateRepository) Fetch(ctx context.Context) (res []domain.Template, err error) {
+	query := `SELECT id, title, email, updated_at, created_at FROM template ORDER BY title ASC, id ASC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *mysqlTemplateRepository) GetByID(ctx context.Context, id int64) (res domain.Template, err error) {
+	query := `SELECT id, title, email, updated_at, created_at FROM template WHERE ID = ?`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Template{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	return list[0], nil
+}
+
+func (m *mysqlTemplateRepository) Store(ctx context.Context, t *domain.Template) (err error) {
+	query := `INSERT template SET title=?, email=?, updated_at=?, created_at=?`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, t.Title, t.Email, t.UpdatedAt, t.CreatedAt)
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	t.ID = lastID
+
+	return m.storeTodos(ctx, t)
+}
+
+// Update will replace the template along with every one of its todos
+func (m *mysqlTemplateRepository) Update(ctx context.Context, t *domain.Template) (err error) {
+	query := `UPDATE template set title=?, email=?, updated_at=? WHERE ID = ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, t.Title, t.Email, t.UpdatedAt, t.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	_, err = transaction.Conn(ctx, m.Conn).ExecContext(ctx, "DELETE FROM template_todo WHERE template_id = ?", t.ID)
+	if err != nil {
+		return
+	}
+	return m.storeTodos(ctx, t)
+}
+
+// Delete will delete the template, its todos go along through the foreign key
+func (m *mysqlTemplateRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := "DELETE FROM template WHERE id = ?"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,132 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	activityMysqlRepo "github.com/bxcodec/go-clean-arch/activity/repository/mysql"
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+func TestFetchTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "title", "email", "updated_at", "created_at"}).
+		AddRow(1, "Sprint {{week}}", "team@example.com", now, now).
+		AddRow(2, "Weekly review", "team@example.com", now, now)
+	mock.ExpectQuery("SELECT id, title, email, updated_at, created_at FROM template ORDER BY title ASC, id ASC").WillReturnRows(rows)
+
+	todoRows := sqlmock.NewRows([]string{"template_id", "title", "priority"}).
+		AddRow(1, "Plan", 4).
+		AddRow(1, "Demo {{date}}", 3)
+	mock.ExpectQuery("SELECT template_id, title, priority FROM template_todo WHERE template_id IN \\(\\?, \\?\\) ORDER BY position ASC").
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(todoRows)
+
+	a := activityMysqlRepo.NewMysqlTemplateRepository(db)
+
+	list, err := a.Fetch(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}, {Title: "Demo {{date}}", Priority: domain.PriorityNormal}}, list[0].Todos)
+		assert.Equal(t, []domain.TemplateTodo{}, list[1].Todos)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTemplateByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectQuery("SELECT id, title, email, updated_at, created_at FROM template WHERE ID = \\?").
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "email", "updated_at", "created_at"}))
+
+	a := activityMysqlRepo.NewMysqlTemplateRepository(db)
+
+	_, err = a.GetByID(context.TODO(), int64(5))
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStoreTemplate(t *testing.T) {
+	now := time.Now()
+	tp := &domain.Template{
+		Title:     "Sprint {{week}}",
+		Email:     "team@example.com",
+		Todos:     []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}, {Title: "Demo", Priority: domain.PriorityNormal}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("INSERT template SET title=\\?, email=\\?, updated_at=\\?, created_at=\\?")
+	prep.ExpectExec().WithArgs(tp.Title, tp.Email, tp.UpdatedAt, tp.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec("INSERT INTO template_todo \\(template_id, position, title, priority\\) VALUES \\(\\?, \\?, \\?, \\?\\), \\(\\?, \\?, \\?, \\?\\)").
+		WithArgs(int64(12), 0, "Plan", domain.PriorityHigh, int64(12), 1, "Demo", domain.PriorityNormal).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	a := activityMysqlRepo.NewMysqlTemplateRepository(db)
+
+	err = a.Store(context.TODO(), tp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), tp.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	now := time.Now()
+	tp := &domain.Template{
+		ID:        12,
+		Title:     "Sprint {{week}}",
+		Email:     "team@example.com",
+		Todos:     []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}},
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("UPDATE template set title=\\?, email=\\?, updated_at=\\? WHERE ID = \\?")
+	prep.ExpectExec().WithArgs(tp.Title, tp.Email, tp.UpdatedAt, tp.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec("DELETE FROM template_todo WHERE template_id = \\?").WithArgs(tp.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO template_todo \\(template_id, position, title, priority\\) VALUES \\(\\?, \\?, \\?, \\?\\)").
+		WithArgs(tp.ID, 0, "Plan", domain.PriorityHigh).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	a := activityMysqlRepo.NewMysqlTemplateRepository(db)
+
+	err = a.Update(context.TODO(), tp)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("DELETE FROM template WHERE id = \\?")
+	prep.ExpectExec().WithArgs(int64(12)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	a := activityMysqlRepo.NewMysqlTemplateRepository(db)
+
+	err = a.Delete(context.TODO(), int64(12))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
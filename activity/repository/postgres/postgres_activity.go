@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/activity/repository"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+	"github.com/bxcodec/go-clean-arch/pkg/postgresdb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type postgresActivityRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresActivityRepository will create an object that represent the domain.ActivityRepository interface
+func NewPostgresActivityRepository(Conn *sql.DB) domain.ActivityRepository {
+	return &postgresActivityRepository{Conn}
+}
+
+func (m *postgresActivityRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Activity, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Activity, 0)
+	for rows.Next() {
+		t := domain.Activity{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Email,
+			&t.Title,
+			&t.Archived,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.DeletedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *postgresActivityRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.ActivityFilter) (res []domain.Activity, nextCursor string, prevCursor string, err error) {
+	where := " WHERE deleted_at IS NULL"
+	if !filter.IncludeArchived {
+		where += " AND archived = FALSE"
+	}
+	args := postgresdb.Args{}
+
+	direction := pagination.DirectionNext
+	if cursor != "" {
+		c, createdAt, err := repository.ParseCursor(cursor, filter)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		direction = c.Direction
+		operator := ">"
+		if direction == pagination.DirectionPrev {
+			operator = "<"
+		}
+		where += " AND (created_at " + operator + " " + args.Add(createdAt) + " OR (created_at = " + args.Add(createdAt) + " AND id " + operator + " " + args.Add(c.ID) + "))"
+	}
+
+	order := "ASC"
+	if direction == pagination.DirectionPrev {
+		order = "DESC"
+	}
+
+	// fetch one more row than requested to know whether there is another page in the same direction
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity` + where + ` ORDER BY created_at ` + order + `, id ` + order + ` LIMIT ` + args.Add(num+1)
+	res, err = m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	hasMore := len(res) > int(num)
+	if hasMore {
+		res = res[:num]
+	}
+	if direction == pagination.DirectionPrev {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	if len(res) == 0 {
+		return
+	}
+
+	if hasMore || direction == pagination.DirectionPrev {
+		nextCursor = repository.CursorOf(res[len(res)-1], pagination.DirectionNext, filter)
+	}
+	if (hasMore && direction == pagination.DirectionPrev) || (cursor != "" && direction == pagination.DirectionNext) {
+		prevCursor = repository.CursorOf(res[0], pagination.DirectionPrev, filter)
+	}
+
+	return
+}
+
+func (m *postgresActivityRepository) GetByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE ID = $1 AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *postgresActivityRepository) GetByTitle(ctx context.Context, title string) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE title = $1 AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, title)
+	if err != nil {
+		return
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *postgresActivityRepository) Store(ctx context.Context, a *domain.Activity) (err error) {
+	query := `INSERT INTO activity (email, title, updated_at, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	return stmt.QueryRowContext(ctx, a.Email, a.Title, a.UpdatedAt, a.CreatedAt).Scan(&a.ID)
+}
+
+func (m *postgresActivityRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE activity set deleted_at=$1 WHERE ID = $2 AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, deletedAt, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+func (m *postgresActivityRepository) Update(ctx context.Context, ar *domain.Activity) (err error) {
+	query := `UPDATE activity set email=$1, title=$2, updated_at=$3 WHERE ID = $4 AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Email, ar.Title, ar.UpdatedAt, ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresActivityRepository) SetArchived(ctx context.Context, id int64, archived bool, updatedAt time.Time) (err error) {
+	query := `UPDATE activity set archived=$1, updated_at=$2 WHERE ID = $3 AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, archived, updatedAt, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresActivityRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE ID = $1 AND deleted_at IS NOT NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *postgresActivityRepository) FetchDeleted(ctx context.Context) (res []domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *postgresActivityRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := `UPDATE activity set deleted_at=NULL WHERE ID = $1 AND deleted_at IS NOT NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+// Purge will leave the activities still referred by a todo, even one in the trash, to a later purge
+func (m *postgresActivityRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	query := `DELETE FROM activity WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM todo WHERE todo.activity_group_id = activity.id)`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,337 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	activityPostgresRepo "github.com/bxcodec/go-clean-arch/activity/repository/postgres"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+)
+
+func TestFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockArticles := []domain.Activity{
+		{
+			ID: 1, Email: "Bagus@gmail.com", Title: "title 1", UpdatedAt: createdAt, CreatedAt: createdAt,
+		},
+		{
+			ID: 2, Email: "Bagus@gmail.com", Title: "title 2", UpdatedAt: createdAt, CreatedAt: createdAt,
+		},
+	}
+
+	t.Run("first-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, false, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, nil).
+			AddRow(mockArticles[1].ID, mockArticles[1].Email, mockArticles[1].Title, false, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE deleted_at IS NULL AND archived = FALSE ORDER BY created_at ASC, id ASC LIMIT \\$1"
+
+		mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+		a := activityPostgresRepo.NewPostgresActivityRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), "", int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 1)
+
+		decoded, err := pagination.DecodeCursor(nextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, mockArticles[0].ID, decoded.ID)
+		assert.Equal(t, pagination.DirectionNext, decoded.Direction)
+	})
+
+	t.Run("next-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[1].ID, mockArticles[1].Email, mockArticles[1].Title, false, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity " +
+			"WHERE deleted_at IS NULL AND archived = FALSE AND \\(created_at > \\$1 OR \\(created_at = \\$2 AND id > \\$3\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\$4"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockArticles[0].ID,
+			Sort:      "created_at,id",
+		})
+		mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), mockArticles[0].ID, int64(2)).WillReturnRows(rows)
+		a := activityPostgresRepo.NewPostgresActivityRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("prev-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, false, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity " +
+			"WHERE deleted_at IS NULL AND archived = FALSE AND \\(created_at < \\$1 OR \\(created_at = \\$2 AND id < \\$3\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\$4"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionPrev,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockArticles[1].ID,
+			Sort:      "created_at,id",
+		})
+		mock.ExpectQuery(query).WillReturnRows(rows)
+		a := activityPostgresRepo.NewPostgresActivityRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.ActivityFilter{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("tampered-cursor", func(t *testing.T) {
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockArticles[0].ID,
+			Sort:      "created_at,id",
+		})
+		a := activityPostgresRepo.NewPostgresActivityRepository(db)
+		_, _, _, err := a.Fetch(context.TODO(), "x"+cursor, int64(1), domain.ActivityFilter{})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	t.Run("include-archived", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+			AddRow(mockArticles[0].ID, mockArticles[0].Email, mockArticles[0].Title, true, mockArticles[0].UpdatedAt, mockArticles[0].CreatedAt, nil).
+			AddRow(mockArticles[1].ID, mockArticles[1].Email, mockArticles[1].Title, false, mockArticles[1].UpdatedAt, mockArticles[1].CreatedAt, nil)
+
+		query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE deleted_at IS NULL ORDER BY created_at ASC, id ASC LIMIT \\$1"
+
+		mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+		a := activityPostgresRepo.NewPostgresActivityRepository(db)
+		filter := domain.ActivityFilter{IncludeArchived: true}
+		list, nextCursor, _, err := a.Fetch(context.TODO(), "", int64(1), filter)
+		assert.NoError(t, err)
+		if assert.Len(t, list, 1) {
+			assert.True(t, list[0].Archived)
+		}
+
+		// the cursor belongs to the listing it was given with
+		_, _, _, err = a.Fetch(context.TODO(), nextCursor, int64(1), domain.ActivityFilter{})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, "title 1", "Content 1", false, time.Now(), time.Now(), nil)
+
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE ID = \\$1 AND deleted_at IS NULL"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	num := int64(5)
+	anArticle, err := a.GetByID(context.TODO(), num)
+	assert.NoError(t, err)
+	assert.NotNil(t, anArticle)
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Activity{
+		ID:        12,
+		Email:     "Content",
+		Title:     "Judul",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO activity \\(email, title, updated_at, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING id"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(ar.Email, ar.Title, ar.UpdatedAt, ar.CreatedAt).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	err = a.Store(context.TODO(), ar)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), ar.ID)
+}
+
+func TestGetByTitle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, "title 1", "Content 1", false, time.Now(), time.Now(), nil)
+
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE title = \\$1 AND deleted_at IS NULL"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	title := "title 1"
+	anArticle, err := a.GetByTitle(context.TODO(), title)
+	assert.NoError(t, err)
+	assert.NotNil(t, anArticle)
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	query := "UPDATE activity set deleted_at=\\$1 WHERE ID = \\$2 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(now, 12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	num := int64(12)
+	err = a.Delete(context.TODO(), num, now)
+	assert.NoError(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Activity{
+		ID:        12,
+		Email:     "Content",
+		Title:     "Judul",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE activity set email=\\$1, title=\\$2, updated_at=\\$3 WHERE ID = \\$4 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(ar.Email, ar.Title, ar.UpdatedAt, ar.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	err = a.Update(context.TODO(), ar)
+	assert.NoError(t, err)
+}
+
+func TestSetArchived(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	query := "UPDATE activity set archived=\\$1, updated_at=\\$2 WHERE ID = \\$3 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(true, now, 12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	err = a.SetArchived(context.TODO(), int64(12), true, now)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	deletedAt := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, "Bagus@gmail.com", "title 1", false, time.Now(), time.Now(), deletedAt)
+
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	list, err := a.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) && assert.NotNil(t, list[0].DeletedAt) {
+		assert.Equal(t, deletedAt, *list[0].DeletedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeletedByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, email, title, archived, updated_at, created_at, deleted_at FROM activity WHERE ID = \\$1 AND deleted_at IS NOT NULL"
+
+	mock.ExpectQuery(query).WithArgs(int64(5)).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "title", "archived", "updated_at", "created_at", "deleted_at"}))
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	_, err = a.GetDeletedByID(context.TODO(), int64(5))
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE activity set deleted_at=NULL WHERE ID = \\$1 AND deleted_at IS NOT NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	err = a.Restore(context.TODO(), int64(12))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	before := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	query := "DELETE FROM activity WHERE deleted_at < \\$1 AND NOT EXISTS \\(SELECT 1 FROM todo WHERE todo.activity_group_id = activity.id\\)"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := activityPostgresRepo.NewPostgresActivityRepository(db)
+
+	purged, err := a.Purge(context.TODO(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,315 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/activity/repository"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type sqliteActivityRepository struct {
+	Conn *sql.DB
+}
+
+// NewSqliteActivityRepository will create an object that represent the domain.ActivityRepository interface
+func NewSqliteActivityRepository(Conn *sql.DB) domain.ActivityRepository {
+	return &sqliteActivityRepository{Conn}
+}
+
+func (m *sqliteActivityRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Activity, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Activity, 0)
+	for rows.Next() {
+		t := domain.Activity{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Email,
+			&t.Title,
+			&t.Archived,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.DeletedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *sqliteActivityRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.ActivityFilter) (res []domain.Activity, nextCursor string, prevCursor string, err error) {
+	where := " WHERE deleted_at IS NULL"
+	if !filter.IncludeArchived {
+		where += " AND archived = 0"
+	}
+	args := make([]interface{}, 0)
+
+	direction := pagination.DirectionNext
+	if cursor != "" {
+		c, createdAt, err := repository.ParseCursor(cursor, filter)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		direction = c.Direction
+		operator := ">"
+		if direction == pagination.DirectionPrev {
+			operator = "<"
+		}
+		where += " AND (created_at " + operator + " ? OR (created_at = ? AND id " + operator + " ?))"
+		args = append(args, sqlitedb.Time(createdAt), sqlitedb.Time(createdAt), c.ID)
+	}
+
+	order := "ASC"
+	if direction == pagination.DirectionPrev {
+		order = "DESC"
+	}
+
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity` + where + ` ORDER BY created_at ` + order + `, id ` + order + ` LIMIT ? `
+
+	// fetch one more row than requested to know whether there is another page in the same direction
+	args = append(args, num+1)
+	res, err = m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	hasMore := len(res) > int(num)
+	if hasMore {
+		res = res[:num]
+	}
+	if direction == pagination.DirectionPrev {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	if len(res) == 0 {
+		return
+	}
+
+	if hasMore || direction == pagination.DirectionPrev {
+		nextCursor = repository.CursorOf(res[len(res)-1], pagination.DirectionNext, filter)
+	}
+	if (hasMore && direction == pagination.DirectionPrev) || (cursor != "" && direction == pagination.DirectionNext) {
+		prevCursor = repository.CursorOf(res[0], pagination.DirectionPrev, filter)
+	}
+
+	return
+}
+
+func (m *sqliteActivityRepository) GetByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE ID = ? AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *sqliteActivityRepository) GetByTitle(ctx context.Context, title string) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE title = ? AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, title)
+	if err != nil {
+		return
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *sqliteActivityRepository) Store(ctx context.Context, a *domain.Activity) (err error) {
+	query := `INSERT INTO activity (email, title, updated_at, created_at) VALUES (?, ?, ?, ?)`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, a.Email, a.Title, sqlitedb.Time(a.UpdatedAt), sqlitedb.Time(a.CreatedAt))
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return
+}
+
+func (m *sqliteActivityRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE activity set deleted_at=? WHERE ID = ? AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, sqlitedb.Time(deletedAt), id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+func (m *sqliteActivityRepository) Update(ctx context.Context, ar *domain.Activity) (err error) {
+	query := `UPDATE activity set email=?, title=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Email, ar.Title, sqlitedb.Time(ar.UpdatedAt), ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteActivityRepository) SetArchived(ctx context.Context, id int64, archived bool, updatedAt time.Time) (err error) {
+	query := `UPDATE activity set archived=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, archived, sqlitedb.Time(updatedAt), id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteActivityRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *sqliteActivityRepository) FetchDeleted(ctx context.Context) (res []domain.Activity, err error) {
+	query := `SELECT id, email, title, archived, updated_at, created_at, deleted_at
+  						FROM activity WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *sqliteActivityRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := `UPDATE activity set deleted_at=NULL WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+// Purge will leave the activities still referred by a todo, even one in the trash, to a later purge
+func (m *sqliteActivityRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	query := `DELETE FROM activity WHERE deleted_at < ? AND NOT EXISTS (SELECT 1 FROM todo WHERE todo.activity_group_id = activity.id)`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, sqlitedb.Time(before))
+	if err != nil {
+		return
+	}
+	return res.RowsAffected()
+}
@@ -15,6 +15,23 @@ import (
 	"github.com/bxcodec/go-clean-arch/domain/mocks"
 )
 
+// now is the time the clock of the usecase tells
+var now = time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// transactionKey marks the context of the functions run by markingTransactor
+type transactionKey struct{}
+
+// markingTransactor runs the given function in place with a context telling it is within the transaction
+type markingTransactor struct{}
+
+func (markingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, transactionKey{}, true))
+}
+
+func (markingTransactor) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestFetch(t *testing.T) {
 	mockArticleRepo := new(mocks.ActivityRepositoryMock)
 	mockArticle := domain.Activity{
@@ -27,14 +44,15 @@ func TestFetch(t *testing.T) {
 	log.Println("asu", mockListArtilce)
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
-			mock.AnythingOfType("int64")).Return(mockListArtilce, "next-cursor", nil).Once()
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+			mock.AnythingOfType("int64"), domain.ActivityFilter{IncludeArchived: true}).Return(mockListArtilce, "next-cursor", "prev-cursor", nil).Once()
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
 		num := int64(1)
 		cursor := "12"
-		list, nextCursor, err := u.Fetch(context.TODO(), cursor, num)
+		list, nextCursor, prevCursor, err := u.Fetch(context.TODO(), cursor, num, domain.ActivityFilter{IncludeArchived: true})
 		cursorExpected := "next-cursor"
 		assert.Equal(t, cursorExpected, nextCursor)
 		assert.NotEmpty(t, nextCursor)
+		assert.Equal(t, "prev-cursor", prevCursor)
 		assert.NoError(t, err)
 		assert.Len(t, list, len(mockListArtilce))
 
@@ -52,7 +70,7 @@ func TestGetByID(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
 
 		a, err := u.GetByID(context.TODO(), mockArticle.ID)
 
@@ -77,12 +95,14 @@ func TestStore(t *testing.T) {
 		mockArticleRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Activity{}, domain.ErrNotFound).Once()
 		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Activity")).Return(nil).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
 
 		err := u.Store(context.TODO(), &tempMockArticle)
 
 		assert.NoError(t, err)
 		assert.Equal(t, mockArticle.Title, tempMockArticle.Title)
+		assert.Equal(t, now, tempMockArticle.CreatedAt)
+		assert.Equal(t, now, tempMockArticle.UpdatedAt)
 		mockArticleRepo.AssertExpectations(t)
 	})
 
@@ -90,27 +110,75 @@ func TestStore(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	mockArticleRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
 	mockArticle := domain.Activity{
+		ID:    2,
 		Title: "Hello",
 		Email: "Content",
 	}
 
 	t.Run("success", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
+		mockTodoRepo.On("Fetch", mock.Anything, "", int64(1), domain.TodoFilter{ActivityGroupID: mockArticle.ID}, domain.TodoSort(nil)).Return([]domain.Todo{}, "", "", nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, mockArticle.ID, now).Return(nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		err := u.Delete(context.TODO(), mockArticle.ID)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("restrict-has-todos", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
+		mockTodoRepo.On("Fetch", mock.Anything, "", int64(1), domain.TodoFilter{ActivityGroupID: mockArticle.ID}, domain.TodoSort(nil)).Return([]domain.Todo{{ID: 7}}, "", "", nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		err := u.Delete(context.TODO(), mockArticle.ID)
 
-		mockArticleRepo.On("Delete", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+		assert.Equal(t, domain.ErrActivityHasTodos, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("cascade", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
+		var todosDeletedAt, articleDeletedAt time.Time
+		mockTodoRepo.On("DeleteByActivityGroupID", mock.Anything, mockArticle.ID, mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+			todosDeletedAt = args.Get(2).(time.Time)
+		}).Return(nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, mockArticle.ID, mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+			articleDeletedAt = args.Get(2).(time.Time)
+		}).Return(nil).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyCascade, time.Second*2)
 
 		err := u.Delete(context.TODO(), mockArticle.ID)
 
 		assert.NoError(t, err)
+		assert.Equal(t, now, articleDeletedAt)
+		assert.Equal(t, now, todosDeletedAt)
 		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("detach", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
+		mockTodoRepo.On("DetachActivityGroup", mock.Anything, mockArticle.ID).Return(nil).Once()
+		mockArticleRepo.On("Delete", mock.Anything, mockArticle.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyDetach, time.Second*2)
+
+		err := u.Delete(context.TODO(), mockArticle.ID)
+
+		assert.NoError(t, err)
+		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
 	})
 	t.Run("article-is-not-exist", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Activity{}, nil).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
 
 		err := u.Delete(context.TODO(), mockArticle.ID)
 
@@ -120,7 +188,7 @@ func TestDelete(t *testing.T) {
 	t.Run("error-happens-in-db", func(t *testing.T) {
 		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Activity{}, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
 
 		err := u.Delete(context.TODO(), mockArticle.ID)
 
@@ -130,6 +198,119 @@ func TestDelete(t *testing.T) {
 
 }
 
+func TestRestore(t *testing.T) {
+	deletedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	deleted := domain.Activity{
+		ID:        2,
+		Title:     "Hello",
+		Email:     "Content",
+		DeletedAt: &deletedAt,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockArticleRepo.On("GetDeletedByID", mock.Anything, deleted.ID).Return(deleted, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, deleted.Title).Return(domain.Activity{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Restore", mock.Anything, deleted.ID).Return(nil).Once()
+		mockTodoRepo.On("RestoreByActivityGroupID", mock.Anything, deleted.ID, deletedAt).Return(nil).Once()
+		mockArticleRepo.On("GetByID", mock.Anything, deleted.ID).Return(domain.Activity{ID: 2, Title: "Hello", Email: "Content"}, nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyCascade, time.Second*2)
+
+		res, err := u.Restore(context.TODO(), deleted.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, res.DeletedAt)
+		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("title-taken", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetDeletedByID", mock.Anything, deleted.ID).Return(deleted, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, deleted.Title).Return(domain.Activity{ID: 3, Title: "Hello"}, nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyCascade, time.Second*2)
+
+		_, err := u.Restore(context.TODO(), deleted.ID)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		mockArticleRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+	t.Run("not-in-trash", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetDeletedByID", mock.Anything, int64(9)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyCascade, time.Second*2)
+
+		_, err := u.Restore(context.TODO(), 9)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestArchive(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    2,
+		Title: "Hello",
+		Email: "Content",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("SetArchived", mock.Anything, mockArticle.ID, true, now).Return(nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		res, err := u.Archive(context.TODO(), mockArticle.ID)
+
+		assert.NoError(t, err)
+		assert.True(t, res.Archived)
+		assert.Equal(t, now, res.UpdatedAt)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("already-archived", func(t *testing.T) {
+		archived := mockArticle
+		archived.Archived = true
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(archived, nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		res, err := u.Archive(context.TODO(), mockArticle.ID)
+
+		assert.NoError(t, err)
+		assert.True(t, res.Archived)
+		mockArticleRepo.AssertNotCalled(t, "SetArchived", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("unarchive", func(t *testing.T) {
+		archived := mockArticle
+		archived.Archived = true
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(archived, nil).Once()
+		mockArticleRepo.On("SetArchived", mock.Anything, mockArticle.ID, false, now).Return(nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		res, err := u.Unarchive(context.TODO(), mockArticle.ID)
+
+		assert.NoError(t, err)
+		assert.False(t, res.Archived)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		_, err := u.Archive(context.TODO(), 9)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	mockArticleRepo := new(mocks.ActivityRepositoryMock)
 	mockArticle := domain.Activity{
@@ -139,12 +320,118 @@ func TestUpdate(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
 		mockArticleRepo.On("Update", mock.Anything, &mockArticle).Once().Return(nil)
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		err := u.Update(context.TODO(), &mockArticle)
+		assert.NoError(t, err)
+		assert.Equal(t, now, mockArticle.UpdatedAt)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("article-is-not-exist", func(t *testing.T) {
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
 
 		err := u.Update(context.TODO(), &mockArticle)
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("title-conflict", func(t *testing.T) {
+		tempMockArticle := mockArticle
+		tempMockArticle.Title = "World"
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, "World").Return(domain.Activity{ID: 24, Title: "World"}, nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockArticle)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockArticleRepo.AssertExpectations(t)
+	})
+}
+
+func TestDuplicate(t *testing.T) {
+	mockArticle := domain.Activity{
+		ID:    2,
+		Title: "Sprint",
+		Email: "team@example.com",
+	}
+	filter := domain.TodoFilter{ActivityGroupID: mockArticle.ID}
+	sort := domain.TodoSort{{Field: "position"}}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoUcase := new(mocks.TodoUsecaseMock)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, "Sprint").Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, "Sprint (copy)").Return(domain.Activity{ID: 3, Title: "Sprint (copy)"}, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, "Sprint (copy 2)").Return(domain.Activity{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Activity) bool {
+			return ar.Title == "Sprint (copy 2)" && ar.Email == mockArticle.Email
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Activity).ID = 4
+		}).Return(nil).Once()
+		mockTodoRepo.On("Fetch", mock.Anything, "", int64(100), filter, sort).Return([]domain.Todo{{ID: 7}, {ID: 8}}, "next", "", nil).Once()
+		mockTodoRepo.On("Fetch", mock.Anything, "next", int64(100), filter, sort).Return([]domain.Todo{{ID: 9}}, "", "prev", nil).Once()
+		mockTodoUcase.On("CopyMany", mock.Anything, []int64{7, 8, 9}, domain.TodoCopy{ActivityGroupID: 4, ResetStatus: true}).Return([]domain.Todo{}, nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, mockTodoUcase, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		res, err := u.Duplicate(context.TODO(), mockArticle.ID, domain.ActivityDuplicate{ResetStatus: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), res.ID)
+		assert.Equal(t, "Sprint (copy 2)", res.Title)
+		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+		mockTodoUcase.AssertExpectations(t)
+	})
+	t.Run("new-title-without-todos", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoUcase := new(mocks.TodoUsecaseMock)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", mock.Anything, "Sprint 5").Return(domain.Activity{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Activity")).Return(nil).Once()
+		mockTodoRepo.On("Fetch", mock.Anything, "", int64(100), filter, sort).Return([]domain.Todo{}, "", "", nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, mockTodoUcase, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		res, err := u.Duplicate(context.TODO(), mockArticle.ID, domain.ActivityDuplicate{Title: "Sprint 5"})
 		assert.NoError(t, err)
+		assert.Equal(t, "Sprint 5", res.Title)
 		mockArticleRepo.AssertExpectations(t)
+		mockTodoUcase.AssertNotCalled(t, "CopyMany", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("title-picked-in-transaction", func(t *testing.T) {
+		inTransaction := mock.MatchedBy(func(ctx context.Context) bool {
+			return ctx.Value(transactionKey{}) != nil
+		})
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockArticleRepo.On("GetByID", mock.Anything, mockArticle.ID).Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", inTransaction, "Sprint").Return(mockArticle, nil).Once()
+		mockArticleRepo.On("GetByTitle", inTransaction, "Sprint (copy)").Return(domain.Activity{}, domain.ErrNotFound).Once()
+		mockArticleRepo.On("Store", inTransaction, mock.AnythingOfType("*domain.Activity")).Return(nil).Once()
+		mockTodoRepo.On("Fetch", mock.Anything, "", int64(100), filter, sort).Return([]domain.Todo{}, "", "", nil).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, mockTodoRepo, new(mocks.TodoUsecaseMock), markingTransactor{}, &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		res, err := u.Duplicate(context.TODO(), mockArticle.ID, domain.ActivityDuplicate{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Sprint (copy)", res.Title)
+		mockArticleRepo.AssertExpectations(t)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockArticleRepo := new(mocks.ActivityRepositoryMock)
+		mockArticleRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewArticleUsecase(mockArticleRepo, new(mocks.TodoRepositoryMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, domain.DeletePolicyRestrict, time.Second*2)
+
+		_, err := u.Duplicate(context.TODO(), 9, domain.ActivityDuplicate{})
+		assert.Equal(t, domain.ErrNotFound, err)
 	})
 }
@@ -9,17 +9,27 @@ import (
 
 type activityUsecase struct {
 	articleRepo    domain.ActivityRepository
+	todoRepo       domain.TodoRepository
+	todoUsecase    domain.TodoUsecase
+	transactor     domain.Transactor
+	clock          domain.Clock
+	deletePolicy   domain.ActivityDeletePolicy
 	contextTimeout time.Duration
 }
 
-func NewArticleUsecase(a domain.ActivityRepository, timeout time.Duration) domain.ActivityUsecase {
+func NewArticleUsecase(a domain.ActivityRepository, td domain.TodoRepository, tu domain.TodoUsecase, tx domain.Transactor, clk domain.Clock, policy domain.ActivityDeletePolicy, timeout time.Duration) domain.ActivityUsecase {
 	return &activityUsecase{
 		articleRepo:    a,
+		todoRepo:       td,
+		todoUsecase:    tu,
+		transactor:     tx,
+		clock:          clk,
+		deletePolicy:   policy,
 		contextTimeout: timeout,
 	}
 }
 
-func (a *activityUsecase) Fetch(c context.Context, cursor string, num int64) (res []domain.Activity, nextCursor string, err error) {
+func (a *activityUsecase) Fetch(c context.Context, cursor string, num int64, filter domain.ActivityFilter) (res []domain.Activity, nextCursor string, prevCursor string, err error) {
 	if num == 0 {
 		num = 10
 	}
@@ -27,9 +37,9 @@ func (a *activityUsecase) Fetch(c context.Context, cursor string, num int64) (re
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
 
-	res, nextCursor, err = a.articleRepo.Fetch(ctx, cursor, num)
+	res, nextCursor, prevCursor, err = a.articleRepo.Fetch(ctx, cursor, num, filter)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
 	return
@@ -51,7 +61,20 @@ func (a *activityUsecase) Update(c context.Context, ar *domain.Activity) (err er
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
 
-	ar.UpdatedAt = time.Now()
+	existedArticle, err := a.articleRepo.GetByID(ctx, ar.ID)
+	if err != nil {
+		return
+	}
+	if existedArticle.Title != ar.Title {
+		sameTitle, _ := a.articleRepo.GetByTitle(ctx, ar.Title)
+		if sameTitle != (domain.Activity{}) {
+			return domain.ErrConflict
+		}
+	}
+
+	ar.Archived = existedArticle.Archived
+	ar.CreatedAt = existedArticle.CreatedAt
+	ar.UpdatedAt = a.clock.Now()
 	return a.articleRepo.Update(ctx, ar)
 }
 
@@ -69,15 +92,85 @@ func (a *activityUsecase) GetByTitle(c context.Context, title string) (res domai
 func (a *activityUsecase) Store(c context.Context, m *domain.Activity) (err error) {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
-	existedArticle, _ := a.GetByTitle(ctx, m.Title)
-	if existedArticle != (domain.Activity{}) {
+	if a.titleTaken(ctx, m.Title) {
 		return domain.ErrConflict
 	}
 
+	m.CreatedAt = a.clock.Now()
+	m.UpdatedAt = m.CreatedAt
 	err = a.articleRepo.Store(ctx, m)
 	return
 }
 
+// titleTaken will tell whether an activity already uses the title
+func (a *activityUsecase) titleTaken(ctx context.Context, title string) bool {
+	existedArticle, _ := a.articleRepo.GetByTitle(ctx, title)
+	return existedArticle != (domain.Activity{})
+}
+
+// Duplicate will store a copy of the activity and copy its todos into it in the same order, all of them or none.
+// A taken title is suffixed `(copy)`, `(copy 2)` and so on instead of being a conflict.
+func (a *activityUsecase) Duplicate(c context.Context, id int64, dup domain.ActivityDuplicate) (res domain.Activity, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	source, err := a.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	title := dup.Title
+	if title == "" {
+		title = source.Title
+	}
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the title is picked along with the store for a concurrent duplicate not to take it in between
+		res = domain.Activity{
+			Email: source.Email,
+			Title: title,
+		}
+		for n := 1; a.titleTaken(ctx, res.Title); n++ {
+			res.Title = domain.CopyTitle(title, n)
+		}
+
+		res.CreatedAt = a.clock.Now()
+		res.UpdatedAt = res.CreatedAt
+		if err := a.articleRepo.Store(ctx, &res); err != nil {
+			return err
+		}
+
+		ids, err := a.todoIDs(ctx, id)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		_, err = a.todoUsecase.CopyMany(ctx, ids, domain.TodoCopy{ActivityGroupID: res.ID, ResetStatus: dup.ResetStatus})
+		return err
+	})
+	if err != nil {
+		return domain.Activity{}, err
+	}
+	return
+}
+
+// todoIDs will list the id of every todo of the activity, ordered by position
+func (a *activityUsecase) todoIDs(ctx context.Context, id int64) (ids []int64, err error) {
+	filter := domain.TodoFilter{ActivityGroupID: id}
+	sort := domain.TodoSort{{Field: "position"}}
+	cursor := ""
+	for {
+		todos, nextCursor, _, err := a.todoRepo.Fetch(ctx, cursor, 100, filter, sort)
+		if err != nil {
+			return nil, err
+		}
+		for _, todo := range todos {
+			ids = append(ids, todo.ID)
+		}
+		if nextCursor == "" {
+			return ids, nil
+		}
+		cursor = nextCursor
+	}
+}
+
 func (a *activityUsecase) Delete(c context.Context, id int64) (err error) {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
@@ -88,5 +181,86 @@ func (a *activityUsecase) Delete(c context.Context, id int64) (err error) {
 	if existedArticle == (domain.Activity{}) {
 		return domain.ErrNotFound
 	}
-	return a.articleRepo.Delete(ctx, id)
+
+	// cascaded todos share the deletion time of the activity, so a restore can tell them apart
+	now := a.clock.Now()
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := a.releaseTodos(ctx, id, now)
+		if err != nil {
+			return err
+		}
+		return a.articleRepo.Delete(ctx, id, now)
+	})
+}
+
+// releaseTodos will apply the delete policy to the todos of the activity about to be deleted
+func (a *activityUsecase) releaseTodos(ctx context.Context, id int64, deletedAt time.Time) error {
+	switch a.deletePolicy {
+	case domain.DeletePolicyCascade:
+		return a.todoRepo.DeleteByActivityGroupID(ctx, id, deletedAt)
+	case domain.DeletePolicyDetach:
+		return a.todoRepo.DetachActivityGroup(ctx, id)
+	default:
+		todos, _, _, err := a.todoRepo.Fetch(ctx, "", 1, domain.TodoFilter{ActivityGroupID: id}, nil)
+		if err != nil {
+			return err
+		}
+		if len(todos) > 0 {
+			return domain.ErrActivityHasTodos
+		}
+		return nil
+	}
+}
+
+// Restore will bring the activity back from the trash together with the todos deleted along with it
+func (a *activityUsecase) Restore(c context.Context, id int64) (res domain.Activity, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	deleted, err := a.articleRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if a.titleTaken(ctx, deleted.Title) {
+		return domain.Activity{}, domain.ErrConflict
+	}
+
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := a.articleRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		return a.todoRepo.RestoreByActivityGroupID(ctx, id, *deleted.DeletedAt)
+	})
+	if err != nil {
+		return
+	}
+	return a.articleRepo.GetByID(ctx, id)
+}
+
+func (a *activityUsecase) Archive(c context.Context, id int64) (domain.Activity, error) {
+	return a.setArchived(c, id, true)
+}
+
+func (a *activityUsecase) Unarchive(c context.Context, id int64) (domain.Activity, error) {
+	return a.setArchived(c, id, false)
+}
+
+// setArchived will flag the activity as archived or not, an activity already flagged is left as it is
+func (a *activityUsecase) setArchived(c context.Context, id int64, archived bool) (res domain.Activity, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	res, err = a.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if res.Archived == archived {
+		return
+	}
+
+	res.Archived = archived
+	res.UpdatedAt = a.clock.Now()
+	err = a.articleRepo.SetArchived(ctx, id, archived, res.UpdatedAt)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+	return
 }
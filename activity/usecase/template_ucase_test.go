@@ -0,0 +1,176 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	ucase "github.com/bxcodec/go-clean-arch/activity/usecase"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+)
+
+func TestStoreTemplate(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+	mockTemplateRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Template")).Return(nil).Once()
+
+	u := ucase.NewTemplateUsecase(mockTemplateRepo, new(mocks.ActivityUsecaseMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, nil, time.Second*2)
+
+	tp := domain.Template{ID: 9, Title: "Sprint {{week}}", Email: "team@example.com"}
+	err := u.Store(context.TODO(), &tp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), tp.ID)
+	assert.True(t, tp.CreatedAt.Equal(now))
+	mockTemplateRepo.AssertExpectations(t)
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := createdAt.Add(time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+		mockTemplateRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Template{ID: 9, CreatedAt: createdAt}, nil).Once()
+		mockTemplateRepo.On("Update", mock.Anything, mock.MatchedBy(func(tp *domain.Template) bool {
+			return tp.CreatedAt.Equal(createdAt) && tp.UpdatedAt.Equal(now)
+		})).Return(nil).Once()
+
+		u := ucase.NewTemplateUsecase(mockTemplateRepo, new(mocks.ActivityUsecaseMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, nil, time.Second*2)
+
+		err := u.Update(context.TODO(), &domain.Template{ID: 9, Title: "Sprint", Email: "team@example.com"})
+		assert.NoError(t, err)
+		mockTemplateRepo.AssertExpectations(t)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+		mockTemplateRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Template{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTemplateUsecase(mockTemplateRepo, new(mocks.ActivityUsecaseMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, nil, time.Second*2)
+
+		err := u.Update(context.TODO(), &domain.Template{ID: 9})
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTemplateRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFromActivity(t *testing.T) {
+	mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+	mockActivityUcase := new(mocks.ActivityUsecaseMock)
+	mockTodoUcase := new(mocks.TodoUsecaseMock)
+	filter := domain.TodoFilter{ActivityGroupID: 2}
+	sort := domain.TodoSort{{Field: "position"}}
+	mockActivityUcase.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Sprint", Email: "team@example.com"}, nil).Once()
+	mockTodoUcase.On("Fetch", mock.Anything, "", int64(100), filter, sort).Return([]domain.Todo{{ID: 7, Title: "Plan", Priority: domain.PriorityHigh}}, "next", "", nil).Once()
+	mockTodoUcase.On("Fetch", mock.Anything, "next", int64(100), filter, sort).Return([]domain.Todo{{ID: 8, Title: "Demo", Priority: domain.PriorityNormal}}, "", "prev", nil).Once()
+	mockTemplateRepo.On("Store", mock.Anything, mock.MatchedBy(func(tp *domain.Template) bool {
+		return tp.Title == "Sprint" && tp.Email == "team@example.com" && len(tp.Todos) == 2
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Template).ID = 3
+	}).Return(nil).Once()
+
+	u := ucase.NewTemplateUsecase(mockTemplateRepo, mockActivityUcase, mockTodoUcase, new(mocks.TransactorMock), &mocks.ClockMock{Time: time.Now()}, nil, time.Second*2)
+
+	res, err := u.FromActivity(context.TODO(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), res.ID)
+	assert.Equal(t, []domain.TemplateTodo{{Title: "Plan", Priority: domain.PriorityHigh}, {Title: "Demo", Priority: domain.PriorityNormal}}, res.Todos)
+	mockTemplateRepo.AssertExpectations(t)
+	mockTodoUcase.AssertExpectations(t)
+}
+
+func TestInstantiate(t *testing.T) {
+	// late on Sunday in UTC is already Monday of the next ISO week in Jakarta
+	now := time.Date(2021, 3, 7, 20, 0, 0, 0, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := domain.Template{
+		ID:    3,
+		Title: "Sprint {{week}}",
+		Email: "team@example.com",
+		Todos: []domain.TemplateTodo{
+			{Title: "Plan {{date}}", Priority: domain.PriorityHigh},
+			{Title: "Demo", Priority: domain.PriorityNormal},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+		mockActivityUcase := new(mocks.ActivityUsecaseMock)
+		mockTodoUcase := new(mocks.TodoUsecaseMock)
+		mockTemplateRepo.On("GetByID", mock.Anything, tp.ID).Return(tp, nil).Once()
+		mockActivityUcase.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Activity) bool {
+			return ar.Title == "Sprint 2021-W10"
+		})).Return(domain.ErrConflict).Once()
+		mockActivityUcase.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Activity) bool {
+			return ar.Title == "Sprint 2021-W10 (copy)" && ar.Email == tp.Email
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Activity).ID = 4
+		}).Return(nil).Once()
+		mockTodoUcase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Title == "Plan 2021-03-08" && td.Priority == domain.PriorityHigh && td.ActivityGroupID.ID == 4
+		})).Return(nil).Once()
+		mockTodoUcase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Title == "Demo"
+		})).Return(domain.ErrConflict).Once()
+		mockTodoUcase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Title == "Demo (copy)" && td.Priority == domain.PriorityNormal && td.ActivityGroupID.ID == 4
+		})).Return(nil).Once()
+
+		u := ucase.NewTemplateUsecase(mockTemplateRepo, mockActivityUcase, mockTodoUcase, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, jakarta, time.Second*2)
+
+		res, err := u.Instantiate(context.TODO(), tp.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), res.ID)
+		assert.Equal(t, "Sprint 2021-W10 (copy)", res.Title)
+		mockActivityUcase.AssertExpectations(t)
+		mockTodoUcase.AssertExpectations(t)
+	})
+	t.Run("long-title", func(t *testing.T) {
+		long := domain.Template{
+			ID:    3,
+			Title: "Quarterly planning of platform team {{date}}",
+			Email: "team@example.com",
+		}
+		mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+		mockActivityUcase := new(mocks.ActivityUsecaseMock)
+		mockTemplateRepo.On("GetByID", mock.Anything, long.ID).Return(long, nil).Once()
+		mockActivityUcase.On("Store", mock.Anything, mock.MatchedBy(func(ar *domain.Activity) bool {
+			return ar.Title == "Quarterly planning of platform team 2021-03-0"
+		})).Return(nil).Once()
+
+		u := ucase.NewTemplateUsecase(mockTemplateRepo, mockActivityUcase, new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, jakarta, time.Second*2)
+
+		_, err := u.Instantiate(context.TODO(), long.ID)
+		assert.NoError(t, err)
+		mockActivityUcase.AssertExpectations(t)
+	})
+	t.Run("unknown-priority", func(t *testing.T) {
+		mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+		mockActivityUcase := new(mocks.ActivityUsecaseMock)
+		mockTodoUcase := new(mocks.TodoUsecaseMock)
+		mockTemplateRepo.On("GetByID", mock.Anything, tp.ID).Return(tp, nil).Once()
+		mockActivityUcase.On("Store", mock.Anything, mock.AnythingOfType("*domain.Activity")).Return(nil).Once()
+		mockTodoUcase.On("Store", mock.Anything, mock.AnythingOfType("*domain.Todo")).Return(domain.ErrBadParamInput).Once()
+
+		u := ucase.NewTemplateUsecase(mockTemplateRepo, mockActivityUcase, mockTodoUcase, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, nil, time.Second*2)
+
+		_, err := u.Instantiate(context.TODO(), tp.ID)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockTodoUcase.AssertNumberOfCalls(t, "Store", 1)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mockTemplateRepo := new(mocks.TemplateRepositoryMock)
+		mockTemplateRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Template{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTemplateUsecase(mockTemplateRepo, new(mocks.ActivityUsecaseMock), new(mocks.TodoUsecaseMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, nil, time.Second*2)
+
+		_, err := u.Instantiate(context.TODO(), 9)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type templateUsecase struct {
+	template       domain.TemplateRepository
+	activity       domain.ActivityUsecase
+	todo           domain.TodoUsecase
+	transactor     domain.Transactor
+	clock          domain.Clock
+	location       *time.Location
+	contextTimeout time.Duration
+}
+
+// NewTemplateUsecase will create new an templateUsecase object representation of domain.TemplateUsecase interface,
+// the placeholders of the titles are filled with the day in the given time zone
+func NewTemplateUsecase(tp domain.TemplateRepository, au domain.ActivityUsecase, tu domain.TodoUsecase, tx domain.Transactor, clk domain.Clock, loc *time.Location, timeout time.Duration) domain.TemplateUsecase {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &templateUsecase{
+		template:       tp,
+		activity:       au,
+		todo:           tu,
+		transactor:     tx,
+		clock:          clk,
+		location:       loc,
+		contextTimeout: timeout,
+	}
+}
+
+func (a *templateUsecase) Fetch(c context.Context) (res []domain.Template, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.template.Fetch(ctx)
+}
+
+func (a *templateUsecase) GetByID(c context.Context, id int64) (res domain.Template, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.template.GetByID(ctx, id)
+}
+
+func (a *templateUsecase) Store(c context.Context, m *domain.Template) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.store(ctx, m)
+}
+
+func (a *templateUsecase) store(ctx context.Context, m *domain.Template) error {
+	m.ID = 0
+	m.CreatedAt = a.clock.Now()
+	m.UpdatedAt = m.CreatedAt
+
+	// the template and its todos are written together
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return a.template.Store(ctx, m)
+	})
+}
+
+func (a *templateUsecase) Update(c context.Context, m *domain.Template) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	existedTemplate, err := a.template.GetByID(ctx, m.ID)
+	if err != nil {
+		return
+	}
+
+	m.CreatedAt = existedTemplate.CreatedAt
+	m.UpdatedAt = a.clock.Now()
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return a.template.Update(ctx, m)
+	})
+}
+
+func (a *templateUsecase) Delete(c context.Context, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if _, err = a.template.GetByID(ctx, id); err != nil {
+		return
+	}
+	return a.template.Delete(ctx, id)
+}
+
+// FromActivity will save the activity as a template, the todos keep their order and priority
+func (a *templateUsecase) FromActivity(c context.Context, activityID int64) (res domain.Template, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	activity, err := a.activity.GetByID(ctx, activityID)
+	if err != nil {
+		return
+	}
+
+	res = domain.Template{
+		Title: activity.Title,
+		Email: activity.Email,
+		Todos: []domain.TemplateTodo{},
+	}
+	filter := domain.TodoFilter{ActivityGroupID: activityID}
+	sort := domain.TodoSort{{Field: "position"}}
+	cursor := ""
+	for {
+		todos, nextCursor, _, err := a.todo.Fetch(ctx, cursor, 100, filter, sort)
+		if err != nil {
+			return domain.Template{}, err
+		}
+		for _, todo := range todos {
+			res.Todos = append(res.Todos, domain.TemplateTodo{Title: todo.Title, Priority: todo.Priority})
+		}
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	if err = a.store(ctx, &res); err != nil {
+		return domain.Template{}, err
+	}
+	return
+}
+
+// Instantiate will store the activity of the template with its todos, all of them or none
+func (a *templateUsecase) Instantiate(c context.Context, id int64) (res domain.Activity, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	t, err := a.template.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	day := a.clock.Now().In(a.location)
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		res = domain.Activity{Email: t.Email}
+		err := storeUnique(domain.RenderTitle(t.Title, day), func(title string) error {
+			res.Title = title
+			return a.activity.Store(ctx, &res)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, todo := range t.Todos {
+			td := domain.Todo{
+				ActivityGroupID: domain.Activity{ID: res.ID},
+				Priority:        todo.Priority,
+			}
+			err := storeUnique(domain.RenderTitle(todo.Title, day), func(title string) error {
+				td.Title = title
+				return a.todo.Store(ctx, &td)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Activity{}, err
+	}
+	return
+}
+
+// storeUnique will store under the title, or under its first copy title not being a conflict
+func storeUnique(title string, store func(title string) error) error {
+	err := store(title)
+	for n := 1; err == domain.ErrConflict; n++ {
+		err = store(domain.CopyTitle(title, n))
+	}
+	return err
+}
@@ -1,24 +1,31 @@
 package main
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
 	"log"
-	"net/url"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/labstack/echo"
 	"github.com/spf13/viper"
 
 	_activityHttpDelivery "github.com/bxcodec/go-clean-arch/activity/delivery/http"
 	_activityHttpDeliveryMiddleware "github.com/bxcodec/go-clean-arch/activity/delivery/http/middleware"
-	_activityRepo "github.com/bxcodec/go-clean-arch/activity/repository/mysql"
 	_activityUcase "github.com/bxcodec/go-clean-arch/activity/usecase"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/clock"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+	"github.com/bxcodec/go-clean-arch/pkg/storage"
+	_searchHttpDelivery "github.com/bxcodec/go-clean-arch/search/delivery/http"
+	_searchUcase "github.com/bxcodec/go-clean-arch/search/usecase"
+	_tagHttpDelivery "github.com/bxcodec/go-clean-arch/tag/delivery/http"
+	_tagUcase "github.com/bxcodec/go-clean-arch/tag/usecase"
 	_todoHttpDelivery "github.com/bxcodec/go-clean-arch/todo/delivery/http"
 	_todoHttpDeliveryMiddleware "github.com/bxcodec/go-clean-arch/todo/delivery/http/middleware"
-	_todoRepo "github.com/bxcodec/go-clean-arch/todo/repository/mysql"
 	_todoUcase "github.com/bxcodec/go-clean-arch/todo/usecase"
+	_todoItemHttpDelivery "github.com/bxcodec/go-clean-arch/todoitem/delivery/http"
+	_todoItemUcase "github.com/bxcodec/go-clean-arch/todoitem/usecase"
+	_trashHttpDelivery "github.com/bxcodec/go-clean-arch/trash/delivery/http"
+	_trashUcase "github.com/bxcodec/go-clean-arch/trash/usecase"
 )
 
 func init() {
@@ -34,46 +41,70 @@ func init() {
 }
 
 func main() {
-	dbHost := viper.GetString(`database.host`)
-	dbPort := viper.GetString(`database.port`)
-	dbUser := viper.GetString(`database.user`)
-	dbPass := viper.GetString(`database.pass`)
-	dbName := viper.GetString(`database.name`)
-	connection := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPass, dbHost, dbPort, dbName)
-	val := url.Values{}
-	val.Add("parseTime", "1")
-	val.Add("loc", "Asia/Jakarta")
-	dsn := fmt.Sprintf("%s?%s", connection, val.Encode())
-	dbConn, err := sql.Open(`mysql`, dsn)
-
-	if err != nil {
-		log.Fatal(err)
+	// the cursors are signed with the secret, without one they are only accepted until the server restarts
+	if secret := viper.GetString(`cursor.secret`); secret != "" {
+		pagination.SetSecret([]byte(secret))
+	} else {
+		log.Println("cursor.secret is not set, the cursors handed out are only valid until the server restarts")
 	}
-	err = dbConn.Ping()
+
+	repos, err := storage.Open(storage.Config{
+		Driver:  viper.GetString(`database.driver`),
+		Host:    viper.GetString(`database.host`),
+		Port:    viper.GetString(`database.port`),
+		User:    viper.GetString(`database.user`),
+		Pass:    viper.GetString(`database.pass`),
+		Name:    viper.GetString(`database.name`),
+		SSLMode: viper.GetString(`database.sslmode`),
+		Path:    viper.GetString(`database.path`),
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
-
 	defer func() {
-		err := dbConn.Close()
+		err := repos.Close()
 		if err != nil {
 			log.Fatal(err)
 		}
 	}()
+	ar, todo, todoItem, tag, template := repos.Activity, repos.Todo, repos.TodoItem, repos.Tag, repos.Template
+	transactor, search := repos.Transactor, repos.Search
 
 	e := echo.New()
 	middL := _activityHttpDeliveryMiddleware.InitMiddleware()
 	toMiddl := _todoHttpDeliveryMiddleware.InitMiddleware()
 	e.Use(middL.CORS)
 	e.Use(toMiddl.CORS)
-	todo := _todoRepo.NewMysqlTodoRepository(dbConn)
-	ar := _activityRepo.NewMysqlActivityRepository(dbConn)
-
 	timeoutContext := time.Duration(viper.GetInt("context.timeout")) * time.Second
-	td := _todoUcase.NewTodoUsecase(ar, todo, timeoutContext)
-	au := _activityUcase.NewArticleUsecase(ar, timeoutContext)
-	_activityHttpDelivery.NewArticleHandler(e, au)
-	_todoHttpDelivery.NewTodoHandler(e, td)
+	td := _todoUcase.NewTodoUsecase(ar, todo, todoItem, transactor, clock.New(), timeoutContext)
+	deletePolicy := domain.ActivityDeletePolicy(viper.GetString("activity.delete_policy"))
+	switch deletePolicy {
+	case "":
+		deletePolicy = domain.DeletePolicyRestrict
+	case domain.DeletePolicyRestrict, domain.DeletePolicyCascade, domain.DeletePolicyDetach:
+	default:
+		log.Fatalf("unknown activity.delete_policy %q", deletePolicy)
+	}
+	au := _activityUcase.NewArticleUsecase(ar, todo, td, transactor, clock.New(), deletePolicy, timeoutContext)
+	loc, err := time.LoadLocation(viper.GetString("timezone"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	_activityHttpDelivery.NewArticleHandler(e, au, td, loc)
+	_todoHttpDelivery.NewTodoHandler(e, td, loc)
+	tpu := _activityUcase.NewTemplateUsecase(template, au, td, transactor, clock.New(), loc, timeoutContext)
+	_activityHttpDelivery.NewTemplateHandler(e, tpu)
+	tu := _todoItemUcase.NewTodoItemUsecase(ar, todo, todoItem, clock.New(), timeoutContext)
+	_todoItemHttpDelivery.NewTodoItemHandler(e, tu)
+	tgu := _tagUcase.NewTagUsecase(tag, transactor, clock.New(), timeoutContext)
+	_tagHttpDelivery.NewTagHandler(e, tgu)
+	su := _searchUcase.NewSearchUsecase(search, timeoutContext)
+	_searchHttpDelivery.NewSearchHandler(e, su)
+	trashu := _trashUcase.NewTrashUsecase(ar, todo, transactor, clock.New(), viper.GetDuration("trash.retention"), timeoutContext)
+	_trashHttpDelivery.NewTrashHandler(e, trashu)
+	if interval := viper.GetDuration("trash.purge_interval"); interval > 0 {
+		go _trashUcase.PurgeEvery(context.Background(), trashu, interval)
+	}
 
 	log.Fatal(e.Start(viper.GetString("server.address")))
 }
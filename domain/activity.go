@@ -6,30 +6,75 @@ import (
 )
 
 // Activity ...
+// The todos of an Archived activity are read-only, and it is left out of the listing unless asked for.
+// DeletedAt is only given with an activity in the trash.
 type Activity struct {
-	ID        int64     `json:"id"`
-	Email     string    `json:"email" validate:"required"`
-	Title     string    `json:"title" validate:"required"`
-	UpdatedAt time.Time `json:"updated_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64      `json:"id"`
+	Email     string     `json:"email" validate:"required"`
+	Title     string     `json:"title" validate:"required,max=45"`
+	Archived  bool       `json:"archived"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ActivityFilter represent the conditions an activity must meet to be listed
+type ActivityFilter struct {
+	IncludeArchived bool
+}
+
+// ActivityDeletePolicy represent what happens to the todos of a deleted activity
+type ActivityDeletePolicy string
+
+const (
+	// DeletePolicyRestrict refuses to delete an activity that still holds todos
+	DeletePolicyRestrict ActivityDeletePolicy = "restrict"
+	// DeletePolicyCascade deletes the todos along with their activity
+	DeletePolicyCascade ActivityDeletePolicy = "cascade"
+	// DeletePolicyDetach keeps the todos without any activity group
+	DeletePolicyDetach ActivityDeletePolicy = "detach"
+)
+
+// ActivityDuplicate represent how an activity is duplicated, the copy keeps the original title when Title is empty
+// and its todos restart as todos with unchecked checklists on ResetStatus
+type ActivityDuplicate struct {
+	Title       string `json:"title" validate:"max=45"`
+	ResetStatus bool   `json:"reset_status"`
 }
 
 // ArticleUsecase represent the article's usecases
 type ActivityUsecase interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]Activity, string, error)
+	Fetch(ctx context.Context, cursor string, num int64, filter ActivityFilter) ([]Activity, string, string, error)
 	GetByID(ctx context.Context, id int64) (Activity, error)
 	Update(ctx context.Context, ar *Activity) error
 	GetByTitle(ctx context.Context, title string) (Activity, error)
 	Store(context.Context, *Activity) error
 	Delete(ctx context.Context, id int64) error
+	// Duplicate stores a copy of the activity along with a copy of each of its todos
+	Duplicate(ctx context.Context, id int64, dup ActivityDuplicate) (Activity, error)
+	// Restore takes the activity back out of the trash along with the todos deleted with it
+	Restore(ctx context.Context, id int64) (Activity, error)
+	// Archive makes the todos of the activity read-only and hides it from the listing, Unarchive undoes it
+	Archive(ctx context.Context, id int64) (Activity, error)
+	Unarchive(ctx context.Context, id int64) (Activity, error)
 }
 
 // ArticleRepository represent the article's repository contract
 type ActivityRepository interface {
-	Fetch(ctx context.Context, cursor string, num int64) (res []Activity, nextCursor string, err error)
+	Fetch(ctx context.Context, cursor string, num int64, filter ActivityFilter) (res []Activity, nextCursor string, prevCursor string, err error)
 	GetByID(ctx context.Context, id int64) (Activity, error)
 	GetByTitle(ctx context.Context, title string) (Activity, error)
+	// Update leaves the archived flag as it is, it is only written by SetArchived
 	Update(ctx context.Context, ar *Activity) error
 	Store(ctx context.Context, a *Activity) error
-	Delete(ctx context.Context, id int64) error
+	SetArchived(ctx context.Context, id int64, archived bool, updatedAt time.Time) error
+	// Delete moves the activity to the trash, every other method but the trash ones ignores the activities in the trash
+	Delete(ctx context.Context, id int64, deletedAt time.Time) error
+	GetDeletedByID(ctx context.Context, id int64) (Activity, error)
+	// FetchDeleted gives every activity in the trash, the most recently deleted first
+	FetchDeleted(ctx context.Context) ([]Activity, error)
+	Restore(ctx context.Context, id int64) error
+	// Purge deletes for good the activities in the trash since before the given time and no longer referred by any todo,
+	// it gives how many were deleted
+	Purge(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+// Clock represent the source of the current time, it lets the usecases be tested at a fixed time
+type Clock interface {
+	Now() time.Time
+}
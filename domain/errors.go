@@ -11,4 +11,14 @@ var (
 	ErrConflict = errors.New("your Item already exist")
 	// ErrBadParamInput will throw if the given request-body or params is not valid
 	ErrBadParamInput = errors.New("given Param is not valid")
+	// ErrUnknownActivity will throw if the todo refers to an activity group that is not exists
+	ErrUnknownActivity = errors.New("given activity_group_id is not exists")
+	// ErrActivityHasTodos will throw if the activity can't be deleted because it still holds todos
+	ErrActivityHasTodos = errors.New("your Item still has todos")
+	// ErrInvalidTransition will throw if the todo is not allowed to move to the requested status
+	ErrInvalidTransition = errors.New("given status transition is not allowed")
+	// ErrActivityArchived will throw if a todo of an archived activity, or moved into one, is about to be written
+	ErrActivityArchived = errors.New("given activity is archived")
+	// ErrBulkAborted will throw for the operations of a bulk rolled back or skipped because another one failed
+	ErrBulkAborted = errors.New("operation aborted, another operation of the bulk failed")
 )
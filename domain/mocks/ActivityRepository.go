@@ -2,6 +2,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/bxcodec/go-clean-arch/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -11,16 +12,18 @@ type ActivityRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *ActivityRepositoryMock) Delete(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
+func (m *ActivityRepositoryMock) Delete(ctx context.Context, id int64, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
 
 	return args.Error(0)
 }
 
-func (m *ActivityRepositoryMock) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Activity, string, error) {
-	args := m.Called(ctx, cursor, num)
+func (m *ActivityRepositoryMock) Fetch(ctx context.Context, cursor string, num int64, filter domain.ActivityFilter) ([]domain.Activity, string, string, error) {
+	args := m.Called(ctx, cursor, num, filter)
+
+	res, _ := args.Get(0).([]domain.Activity)
 
-	return args.Get(0).([]domain.Activity), args.Get(0).(string), args.Error(1)
+	return res, args.String(1), args.String(2), args.Error(3)
 }
 
 func (m *ActivityRepositoryMock) GetByID(ctx context.Context, id int64) (domain.Activity, error) {
@@ -46,3 +49,35 @@ func (m *ActivityRepositoryMock) Update(ctx context.Context, ar *domain.Activity
 
 	return args.Error(0)
 }
+
+func (m *ActivityRepositoryMock) GetDeletedByID(ctx context.Context, id int64) (domain.Activity, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
+
+func (m *ActivityRepositoryMock) SetArchived(ctx context.Context, id int64, archived bool, updatedAt time.Time) error {
+	args := m.Called(ctx, id, archived, updatedAt)
+
+	return args.Error(0)
+}
+
+func (m *ActivityRepositoryMock) FetchDeleted(ctx context.Context) ([]domain.Activity, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]domain.Activity)
+
+	return res, args.Error(1)
+}
+
+func (m *ActivityRepositoryMock) Restore(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *ActivityRepositoryMock) Purge(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -17,11 +17,20 @@ func (m *ActivityUsecaseMock) Delete(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
-// Fetch provides a mock function with given fields: ctx, cursor, num
-func (m *ActivityUsecaseMock) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Activity, string, error) {
-	args := m.Called(ctx, cursor, num)
+// Duplicate provides a mock function with given fields: ctx, id, dup
+func (m *ActivityUsecaseMock) Duplicate(ctx context.Context, id int64, dup domain.ActivityDuplicate) (domain.Activity, error) {
+	args := m.Called(ctx, id, dup)
 
-	return args.Get(0).([]domain.Activity), args.Get(0).(string), args.Error(1)
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
+
+// Fetch provides a mock function with given fields: ctx, cursor, num, filter
+func (m *ActivityUsecaseMock) Fetch(ctx context.Context, cursor string, num int64, filter domain.ActivityFilter) ([]domain.Activity, string, string, error) {
+	args := m.Called(ctx, cursor, num, filter)
+
+	res, _ := args.Get(0).([]domain.Activity)
+
+	return res, args.String(1), args.String(2), args.Error(3)
 }
 
 // GetByID provides a mock function with given fields: ctx, id
@@ -38,6 +47,13 @@ func (m *ActivityUsecaseMock) GetByTitle(ctx context.Context, title string) (dom
 	return args.Get(0).(domain.Activity), args.Error(1)
 }
 
+// Restore provides a mock function with given fields: ctx, id
+func (m *ActivityUsecaseMock) Restore(ctx context.Context, id int64) (domain.Activity, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
+
 // Store provides a mock function with given fields: _a0, _a1
 func (m *ActivityUsecaseMock) Store(_a0 context.Context, _a1 *domain.Activity) error {
 	args := m.Called(_a0, _a1)
@@ -51,3 +67,17 @@ func (m *ActivityUsecaseMock) Update(ctx context.Context, ar *domain.Activity) e
 
 	return args.Error(0)
 }
+
+// Archive provides a mock function with given fields: ctx, id
+func (m *ActivityUsecaseMock) Archive(ctx context.Context, id int64) (domain.Activity, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
+
+// Unarchive provides a mock function with given fields: ctx, id
+func (m *ActivityUsecaseMock) Unarchive(ctx context.Context, id int64) (domain.Activity, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
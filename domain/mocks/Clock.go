@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	time "time"
+)
+
+// ClockMock always tells the time it is set to
+type ClockMock struct {
+	Time time.Time
+}
+
+func (m *ClockMock) Now() time.Time {
+	return m.Time
+}
+
+// Advance will move the clock forward by the given duration
+func (m *ClockMock) Advance(d time.Duration) {
+	m.Time = m.Time.Add(d)
+}
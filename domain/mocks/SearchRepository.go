@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchRepositoryMock is a mock type for the domain.SearchRepository type
+type SearchRepositoryMock struct {
+	mock.Mock
+}
+
+// Search provides a mock function with given fields: ctx, query, cursor, num
+func (m *SearchRepositoryMock) Search(ctx context.Context, query string, cursor string, num int64) ([]domain.SearchResult, string, error) {
+	args := m.Called(ctx, query, cursor, num)
+
+	res, _ := args.Get(0).([]domain.SearchResult)
+
+	return res, args.String(1), args.Error(2)
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+type TagRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TagRepositoryMock) Fetch(ctx context.Context) ([]domain.Tag, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]domain.Tag)
+
+	return res, args.Error(1)
+}
+
+func (m *TagRepositoryMock) GetByID(ctx context.Context, id int64) (domain.Tag, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *TagRepositoryMock) GetByName(ctx context.Context, name string) (domain.Tag, error) {
+	args := m.Called(ctx, name)
+
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *TagRepositoryMock) Store(ctx context.Context, tag *domain.Tag) error {
+	args := m.Called(ctx, tag)
+
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) Update(ctx context.Context, tag *domain.Tag) error {
+	args := m.Called(ctx, tag)
+
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) MoveTodos(ctx context.Context, from int64, into int64) error {
+	args := m.Called(ctx, from, into)
+
+	return args.Error(0)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TagUsecaseMock is a mock type for the domain.TagUsecase type
+type TagUsecaseMock struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (m *TagUsecaseMock) Fetch(ctx context.Context) ([]domain.Tag, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]domain.Tag)
+
+	return res, args.Error(1)
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (m *TagUsecaseMock) GetByID(ctx context.Context, id int64) (domain.Tag, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+// Store provides a mock function with given fields: ctx, tag
+func (m *TagUsecaseMock) Store(ctx context.Context, tag *domain.Tag) error {
+	args := m.Called(ctx, tag)
+
+	return args.Error(0)
+}
+
+// Update provides a mock function with given fields: ctx, tag
+func (m *TagUsecaseMock) Update(ctx context.Context, tag *domain.Tag) error {
+	args := m.Called(ctx, tag)
+
+	return args.Error(0)
+}
+
+// Merge provides a mock function with given fields: ctx, id, into
+func (m *TagUsecaseMock) Merge(ctx context.Context, id int64, into int64) (domain.Tag, error) {
+	args := m.Called(ctx, id, into)
+
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (m *TagUsecaseMock) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
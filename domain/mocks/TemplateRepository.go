@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+type TemplateRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TemplateRepositoryMock) Fetch(ctx context.Context) ([]domain.Template, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]domain.Template)
+
+	return res, args.Error(1)
+}
+
+func (m *TemplateRepositoryMock) GetByID(ctx context.Context, id int64) (domain.Template, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Template), args.Error(1)
+}
+
+func (m *TemplateRepositoryMock) Store(ctx context.Context, t *domain.Template) error {
+	args := m.Called(ctx, t)
+
+	return args.Error(0)
+}
+
+func (m *TemplateRepositoryMock) Update(ctx context.Context, t *domain.Template) error {
+	args := m.Called(ctx, t)
+
+	return args.Error(0)
+}
+
+func (m *TemplateRepositoryMock) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+type TemplateUsecaseMock struct {
+	mock.Mock
+}
+
+func (m *TemplateUsecaseMock) Fetch(ctx context.Context) ([]domain.Template, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]domain.Template)
+
+	return res, args.Error(1)
+}
+
+func (m *TemplateUsecaseMock) GetByID(ctx context.Context, id int64) (domain.Template, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Template), args.Error(1)
+}
+
+func (m *TemplateUsecaseMock) Store(ctx context.Context, t *domain.Template) error {
+	args := m.Called(ctx, t)
+
+	return args.Error(0)
+}
+
+func (m *TemplateUsecaseMock) Update(ctx context.Context, t *domain.Template) error {
+	args := m.Called(ctx, t)
+
+	return args.Error(0)
+}
+
+func (m *TemplateUsecaseMock) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *TemplateUsecaseMock) FromActivity(ctx context.Context, activityID int64) (domain.Template, error) {
+	args := m.Called(ctx, activityID)
+
+	return args.Get(0).(domain.Template), args.Error(1)
+}
+
+func (m *TemplateUsecaseMock) Instantiate(ctx context.Context, id int64) (domain.Activity, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Activity), args.Error(1)
+}
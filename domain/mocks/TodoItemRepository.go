@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+type TodoItemRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TodoItemRepositoryMock) FetchByTodoID(ctx context.Context, todoID int64) ([]domain.TodoItem, error) {
+	args := m.Called(ctx, todoID)
+
+	res, _ := args.Get(0).([]domain.TodoItem)
+
+	return res, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) GetByID(ctx context.Context, id int64) (domain.TodoItem, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.TodoItem), args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) CountByTodoID(ctx context.Context, todoID int64) (int64, int64, error) {
+	args := m.Called(ctx, todoID)
+
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *TodoItemRepositoryMock) Store(ctx context.Context, item *domain.TodoItem) error {
+	args := m.Called(ctx, item)
+
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) Update(ctx context.Context, item *domain.TodoItem) error {
+	args := m.Called(ctx, item)
+
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) DeleteByTodoID(ctx context.Context, todoID int64) error {
+	args := m.Called(ctx, todoID)
+
+	return args.Error(0)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TodoItemUsecaseMock is a mock type for the domain.TodoItemUsecase type
+type TodoItemUsecaseMock struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx, todoID
+func (m *TodoItemUsecaseMock) Fetch(ctx context.Context, todoID int64) ([]domain.TodoItem, error) {
+	args := m.Called(ctx, todoID)
+
+	res, _ := args.Get(0).([]domain.TodoItem)
+
+	return res, args.Error(1)
+}
+
+// GetByID provides a mock function with given fields: ctx, todoID, id
+func (m *TodoItemUsecaseMock) GetByID(ctx context.Context, todoID int64, id int64) (domain.TodoItem, error) {
+	args := m.Called(ctx, todoID, id)
+
+	return args.Get(0).(domain.TodoItem), args.Error(1)
+}
+
+// Store provides a mock function with given fields: ctx, item
+func (m *TodoItemUsecaseMock) Store(ctx context.Context, item *domain.TodoItem) error {
+	args := m.Called(ctx, item)
+
+	return args.Error(0)
+}
+
+// Update provides a mock function with given fields: ctx, item
+func (m *TodoItemUsecaseMock) Update(ctx context.Context, item *domain.TodoItem) error {
+	args := m.Called(ctx, item)
+
+	return args.Error(0)
+}
+
+// Delete provides a mock function with given fields: ctx, todoID, id
+func (m *TodoItemUsecaseMock) Delete(ctx context.Context, todoID int64, id int64) error {
+	args := m.Called(ctx, todoID, id)
+
+	return args.Error(0)
+}
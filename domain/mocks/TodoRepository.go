@@ -2,6 +2,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/bxcodec/go-clean-arch/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -11,16 +12,18 @@ type TodoRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *TodoRepositoryMock) Delete(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
+func (m *TodoRepositoryMock) Delete(ctx context.Context, id int64, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
 
 	return args.Error(0)
 }
 
-func (m *TodoRepositoryMock) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Todo, string, error) {
-	args := m.Called(ctx, cursor, num)
+func (m *TodoRepositoryMock) Fetch(ctx context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) ([]domain.Todo, string, string, error) {
+	args := m.Called(ctx, cursor, num, filter, sort)
+
+	res, _ := args.Get(0).([]domain.Todo)
 
-	return args.Get(0).([]domain.Todo), args.Get(0).(string), args.Error(1)
+	return res, args.String(1), args.String(2), args.Error(3)
 }
 
 func (m *TodoRepositoryMock) GetByID(ctx context.Context, id int64) (domain.Todo, error) {
@@ -46,3 +49,65 @@ func (m *TodoRepositoryMock) Update(ctx context.Context, ar *domain.Todo) error
 
 	return args.Error(0)
 }
+
+func (m *TodoRepositoryMock) DeleteByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) error {
+	args := m.Called(ctx, activityGroupID, deletedAt)
+
+	return args.Error(0)
+}
+
+func (m *TodoRepositoryMock) DetachActivityGroup(ctx context.Context, activityGroupID int64) error {
+	args := m.Called(ctx, activityGroupID)
+
+	return args.Error(0)
+}
+
+func (m *TodoRepositoryMock) LastPosition(ctx context.Context, activityGroupID int64) (string, error) {
+	args := m.Called(ctx, activityGroupID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoRepositoryMock) NeighborPosition(ctx context.Context, activityGroupID int64, position string, next bool) (string, error) {
+	args := m.Called(ctx, activityGroupID, position, next)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoRepositoryMock) UpdatePosition(ctx context.Context, ar *domain.Todo) error {
+	args := m.Called(ctx, ar)
+
+	return args.Error(0)
+}
+
+func (m *TodoRepositoryMock) GetDeletedByID(ctx context.Context, id int64) (domain.Todo, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Todo), args.Error(1)
+}
+
+func (m *TodoRepositoryMock) FetchDeleted(ctx context.Context) ([]domain.Todo, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]domain.Todo)
+
+	return res, args.Error(1)
+}
+
+func (m *TodoRepositoryMock) Restore(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *TodoRepositoryMock) RestoreByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) error {
+	args := m.Called(ctx, activityGroupID, deletedAt)
+
+	return args.Error(0)
+}
+
+func (m *TodoRepositoryMock) Purge(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -10,6 +10,38 @@ type TodoUsecaseMock struct {
 	mock.Mock
 }
 
+// Bulk provides a mock function with given fields: ctx, ops, mode
+func (m *TodoUsecaseMock) Bulk(ctx context.Context, ops []domain.TodoOperation, mode domain.BulkMode) ([]domain.TodoOperationResult, error) {
+	args := m.Called(ctx, ops, mode)
+
+	res, _ := args.Get(0).([]domain.TodoOperationResult)
+
+	return res, args.Error(1)
+}
+
+// Complete provides a mock function with given fields: ctx, id
+func (m *TodoUsecaseMock) Complete(ctx context.Context, id int64) (domain.Todo, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Todo), args.Error(1)
+}
+
+// Copy provides a mock function with given fields: ctx, id, cp
+func (m *TodoUsecaseMock) Copy(ctx context.Context, id int64, cp domain.TodoCopy) (domain.Todo, error) {
+	args := m.Called(ctx, id, cp)
+
+	return args.Get(0).(domain.Todo), args.Error(1)
+}
+
+// CopyMany provides a mock function with given fields: ctx, ids, cp
+func (m *TodoUsecaseMock) CopyMany(ctx context.Context, ids []int64, cp domain.TodoCopy) ([]domain.Todo, error) {
+	args := m.Called(ctx, ids, cp)
+
+	res, _ := args.Get(0).([]domain.Todo)
+
+	return res, args.Error(1)
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (m *TodoUsecaseMock) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
@@ -17,11 +49,13 @@ func (m *TodoUsecaseMock) Delete(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
-// Fetch provides a mock function with given fields: ctx, cursor, num
-func (m *TodoUsecaseMock) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Todo, string, error) {
-	args := m.Called(ctx, cursor, num)
+// Fetch provides a mock function with given fields: ctx, cursor, num, filter, sort
+func (m *TodoUsecaseMock) Fetch(ctx context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) ([]domain.Todo, string, string, error) {
+	args := m.Called(ctx, cursor, num, filter, sort)
+
+	res, _ := args.Get(0).([]domain.Todo)
 
-	return args.Get(0).([]domain.Todo), args.Get(0).(string), args.Error(1)
+	return res, args.String(1), args.String(2), args.Error(3)
 }
 
 // GetByID provides a mock function with given fields: ctx, id
@@ -38,6 +72,36 @@ func (m *TodoUsecaseMock) GetByTitle(ctx context.Context, title string) (domain.
 	return args.Get(0).(domain.Todo), args.Error(1)
 }
 
+// Reopen provides a mock function with given fields: ctx, id
+func (m *TodoUsecaseMock) Reopen(ctx context.Context, id int64) (domain.Todo, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Todo), args.Error(1)
+}
+
+// Move provides a mock function with given fields: ctx, id, move
+func (m *TodoUsecaseMock) Move(ctx context.Context, id int64, move domain.TodoMove) (domain.Todo, error) {
+	args := m.Called(ctx, id, move)
+
+	return args.Get(0).(domain.Todo), args.Error(1)
+}
+
+// MoveMany provides a mock function with given fields: ctx, ids, activityGroupID
+func (m *TodoUsecaseMock) MoveMany(ctx context.Context, ids []int64, activityGroupID int64) ([]domain.Todo, error) {
+	args := m.Called(ctx, ids, activityGroupID)
+
+	res, _ := args.Get(0).([]domain.Todo)
+
+	return res, args.Error(1)
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (m *TodoUsecaseMock) Restore(ctx context.Context, id int64) (domain.Todo, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(domain.Todo), args.Error(1)
+}
+
 // Store provides a mock function with given fields: _a0, _a1
 func (m *TodoUsecaseMock) Store(_a0 context.Context, _a1 *domain.Todo) error {
 	args := m.Called(_a0, _a1)
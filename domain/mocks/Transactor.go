@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	context "context"
+)
+
+// TransactorMock runs the given function in place, without any transaction
+type TransactorMock struct{}
+
+func (m *TransactorMock) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *TransactorMock) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
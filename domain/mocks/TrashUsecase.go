@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/bxcodec/go-clean-arch/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrashUsecaseMock is a mock type for the domain.TrashUsecase type
+type TrashUsecaseMock struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx
+func (m *TrashUsecaseMock) Fetch(ctx context.Context) (domain.Trash, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).(domain.Trash), args.Error(1)
+}
+
+// Purge provides a mock function with given fields: ctx
+func (m *TrashUsecaseMock) Purge(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).(int64), args.Error(1)
+}
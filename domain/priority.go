@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Priority represent how urgent a todo is, a higher level is more urgent so it sorts naturally as a number
+type Priority int
+
+const (
+	// PriorityInvalid is given to a priority that doesn't match any level, it never passes the validation
+	PriorityInvalid Priority = -1
+	// PriorityVeryLow is the least urgent level
+	PriorityVeryLow Priority = 1
+	// PriorityLow ...
+	PriorityLow Priority = 2
+	// PriorityNormal ...
+	PriorityNormal Priority = 3
+	// PriorityHigh ...
+	PriorityHigh Priority = 4
+	// PriorityVeryHigh is the most urgent level
+	PriorityVeryHigh Priority = 5
+)
+
+var priorityNames = map[Priority]string{
+	PriorityVeryLow:  "very-low",
+	PriorityLow:      "low",
+	PriorityNormal:   "normal",
+	PriorityHigh:     "high",
+	PriorityVeryHigh: "very-high",
+}
+
+// ParsePriority will get the priority from its name, the integer level is still accepted for the older clients
+func ParsePriority(priorityS string) (Priority, error) {
+	for priority, name := range priorityNames {
+		if name == priorityS {
+			return priority, nil
+		}
+	}
+
+	level, err := strconv.Atoi(priorityS)
+	if err != nil || !Priority(level).IsValid() {
+		return PriorityInvalid, ErrBadParamInput
+	}
+	return Priority(level), nil
+}
+
+// IsValid will tell whether the priority is one of the levels
+func (p Priority) IsValid() bool {
+	_, ok := priorityNames[p]
+	return ok
+}
+
+// Values will list the names of the levels from the least to the most urgent
+func (p Priority) Values() []string {
+	values := make([]string, 0, len(priorityNames))
+	for level := PriorityVeryLow; level <= PriorityVeryHigh; level++ {
+		values = append(values, priorityNames[level])
+	}
+	return values
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return strconv.Itoa(int(p))
+}
+
+// MarshalJSON will write the priority by its name
+func (p Priority) MarshalJSON() ([]byte, error) {
+	if !p.IsValid() {
+		return json.Marshal(int(p))
+	}
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON will read the priority by its name or by its integer level,
+// an unknown value is kept as an invalid priority so the validation can report it on the field
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var level int
+	if err := json.Unmarshal(data, &level); err == nil {
+		*p = Priority(level)
+		if !p.IsValid() && level != 0 {
+			*p = PriorityInvalid
+		}
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		*p = PriorityInvalid
+		return nil
+	}
+
+	priority, err := ParsePriority(strings.TrimSpace(name))
+	if err != nil {
+		*p = PriorityInvalid
+		return nil
+	}
+	*p = priority
+	return nil
+}
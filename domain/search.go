@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+// SearchKind represent what kind of item a search result is
+type SearchKind string
+
+const (
+	// SearchKindActivity is the kind of the results being activities
+	SearchKindActivity SearchKind = "activity"
+	// SearchKindTodo is the kind of the results being todos
+	SearchKindTodo SearchKind = "todo"
+)
+
+// SearchResult represent an activity or a todo matching a search, the best match having the highest score.
+// Highlights give the matching fields by name, the matched terms wrapped in <mark> and the rest HTML escaped.
+type SearchResult struct {
+	Kind       SearchKind        `json:"kind"`
+	ID         int64             `json:"id"`
+	Title      string            `json:"title"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// SearchUsecase represent the search's usecases
+type SearchUsecase interface {
+	Search(ctx context.Context, query string, cursor string, num int64) ([]SearchResult, string, error)
+}
+
+// SearchRepository represent the search's repository contract, the results are ranked by score then kind and id
+type SearchRepository interface {
+	Search(ctx context.Context, query string, cursor string, num int64) (res []SearchResult, nextCursor string, err error)
+}
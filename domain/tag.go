@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Tag represent a free-form label shared by the todos of every activity group, its name is unique
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name" validate:"required,max=45"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NormalizeTagName will give the stored form of a tag name, tags are matched case-insensitively
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags will normalize the tag names, drop the empty and duplicated ones and sort them
+func NormalizeTags(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// TagMatch tells whether a todo has to carry any or all the tags of the filter
+type TagMatch string
+
+const (
+	// TagMatchAny keeps the todos carrying at least one of the tags
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll keeps the todos carrying every tag
+	TagMatchAll TagMatch = "all"
+)
+
+// ParseTagMatch will get the tag match from its textual form, any being the default
+func ParseTagMatch(matchS string) (TagMatch, error) {
+	switch match := TagMatch(matchS); match {
+	case "":
+		return TagMatchAny, nil
+	case TagMatchAny, TagMatchAll:
+		return match, nil
+	}
+	return "", ErrBadParamInput
+}
+
+// TagUsecase represent the tag's usecases
+type TagUsecase interface {
+	Fetch(ctx context.Context) ([]Tag, error)
+	GetByID(ctx context.Context, id int64) (Tag, error)
+	Store(ctx context.Context, tag *Tag) error
+	Update(ctx context.Context, tag *Tag) error
+	Merge(ctx context.Context, id int64, into int64) (Tag, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// TagRepository represent the tag's repository contract, the tags of a todo are written along with the todo
+type TagRepository interface {
+	Fetch(ctx context.Context) ([]Tag, error)
+	GetByID(ctx context.Context, id int64) (Tag, error)
+	GetByName(ctx context.Context, name string) (Tag, error)
+	Store(ctx context.Context, tag *Tag) error
+	Update(ctx context.Context, tag *Tag) error
+	Delete(ctx context.Context, id int64) error
+	MoveTodos(ctx context.Context, from int64, into int64) error
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Template represent an activity and its todos saved to be instantiated again, the titles of both may
+// hold the placeholders `{{date}}`, `{{week}}`, `{{month}}` and `{{year}}` filled on instantiation
+type Template struct {
+	ID        int64          `json:"id"`
+	Title     string         `json:"title" validate:"required,max=45"`
+	Email     string         `json:"email" validate:"required"`
+	Todos     []TemplateTodo `json:"todos" validate:"dive"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// TemplateTodo represent a todo of a template, in the order the todos are instantiated
+type TemplateTodo struct {
+	Title    string   `json:"title" validate:"required,max=45"`
+	Priority Priority `json:"priority" validate:"required,enum"`
+}
+
+// RenderTitle will fill the placeholders of a template title with the given day,
+// `{{date}}` is 2006-01-02, `{{week}}` the ISO week 2006-W01, `{{month}}` 2006-01 and `{{year}}` 2006. A placeholder
+// may be shorter than its value, the title is shortened when needed to fit in TitleMaxLength
+func RenderTitle(title string, day time.Time) string {
+	year, week := day.ISOWeek()
+	title = strings.NewReplacer(
+		"{{date}}", day.Format("2006-01-02"),
+		"{{week}}", fmt.Sprintf("%d-W%02d", year, week),
+		"{{month}}", day.Format("2006-01"),
+		"{{year}}", day.Format("2006"),
+	).Replace(title)
+	if runes := []rune(title); len(runes) > TitleMaxLength {
+		title = strings.TrimRight(string(runes[:TitleMaxLength]), " ")
+	}
+	return title
+}
+
+// TemplateUsecase represent the template's usecases
+type TemplateUsecase interface {
+	Fetch(ctx context.Context) ([]Template, error)
+	GetByID(ctx context.Context, id int64) (Template, error)
+	Store(ctx context.Context, t *Template) error
+	Update(ctx context.Context, t *Template) error
+	Delete(ctx context.Context, id int64) error
+	// FromActivity stores the activity and its todos, in their order, as a new template
+	FromActivity(ctx context.Context, activityID int64) (Template, error)
+	// Instantiate stores a new activity with its todos from the template, a taken title is suffixed the way copies are
+	Instantiate(ctx context.Context, id int64) (Activity, error)
+}
+
+// TemplateRepository represent the template's repository contract, a template is always read and written with its todos
+type TemplateRepository interface {
+	Fetch(ctx context.Context) ([]Template, error)
+	GetByID(ctx context.Context, id int64) (Template, error)
+	Store(ctx context.Context, t *Template) error
+	Update(ctx context.Context, t *Template) error
+	Delete(ctx context.Context, id int64) error
+}
@@ -2,36 +2,312 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
-// Todo ...
+// Todo represent a task of an activity group, a todo carrying a RFC 5545 recurrence rule
+// gets its next occurrence once it is done, the rule being evaluated in its time zone.
+// Completion is the percentage of the checklist items checked, it is only given with a single todo having items.
+// Tags are the normalized names of the labels of the todo.
+// Position is the fractional rank of the todo inside its activity group, see pkg/rank.
+// DeletedAt is only given with a todo in the trash.
+// Notes is the Markdown description of the todo, NotesHTML its sanitized rendering only given on request.
+// Only the id of ActivityGroupID is read from the requests, the todo of a deleted activity group has none.
 type Todo struct {
-	ID              int64     `json:"id"`
-	ActivityGroupID Activity  `json:"activity_group_id"`
-	Title           string    `json:"title" validate:"required"`
-	IsActive        int       `json:"is_active" validate:"required"`
-	Priority        int       `json:"priority" validate:"required"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	CreatedAt       time.Time `json:"created_at"`
+	ID              int64      `json:"id"`
+	ActivityGroupID Activity   `json:"activity_group_id" validate:"-"`
+	Title           string     `json:"title" validate:"required,max=45"`
+	Notes           string     `json:"notes" validate:"max=10000"`
+	NotesHTML       string     `json:"notes_html,omitempty"`
+	Status          TodoStatus `json:"status" validate:"omitempty,enum"`
+	Priority        Priority   `json:"priority" validate:"required,enum"`
+	DueAt           *time.Time `json:"due_at"`
+	Recurrence      string     `json:"recurrence"`
+	TimeZone        string     `json:"time_zone"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	Completion      *int       `json:"completion,omitempty"`
+	Tags            []string   `json:"tags,omitempty" validate:"dive,max=45"`
+	Position        string     `json:"position"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+// TodoStatus represent the step of the workflow a todo is at
+type TodoStatus string
+
+const (
+	// TodoStatusTodo is the status of a todo nobody has started yet
+	TodoStatusTodo TodoStatus = "todo"
+	// TodoStatusInProgress is the status of a todo being worked on
+	TodoStatusInProgress TodoStatus = "in_progress"
+	// TodoStatusDone is the status of a completed todo
+	TodoStatusDone TodoStatus = "done"
+	// TodoStatusCancelled is the status of a todo that will not be done
+	TodoStatusCancelled TodoStatus = "cancelled"
+)
+
+// ActiveTodoStatuses are the statuses of a todo that still has to be worked on
+var ActiveTodoStatuses = []TodoStatus{TodoStatusTodo, TodoStatusInProgress}
+
+// InactiveTodoStatuses are the statuses of a todo that is closed
+var InactiveTodoStatuses = []TodoStatus{TodoStatusDone, TodoStatusCancelled}
+
+// todoTransitions list the statuses a todo is allowed to move to from each status
+var todoTransitions = map[TodoStatus][]TodoStatus{
+	TodoStatusTodo:       {TodoStatusInProgress, TodoStatusDone, TodoStatusCancelled},
+	TodoStatusInProgress: {TodoStatusTodo, TodoStatusDone, TodoStatusCancelled},
+	TodoStatusDone:       {TodoStatusTodo},
+	TodoStatusCancelled:  {TodoStatusTodo},
+}
+
+// IsValid will tell whether the status is part of the workflow
+func (s TodoStatus) IsValid() bool {
+	_, ok := todoTransitions[s]
+	return ok
+}
+
+// Values will list the statuses of the workflow
+func (s TodoStatus) Values() []string {
+	return []string{string(TodoStatusTodo), string(TodoStatusInProgress), string(TodoStatusDone), string(TodoStatusCancelled)}
+}
+
+// CanTransitionTo will tell whether a todo is allowed to move from the status to the next one
+func (s TodoStatus) CanTransitionTo(next TodoStatus) bool {
+	for _, status := range todoTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTodoStatuses will build the statuses from their comma separated textual form, e.g. `todo,in_progress`
+func ParseTodoStatuses(statusS string) (statuses []TodoStatus, err error) {
+	for _, status := range strings.Split(statusS, ",") {
+		status := TodoStatus(strings.TrimSpace(status))
+		if !status.IsValid() {
+			return nil, ErrBadParamInput
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// TodoFilter represent the optional criteria to narrow down the todo listing,
+// DueAfter is inclusive while DueBefore is exclusive and Overdue keeps the open todos past their due_at.
+// Tags keeps the todos carrying any or all of the normalized tag names depending on TagMatch.
+// Now is the time Overdue compares the due_at to, the usecase sets it from its clock.
+type TodoFilter struct {
+	ActivityGroupID int64
+	Status          []TodoStatus
+	Priority        *Priority
+	DueAfter        *time.Time
+	DueBefore       *time.Time
+	Overdue         bool
+	Tags            []string
+	TagMatch        TagMatch
+	Now             time.Time
+}
+
+// TodoMove represent where a todo is dropped: right after the After todo and/or right before the Before todo,
+// or at the end of the activity group when no neighbor is given. The neighbors decide the activity group.
+type TodoMove struct {
+	Before          int64 `json:"before"`
+	After           int64 `json:"after"`
+	ActivityGroupID int64 `json:"activity_group_id"`
+}
+
+// TodoCopy represent where a todo is copied to, the copy restarts as a todo with an unchecked checklist on ResetStatus
+type TodoCopy struct {
+	ActivityGroupID int64 `json:"activity_group_id"`
+	ResetStatus     bool  `json:"reset_status"`
+}
+
+// TodoOperationKind represent what an operation of a bulk does to a todo
+type TodoOperationKind string
+
+const (
+	// TodoOperationCreate stores the todo of the operation
+	TodoOperationCreate TodoOperationKind = "create"
+	// TodoOperationUpdate replaces the todo given by ID with the todo of the operation
+	TodoOperationUpdate TodoOperationKind = "update"
+	// TodoOperationComplete marks the todo given by ID as done
+	TodoOperationComplete TodoOperationKind = "complete"
+	// TodoOperationDelete moves the todo given by ID to the trash
+	TodoOperationDelete TodoOperationKind = "delete"
+	// TodoOperationMove drops the todo given by ID where the move of the operation tells
+	TodoOperationMove TodoOperationKind = "move"
+)
+
+// IsValid will tell whether the kind is a known operation
+func (k TodoOperationKind) IsValid() bool {
+	switch k {
+	case TodoOperationCreate, TodoOperationUpdate, TodoOperationComplete, TodoOperationDelete, TodoOperationMove:
+		return true
+	}
+	return false
+}
+
+// Values will list the known operations
+func (k TodoOperationKind) Values() []string {
+	return []string{string(TodoOperationCreate), string(TodoOperationUpdate), string(TodoOperationComplete), string(TodoOperationDelete), string(TodoOperationMove)}
+}
+
+// TodoOperation represent a single write of a bulk, Todo is the payload of create and update, Move the one of move
+type TodoOperation struct {
+	Op   TodoOperationKind `json:"op" validate:"required,enum"`
+	ID   int64             `json:"id"`
+	Todo *Todo             `json:"todo"`
+	Move *TodoMove         `json:"move"`
+}
+
+// BulkMode represent how a bulk reacts to a failed operation
+type BulkMode string
+
+const (
+	// BulkAllOrNothing rolls back every operation once one of them fails
+	BulkAllOrNothing BulkMode = "all_or_nothing"
+	// BulkBestEffort only rolls back the failed operations and carries on with the next ones
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+// IsValid will tell whether the mode is a known one
+func (m BulkMode) IsValid() bool {
+	return m == BulkAllOrNothing || m == BulkBestEffort
+}
+
+// Values will list the known modes
+func (m BulkMode) Values() []string {
+	return []string{string(BulkAllOrNothing), string(BulkBestEffort)}
+}
+
+// TodoOperationResult represent the outcome of an operation of a bulk, Todo is nil when the todo is gone or on Err
+type TodoOperationResult struct {
+	Todo *Todo
+	Err  error
+}
+
+// TitleMaxLength is how many characters the title of an activity or a todo holds at most
+const TitleMaxLength = 45
+
+// CopyTitle will give the title of the n-th copy of a title, `Title (copy)` then `Title (copy 2)` and so on,
+// the title is shortened when needed for the copy to fit in TitleMaxLength
+func CopyTitle(title string, n int) string {
+	suffix := " (copy)"
+	if n > 1 {
+		suffix = fmt.Sprintf(" (copy %d)", n)
+	}
+	runes := []rune(title)
+	if room := TitleMaxLength - len(suffix); len(runes) > room && room > 0 {
+		title = strings.TrimRight(string(runes[:room]), " ")
+	}
+	return title + suffix
+}
+
+// occurrenceSuffix matches the date OccurrenceTitle puts at the end of the title
+var occurrenceSuffix = regexp.MustCompile(` \(\d{4}-\d{2}-\d{2}\)$`)
+
+// OccurrenceTitle will give the title of the occurrence of a recurring todo due on the given day, `Title (2006-01-02)`,
+// the date of the previous occurrence is replaced and the title is shortened when needed to fit in TitleMaxLength
+func OccurrenceTitle(title string, due time.Time) string {
+	suffix := due.Format(" (2006-01-02)")
+	runes := []rune(occurrenceSuffix.ReplaceAllString(title, ""))
+	if room := TitleMaxLength - len(suffix); len(runes) > room {
+		runes = []rune(strings.TrimRight(string(runes[:room]), " "))
+	}
+	return string(runes) + suffix
+}
+
+// TodoSortFields are the fields the todo listing is allowed to be ordered by
+var TodoSortFields = []string{"priority", "title", "position", "created_at", "updated_at"}
+
+// SortField represent a single key of the listing order
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// TodoSort represent the order of the todo listing, the earlier field takes precedence
+type TodoSort []SortField
+
+// ParseTodoSort will build the todo order from its textual form, e.g. `-priority,created_at`
+func ParseTodoSort(sortS string) (sort TodoSort, err error) {
+	if sortS == "" {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	for _, field := range strings.Split(sortS, ",") {
+		field = strings.TrimSpace(field)
+		descending := strings.HasPrefix(field, "-")
+		field = strings.TrimLeft(field, "+-")
+		if !isTodoSortField(field) || seen[field] {
+			return nil, ErrBadParamInput
+		}
+		seen[field] = true
+		sort = append(sort, SortField{Field: field, Descending: descending})
+	}
+
+	return sort, nil
+}
+
+func isTodoSortField(field string) bool {
+	for _, sortField := range TodoSortFields {
+		if field == sortField {
+			return true
+		}
+	}
+	return false
 }
 
 // ArticleUsecase represent the article's usecases
 type TodoUsecase interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]Todo, string, error)
+	Fetch(ctx context.Context, cursor string, num int64, filter TodoFilter, sort TodoSort) ([]Todo, string, string, error)
 	GetByID(ctx context.Context, id int64) (Todo, error)
 	Update(ctx context.Context, ar *Todo) error
 	GetByTitle(ctx context.Context, title string) (Todo, error)
 	Store(context.Context, *Todo) error
 	Delete(ctx context.Context, id int64) error
+	Complete(ctx context.Context, id int64) (Todo, error)
+	Reopen(ctx context.Context, id int64) (Todo, error)
+	Move(ctx context.Context, id int64, move TodoMove) (Todo, error)
+	// Restore takes the todo back out of the trash
+	Restore(ctx context.Context, id int64) (Todo, error)
+	// MoveMany moves every given todo to the end of the activity group, in the given order
+	MoveMany(ctx context.Context, ids []int64, activityGroupID int64) ([]Todo, error)
+	// Copy stores a copy of the todo and its checklist at the end of the activity group
+	Copy(ctx context.Context, id int64, cp TodoCopy) (Todo, error)
+	CopyMany(ctx context.Context, ids []int64, cp TodoCopy) ([]Todo, error)
+	// Bulk runs the operations in order inside a single transaction, giving the outcome of each of them
+	Bulk(ctx context.Context, ops []TodoOperation, mode BulkMode) ([]TodoOperationResult, error)
 }
 
 // ArticleRepository represent the article's repository contract
 type TodoRepository interface {
-	Fetch(ctx context.Context, cursor string, num int64) (res []Todo, nextCursor string, err error)
+	Fetch(ctx context.Context, cursor string, num int64, filter TodoFilter, sort TodoSort) (res []Todo, nextCursor string, prevCursor string, err error)
 	GetByID(ctx context.Context, id int64) (Todo, error)
 	GetByTitle(ctx context.Context, title string) (Todo, error)
 	Update(ctx context.Context, ar *Todo) error
 	Store(ctx context.Context, a *Todo) error
-	Delete(ctx context.Context, id int64) error
+	// Delete moves the todo to the trash, every other method but the trash ones ignores the todos in the trash
+	Delete(ctx context.Context, id int64, deletedAt time.Time) error
+	DeleteByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) error
+	DetachActivityGroup(ctx context.Context, activityGroupID int64) error
+	GetDeletedByID(ctx context.Context, id int64) (Todo, error)
+	// FetchDeleted gives every todo in the trash, the most recently deleted first
+	FetchDeleted(ctx context.Context) ([]Todo, error)
+	Restore(ctx context.Context, id int64) error
+	// RestoreByActivityGroupID takes back the todos of the activity group deleted along with it
+	RestoreByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) error
+	// Purge deletes for good the todos in the trash since before the given time, it gives how many were deleted
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	// LastPosition gives the highest position in the activity group, empty when it has no todo
+	LastPosition(ctx context.Context, activityGroupID int64) (string, error)
+	// NeighborPosition gives the closest position after the given one, or before it when next is false, empty when there is none
+	NeighborPosition(ctx context.Context, activityGroupID int64, position string, next bool) (string, error)
+	UpdatePosition(ctx context.Context, ar *Todo) error
 }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TodoItem represent an entry of the checklist of a todo, the items are ordered by their position
+type TodoItem struct {
+	ID        int64     `json:"id"`
+	TodoID    int64     `json:"todo_id"`
+	Title     string    `json:"title" validate:"required,max=255"`
+	Checked   bool      `json:"checked"`
+	Position  int       `json:"position"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TodoItemUsecase represent the checklist item's usecases, every item is reached through its todo
+type TodoItemUsecase interface {
+	Fetch(ctx context.Context, todoID int64) ([]TodoItem, error)
+	GetByID(ctx context.Context, todoID int64, id int64) (TodoItem, error)
+	Store(ctx context.Context, item *TodoItem) error
+	Update(ctx context.Context, item *TodoItem) error
+	Delete(ctx context.Context, todoID int64, id int64) error
+}
+
+// TodoItemRepository represent the checklist item's repository contract
+type TodoItemRepository interface {
+	FetchByTodoID(ctx context.Context, todoID int64) ([]TodoItem, error)
+	GetByID(ctx context.Context, id int64) (TodoItem, error)
+	CountByTodoID(ctx context.Context, todoID int64) (total int64, checked int64, err error)
+	Store(ctx context.Context, item *TodoItem) error
+	Update(ctx context.Context, item *TodoItem) error
+	Delete(ctx context.Context, id int64) error
+	DeleteByTodoID(ctx context.Context, todoID int64) error
+}
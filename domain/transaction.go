@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// Transactor represent the contract to run several repository calls as a single unit of work
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinSavepoint runs the function so that its failure only undoes its own writes, not the running transaction's
+	WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
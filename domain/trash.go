@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// Trash represent the deleted activities and todos, each of them stays restorable until the retention purges it
+type Trash struct {
+	Activities []Activity `json:"activities"`
+	Todos      []Todo     `json:"todos"`
+}
+
+// TrashUsecase represent the trash's usecases, the restore of an item belongs to the usecase of its kind
+type TrashUsecase interface {
+	Fetch(ctx context.Context) (Trash, error)
+	// Purge deletes for good what stayed in the trash longer than the retention, it gives how many rows were deleted
+	Purge(ctx context.Context) (int64, error)
+}
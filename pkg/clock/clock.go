@@ -0,0 +1,18 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type systemClock struct{}
+
+// New will create an object that represent the domain.Clock interface backed by the system time
+func New() domain.Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
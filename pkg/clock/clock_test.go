@@ -0,0 +1,17 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/pkg/clock"
+)
+
+func TestNow(t *testing.T) {
+	before := time.Now()
+	now := clock.New().Now()
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(time.Now()))
+}
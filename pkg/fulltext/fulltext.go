@@ -0,0 +1,202 @@
+package fulltext
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fold will lowercase the text rune by rune, so the folded text has as many runes as the original one
+func fold(text string) string {
+	return strings.Map(unicode.ToLower, text)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Terms will split the query into the lowercased words it looks for, without duplicates
+func Terms(query string) []string {
+	seen := map[string]bool{}
+	terms := make([]string, 0)
+	for _, term := range strings.FieldsFunc(fold(query), isSeparator) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Index is an inverted index over small texts. Like the MySQL ngram parser a term matches any word containing it,
+// and like the natural language mode a document matches as soon as one of the terms does.
+type Index struct {
+	keys     []string
+	postings map[string]map[int]int
+}
+
+// New will create an empty index
+func New() *Index {
+	return &Index{postings: map[string]map[int]int{}}
+}
+
+// Add will index the fields of the document known by key
+func (ix *Index) Add(key string, fields ...string) {
+	doc := len(ix.keys)
+	ix.keys = append(ix.keys, key)
+	for _, field := range fields {
+		for _, word := range strings.FieldsFunc(fold(field), isSeparator) {
+			if ix.postings[word] == nil {
+				ix.postings[word] = map[int]int{}
+			}
+			ix.postings[word][doc]++
+		}
+	}
+}
+
+// Match represent a document matching a query, the higher the score the better the match
+type Match struct {
+	Key   string
+	Score float64
+}
+
+// Search will give the documents matching at least one term of the query, the best match first.
+// A term weighs its occurrences in the document times its rarity over the index, the score is rounded to 6 decimals.
+func (ix *Index) Search(query string) []Match {
+	scores := map[int]float64{}
+	for _, term := range Terms(query) {
+		occurrences := map[int]int{}
+		for word, docs := range ix.postings {
+			if !strings.Contains(word, term) {
+				continue
+			}
+			for doc, count := range docs {
+				occurrences[doc] += count
+			}
+		}
+
+		idf := math.Log(float64(len(ix.keys)+1) / float64(len(occurrences)))
+		for doc, count := range occurrences {
+			scores[doc] += float64(count) * idf
+		}
+	}
+
+	matches := make([]Match, 0, len(scores))
+	for doc, score := range scores {
+		matches = append(matches, Match{Key: ix.keys[doc], Score: Round(score)})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Key < matches[j].Key
+	})
+	return matches
+}
+
+// Round will round the score to 6 decimals, the result parses back from FormatScore to the very same value
+func Round(score float64) float64 {
+	rounded, _ := strconv.ParseFloat(FormatScore(score), 64)
+	return rounded
+}
+
+// FormatScore will format the score with 6 decimals, as carried by the cursors
+func FormatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', 6, 64)
+}
+
+// Highlight will wrap every occurrence of the terms in the text within <mark> tags, the rest of the text is HTML escaped
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	folded := []rune(fold(text))
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		termRunes := []rune(term)
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(folded); i++ {
+			if string(folded[i:i+len(termRunes)]) == term {
+				for j := i; j < i+len(termRunes); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		chunk := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			chunk = "<mark>" + chunk + "</mark>"
+		}
+		b.WriteString(chunk)
+		i = j
+	}
+	return b.String()
+}
+
+// Excerpt will give about size characters of the text around the first occurrence of the terms, highlighted and
+// with an ellipsis where the text is cut. It is empty when none of the terms occurs in the text.
+func Excerpt(text string, terms []string, size int) string {
+	folded := fold(text)
+	first, last := -1, -1
+	for _, term := range terms {
+		if i := strings.Index(folded, term); i >= 0 && term != "" {
+			if at := utf8.RuneCountInString(folded[:i]); first < 0 || at < first {
+				first, last = at, at+utf8.RuneCountInString(term)
+			}
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	start := first - size/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + size
+	if end > len(runes) {
+		end = len(runes)
+		if start = end - size; start < 0 {
+			start = 0
+		}
+	}
+
+	// the cut words are dropped
+	if start > 0 && !unicode.IsSpace(runes[start-1]) {
+		for i := start; i < first; i++ {
+			if unicode.IsSpace(runes[i]) {
+				start = i + 1
+				break
+			}
+		}
+	}
+	if end < len(runes) && !unicode.IsSpace(runes[end]) {
+		for i := end - 1; i >= last; i-- {
+			if unicode.IsSpace(runes[i]) {
+				end = i
+				break
+			}
+		}
+	}
+
+	excerpt := Highlight(strings.TrimSpace(string(runes[start:end])), terms)
+	if start > 0 {
+		excerpt = "…" + excerpt
+	}
+	if end < len(runes) {
+		excerpt += "…"
+	}
+	return excerpt
+}
@@ -0,0 +1,58 @@
+package fulltext_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/pkg/fulltext"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"buy", "milk", "2"}, fulltext.Terms("  Buy, MILK & buy 2"))
+	assert.Empty(t, fulltext.Terms(" - ! "))
+}
+
+func TestSearch(t *testing.T) {
+	ix := fulltext.New()
+	ix.Add("todo:1", "Buy milk")
+	ix.Add("todo:2", "Buy bread", "and more bread")
+	ix.Add("activity:3", "Groceries")
+	ix.Add("todo:4", "Call mom")
+
+	matches := ix.Search("bread buy")
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "todo:2", matches[0].Key)
+	assert.Equal(t, "todo:1", matches[1].Key)
+	assert.True(t, matches[0].Score > matches[1].Score)
+
+	// a term matches the words containing it
+	matches = ix.Search("gROCer")
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "activity:3", matches[0].Key)
+
+	assert.Empty(t, ix.Search("dentist"))
+	assert.Empty(t, fulltext.New().Search("milk"))
+}
+
+func TestRound(t *testing.T) {
+	assert.Equal(t, 0.333333, fulltext.Round(1.0/3))
+	assert.Equal(t, "0.333333", fulltext.FormatScore(fulltext.Round(1.0/3)))
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t, "<mark>Buy</mark> <mark>milk</mark> &amp; <mark>buy</mark>er", fulltext.Highlight("Buy milk & buyer", []string{"buy", "milk"}))
+	assert.Equal(t, "<mark>STRAßE</mark> &lt;b&gt;", fulltext.Highlight("STRAßE <b>", []string{"straße"}))
+	assert.Equal(t, "no match", fulltext.Highlight("no match", []string{"milk"}))
+	assert.Equal(t, "", fulltext.Highlight("", []string{"milk"}))
+}
+
+func TestExcerpt(t *testing.T) {
+	text := "Call the landlord about the heating, then ask the plumber for a quote on the new boiler"
+
+	assert.Equal(t, "…ask the <mark>plumber</mark> for a quote on the new…", fulltext.Excerpt(text, []string{"plumber"}, 48))
+	assert.Equal(t, "…the <mark>land</mark>lord about the heating,…", fulltext.Excerpt(text, []string{"boiler", "land"}, 35))
+	assert.Equal(t, "…a quote on the new <mark>boiler</mark>", fulltext.Excerpt(text, []string{"boiler"}, 26))
+	assert.Equal(t, "<mark>Call</mark> me", fulltext.Excerpt("Call me", []string{"call"}, 26))
+	assert.Equal(t, "", fulltext.Excerpt(text, []string{"dentist"}, 26))
+}
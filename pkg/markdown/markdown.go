@@ -0,0 +1,96 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// rel is given to every link of the notes, they are written by users so they are not endorsed nor trusted
+const rel = "nofollow noopener noreferrer"
+
+// md is a CommonMark renderer, it is not given html.WithUnsafe so the raw HTML of the source is omitted
+var md = goldmark.New(
+	goldmark.WithParserOptions(parser.WithASTTransformers(util.Prioritized(safeLinks{}, 100))),
+)
+
+// Render will convert the Markdown source to HTML following CommonMark. Raw HTML of the source is omitted and the
+// links and images only keep the http, https and mailto schemes, so the output is safe to embed in a page as it is.
+func Render(src string) string {
+	var b bytes.Buffer
+	// writing to a buffer can't fail
+	_ = md.Convert([]byte(src), &b)
+	return b.String()
+}
+
+// safeLinks will mark the links as not endorsed and replace the links and images of unsafe url by their text
+type safeLinks struct{}
+
+func (safeLinks) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	// the tree is only changed once walked
+	var unsafe []ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch l := n.(type) {
+		case *ast.Link:
+			if !safeURL(l.Destination) {
+				unsafe = append(unsafe, n)
+				break
+			}
+			l.SetAttributeString("rel", []byte(rel))
+		case *ast.AutoLink:
+			if !safeURL(l.URL(source)) {
+				unsafe = append(unsafe, n)
+				break
+			}
+			l.SetAttributeString("rel", []byte(rel))
+		case *ast.Image:
+			if !safeURL(l.Destination) {
+				unsafe = append(unsafe, n)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, n := range unsafe {
+		parent := n.Parent()
+		if l, ok := n.(*ast.AutoLink); ok {
+			parent.ReplaceChild(parent, n, ast.NewString(l.Label(source)))
+			continue
+		}
+		for c := n.FirstChild(); c != nil; c = n.FirstChild() {
+			parent.InsertBefore(parent, n, c)
+		}
+		parent.RemoveChild(parent, n)
+	}
+}
+
+// safeURL will tell whether the url is relative or uses a scheme that can't run script, the url is checked the way it
+// is written to the page, with its escapes and character references resolved
+func safeURL(dest []byte) bool {
+	url := string(util.ResolveEntityNames(util.ResolveNumericReferences(util.UnescapePunctuations(dest))))
+	for _, r := range url {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return true
+	}
+	switch strings.ToLower(url[:colon]) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
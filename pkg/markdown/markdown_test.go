@@ -0,0 +1,82 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/pkg/markdown"
+)
+
+func TestRender(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		src  string
+		html string
+	}{
+		{name: "empty", src: "", html: ""},
+		{name: "paragraphs", src: "first line\nsame paragraph\n\nsecond", html: "<p>first line\nsame paragraph</p>\n<p>second</p>\n"},
+		{name: "hard-break", src: "one  \ntwo\\\nthree", html: "<p>one<br>\ntwo<br>\nthree</p>\n"},
+		{name: "headings", src: "# Title\n### Sub *part*\n#hashtag", html: "<h1>Title</h1>\n<h3>Sub <em>part</em></h3>\n<p>#hashtag</p>\n"},
+		{name: "emphasis", src: "**bold** and *em* and __strong__ _em_", html: "<p><strong>bold</strong> and <em>em</em> and <strong>strong</strong> <em>em</em></p>\n"},
+		{name: "nested-emphasis", src: "*a **b** c*", html: "<p><em>a <strong>b</strong> c</em></p>\n"},
+		{name: "snake-case", src: "call snake_case_name * 2", html: "<p>call snake_case_name * 2</p>\n"},
+		{name: "code-span", src: "run `go test ./... <x>` **now**", html: "<p>run <code>go test ./... &lt;x&gt;</code> <strong>now</strong></p>\n"},
+		{name: "fence", src: "```go\nif a < b {\n```\nafter", html: "<pre><code class=\"language-go\">if a &lt; b {\n</code></pre>\n<p>after</p>\n"},
+		{name: "rule", src: "above\n\n---\n* * *", html: "<p>above</p>\n<hr>\n<hr>\n"},
+		{name: "quote", src: "> quoted **text**\n> # title", html: "<blockquote>\n<p>quoted <strong>text</strong></p>\n<h1>title</h1>\n</blockquote>\n"},
+		{name: "list", src: "- one\n- two\n  continued\n* other", html: "<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n</ul>\n<ul>\n<li>other</li>\n</ul>\n"},
+		{name: "ordered-list", src: "3. three\n4. four", html: "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n"},
+		{name: "nested-list", src: "1. one\n   - sub\n2. two", html: "<ol>\n<li>one\n<ul>\n<li>sub</li>\n</ul>\n</li>\n<li>two</li>\n</ol>\n"},
+		{name: "link", src: "[the *docs*](https://example.com/a?b=1&c=2 \"title\")", html: "<p><a href=\"https://example.com/a?b=1&amp;c=2\" title=\"title\" rel=\"nofollow noopener noreferrer\">the <em>docs</em></a></p>\n"},
+		{name: "autolink", src: "<mailto:bagus@gmail.com>", html: "<p><a href=\"mailto:bagus@gmail.com\" rel=\"nofollow noopener noreferrer\">mailto:bagus@gmail.com</a></p>\n"},
+		{name: "escapes", src: `\*not em\* 1 \< 2`, html: "<p>*not em* 1 &lt; 2</p>\n"},
+		{name: "attribute-breakout", src: `[x](http://a.b/"onmouseover="alert(1))`, html: "<p><a href=\"http://a.b/%22onmouseover=%22alert(1)\" rel=\"nofollow noopener noreferrer\">x</a></p>\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.html, markdown.Render(tt.src))
+		})
+	}
+}
+
+func TestRenderRawHTML(t *testing.T) {
+	for _, src := range []string{
+		"<script>alert(1)</script>",
+		"<img src=x onerror=alert(1)>",
+		"text <b onclick=\"alert(1)\">bold</b> <iframe src=\"https://example.com\"></iframe>",
+		"<svg/onload=alert(1)>",
+	} {
+		t.Run(src, func(t *testing.T) {
+			out := markdown.Render(src)
+			assert.NotContains(t, out, "<script")
+			assert.NotContains(t, out, "<img")
+			assert.NotContains(t, out, "<b ")
+			assert.NotContains(t, out, "<iframe")
+			assert.NotContains(t, out, "<svg")
+			assert.NotContains(t, out, "onerror")
+			assert.NotContains(t, out, "onclick")
+		})
+	}
+}
+
+func TestRenderJavascriptLink(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		html string
+	}{
+		{src: "[click](javascript:alert(1))", html: "<p>click</p>\n"},
+		{src: "[click](JavaScript:alert(1))", html: "<p>click</p>\n"},
+		{src: "[click](<javascript:alert(1)>)", html: "<p>click</p>\n"},
+		{src: "[click](jav&#x61;script:alert(1))", html: "<p>click</p>\n"},
+		{src: "[click](javascript&colon;alert(1))", html: "<p>click</p>\n"},
+		{src: "[click](vbscript:msgbox(1))", html: "<p>click</p>\n"},
+		{src: "[click](data:text/html,x)", html: "<p>click</p>\n"},
+		{src: "<javascript:alert(1)>", html: "<p>javascript:alert(1)</p>\n"},
+		{src: "![*pic*](javascript:alert(1))", html: "<p><em>pic</em></p>\n"},
+		{src: "[click][ref]\n\n[ref]: javascript:alert(1)", html: "<p>click</p>\n"},
+	} {
+		t.Run(tt.src, func(t *testing.T) {
+			assert.Equal(t, tt.html, markdown.Render(tt.src))
+		})
+	}
+}
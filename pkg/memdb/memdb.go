@@ -0,0 +1,187 @@
+package memdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type txKey struct{}
+
+// Tables hold the rows of every table, the repositories reach them through DB.Exec only. A changed row is stored
+// again as a whole, never changed through a slice or a pointer it shares with a snapshot; the link sets of TodoTags
+// are the exception as the snapshots copy them. Todos are stored without their tags, which are linked through
+// TodoTags, and activity groups are referred by their id only.
+type Tables struct {
+	Activities map[int64]domain.Activity
+	Todos      map[int64]domain.Todo
+	TodoItems  map[int64]domain.TodoItem
+	Tags       map[int64]domain.Tag
+	// TodoTags link the id of a todo to the ids of its tags
+	TodoTags  map[int64]map[int64]bool
+	Templates map[int64]domain.Template
+
+	sequences map[string]int64
+}
+
+func newTables() Tables {
+	return Tables{
+		Activities: map[int64]domain.Activity{},
+		Todos:      map[int64]domain.Todo{},
+		TodoItems:  map[int64]domain.TodoItem{},
+		Tags:       map[int64]domain.Tag{},
+		TodoTags:   map[int64]map[int64]bool{},
+		Templates:  map[int64]domain.Template{},
+		sequences:  map[string]int64{},
+	}
+}
+
+// NextID will give the next id of the given table, like an auto increment column the ids are never reused
+func (t *Tables) NextID(table string) int64 {
+	t.sequences[table]++
+	return t.sequences[table]
+}
+
+// clone will copy the tables so that the copy is left untouched by the writes made afterward
+func (t *Tables) clone() Tables {
+	c := Tables{
+		Activities: make(map[int64]domain.Activity, len(t.Activities)),
+		Todos:      make(map[int64]domain.Todo, len(t.Todos)),
+		TodoItems:  make(map[int64]domain.TodoItem, len(t.TodoItems)),
+		Tags:       make(map[int64]domain.Tag, len(t.Tags)),
+		TodoTags:   make(map[int64]map[int64]bool, len(t.TodoTags)),
+		Templates:  make(map[int64]domain.Template, len(t.Templates)),
+		sequences:  make(map[string]int64, len(t.sequences)),
+	}
+	for id, row := range t.Activities {
+		c.Activities[id] = row
+	}
+	for id, row := range t.Todos {
+		c.Todos[id] = row
+	}
+	for id, row := range t.TodoItems {
+		c.TodoItems[id] = row
+	}
+	for id, row := range t.Tags {
+		c.Tags[id] = row
+	}
+	for id, tags := range t.TodoTags {
+		c.TodoTags[id] = make(map[int64]bool, len(tags))
+		for tagID := range tags {
+			c.TodoTags[id][tagID] = true
+		}
+	}
+	for id, row := range t.Templates {
+		c.Templates[id] = row
+	}
+	for table, id := range t.sequences {
+		c.sequences[table] = id
+	}
+	return c
+}
+
+// DB represent an in-memory database shared by the memory repositories, it also implements the domain.Transactor
+// interface. The units of work are serialized: a call made outside of a transaction waits for the running one to end,
+// so it never sees the writes of a transaction that may still be rolled back.
+type DB struct {
+	// txMu is held by the running transaction, or by a single call made outside of any transaction
+	txMu sync.Mutex
+	// mu guards the tables against the goroutines sharing the running transaction
+	mu     sync.Mutex
+	tables Tables
+}
+
+// New will create an empty database
+func New() *DB {
+	return &DB{tables: newTables()}
+}
+
+func (db *DB) inTransaction(ctx context.Context) bool {
+	tx, _ := ctx.Value(txKey{}).(*DB)
+	return tx == db
+}
+
+// Exec will run the function against the tables, within the transaction carried by the context if there is one
+func (db *DB) Exec(ctx context.Context, fn func(t *Tables) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !db.inTransaction(ctx) {
+		db.txMu.Lock()
+		defer db.txMu.Unlock()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return fn(&db.tables)
+}
+
+func (db *DB) snapshot() Tables {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.tables.clone()
+}
+
+// restore will bring the rows back to the snapshot, the sequences are kept as ids are not reused after a rollback
+func (db *DB) restore(snapshot Tables) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	snapshot.sequences = db.tables.sequences
+	db.tables = snapshot
+}
+
+// rollbackOnFailure will run the function and bring the tables back to the snapshot when it fails or panics
+func (db *DB) rollbackOnFailure(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	snapshot := db.snapshot()
+	defer func() {
+		if p := recover(); p != nil {
+			db.restore(snapshot)
+			panic(p)
+		}
+		if err != nil {
+			db.restore(snapshot)
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// WithinTransaction will run the function inside a transaction whose writes are undone when the function fails,
+// a call made while a transaction is already running joins the running one
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if db.inTransaction(ctx) {
+		return fn(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+	return db.rollbackOnFailure(context.WithValue(ctx, txKey{}, db), fn)
+}
+
+// WithinSavepoint will run the function so that its failure only undoes what it wrote,
+// without a running transaction it behaves like WithinTransaction
+func (db *DB) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !db.inTransaction(ctx) {
+		return db.WithinTransaction(ctx, fn)
+	}
+	return db.rollbackOnFailure(ctx, fn)
+}
+
+// Time will bring the time to what a DATETIME column stores, the UTC time rounded to the second
+func Time(t time.Time) time.Time {
+	return t.UTC().Round(time.Second)
+}
+
+// TimePtr will bring the time to what a nullable DATETIME column stores, see Time
+func TimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := Time(*t)
+	return &stored
+}
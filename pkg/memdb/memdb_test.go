@@ -0,0 +1,187 @@
+package memdb_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+)
+
+func store(ctx context.Context, db *memdb.DB, title string) (id int64, err error) {
+	err = db.Exec(ctx, func(t *memdb.Tables) error {
+		id = t.NextID("activity")
+		t.Activities[id] = domain.Activity{ID: id, Title: title}
+		return nil
+	})
+	return
+}
+
+func count(db *memdb.DB) (n int) {
+	_ = db.Exec(context.TODO(), func(t *memdb.Tables) error {
+		n = len(t.Activities)
+		return nil
+	})
+	return
+}
+
+func TestWithinTransaction(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		db := memdb.New()
+		err := db.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if _, err := store(ctx, db, "first"); err != nil {
+				return err
+			}
+
+			// nested call joins the running transaction
+			return db.WithinTransaction(ctx, func(ctx context.Context) error {
+				_, err := store(ctx, db, "second")
+				return err
+			})
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count(db))
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		db := memdb.New()
+		_, err := store(context.TODO(), db, "kept")
+		assert.NoError(t, err)
+
+		errExpected := errors.New("Unexpected Error")
+		err = db.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if _, err := store(ctx, db, "undone"); err != nil {
+				return err
+			}
+			return errExpected
+		})
+		assert.Equal(t, errExpected, err)
+		assert.Equal(t, 1, count(db))
+
+		// the ids taken by the rolled back transaction are not reused
+		id, err := store(context.TODO(), db, "next")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), id)
+	})
+
+	t.Run("rollback-on-panic", func(t *testing.T) {
+		db := memdb.New()
+		assert.Panics(t, func() {
+			_ = db.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+				_, _ = store(ctx, db, "undone")
+				panic("boom")
+			})
+		})
+		assert.Equal(t, 0, count(db))
+
+		// the lock is released along with the panic
+		_, err := store(context.TODO(), db, "next")
+		assert.NoError(t, err)
+	})
+
+	t.Run("serialized", func(t *testing.T) {
+		db := memdb.New()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan int)
+		go func() {
+			_ = db.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+				_, _ = store(ctx, db, "undone")
+				close(started)
+				<-release
+				return errors.New("Unexpected Error")
+			})
+		}()
+
+		<-started
+		go func() {
+			// waits for the running transaction instead of reading its writes
+			done <- count(db)
+		}()
+		select {
+		case <-done:
+			t.Fatal("the call made outside of the transaction did not wait for it")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		assert.Equal(t, 0, <-done)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		db := memdb.New()
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+
+		err := db.WithinTransaction(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.Equal(t, context.Canceled, err)
+		_, err = store(ctx, db, "canceled")
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func TestWithinSavepoint(t *testing.T) {
+	t.Run("rollback-to-savepoint", func(t *testing.T) {
+		db := memdb.New()
+		errExpected := errors.New("Unexpected Error")
+		err := db.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if _, err := store(ctx, db, "kept"); err != nil {
+				return err
+			}
+
+			err := db.WithinSavepoint(ctx, func(ctx context.Context) error {
+				if _, err := store(ctx, db, "undone"); err != nil {
+					return err
+				}
+				return errExpected
+			})
+			assert.Equal(t, errExpected, err)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count(db))
+	})
+
+	t.Run("without-transaction", func(t *testing.T) {
+		db := memdb.New()
+		errExpected := errors.New("Unexpected Error")
+		err := db.WithinSavepoint(context.TODO(), func(ctx context.Context) error {
+			if _, err := store(ctx, db, "undone"); err != nil {
+				return err
+			}
+			return errExpected
+		})
+		assert.Equal(t, errExpected, err)
+		assert.Equal(t, 0, count(db))
+	})
+}
+
+func TestConcurrentExec(t *testing.T) {
+	db := memdb.New()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store(context.TODO(), db, "concurrent")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, count(db))
+}
+
+func TestTime(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	given := time.Date(2020, 4, 1, 7, 30, 15, 600000000, loc)
+
+	assert.Equal(t, time.Date(2020, 4, 1, 0, 30, 16, 0, time.UTC), memdb.Time(given))
+	assert.Equal(t, time.Date(2020, 4, 1, 0, 30, 16, 0, time.UTC), *memdb.TimePtr(&given))
+	assert.Nil(t, memdb.TimePtr(nil))
+}
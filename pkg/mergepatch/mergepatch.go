@@ -0,0 +1,57 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+)
+
+// MIMEApplicationMergePatchJSON is the media type of a JSON merge-patch document
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// ErrMediaType tells the patch was sent as another media type than MIMEApplicationMergePatchJSON
+var ErrMediaType = errors.New("the patch has to be sent as " + MIMEApplicationMergePatchJSON)
+
+// IsMediaType will tell whether the Content-Type header value is the media type of a JSON merge-patch, whatever its
+// parameters
+func IsMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == MIMEApplicationMergePatchJSON
+}
+
+// Apply will apply the given JSON merge-patch (RFC 7396) to the original document
+func Apply(original, patch []byte) ([]byte, error) {
+	var patchVal interface{}
+	if err := json.Unmarshal(patch, &patchVal); err != nil {
+		return nil, err
+	}
+
+	var originalVal interface{}
+	if err := json.Unmarshal(original, &originalVal); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(originalVal, patchVal))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = merge(targetObj[key], value)
+	}
+
+	return targetObj
+}
@@ -0,0 +1,64 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/pkg/mergepatch"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "replace-field",
+			original: `{"title":"Hello","priority":1}`,
+			patch:    `{"priority":3}`,
+			expected: `{"title":"Hello","priority":3}`,
+		},
+		{
+			name:     "remove-field",
+			original: `{"title":"Hello","priority":1}`,
+			patch:    `{"priority":null}`,
+			expected: `{"title":"Hello"}`,
+		},
+		{
+			name:     "nested-object",
+			original: `{"activity_group_id":{"id":1,"title":"Work"}}`,
+			patch:    `{"activity_group_id":{"id":2}}`,
+			expected: `{"activity_group_id":{"id":2,"title":"Work"}}`,
+		},
+		{
+			name:     "replace-array",
+			original: `{"tags":["a","b"]}`,
+			patch:    `{"tags":["c"]}`,
+			expected: `{"tags":["c"]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := mergepatch.Apply([]byte(tc.original), []byte(tc.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(res))
+		})
+	}
+
+	t.Run("invalid-patch", func(t *testing.T) {
+		_, err := mergepatch.Apply([]byte(`{}`), []byte(`{`))
+		assert.Error(t, err)
+	})
+}
+
+func TestIsMediaType(t *testing.T) {
+	assert.True(t, mergepatch.IsMediaType("application/merge-patch+json"))
+	assert.True(t, mergepatch.IsMediaType("application/merge-patch+json; charset=utf-8"))
+	assert.False(t, mergepatch.IsMediaType("application/json"))
+	assert.False(t, mergepatch.IsMediaType(""))
+}
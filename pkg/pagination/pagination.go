@@ -0,0 +1,123 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	timeFormat = "2006-01-02T15:04:05.999Z07:00" // reduce precision from RFC3339Nano as date format
+
+	cursorVersion = 1
+	macLength     = 16
+)
+
+const (
+	// DirectionNext is used by cursor that point to the page after the given position
+	DirectionNext = "next"
+	// DirectionPrev is used by cursor that point to the page before the given position
+	DirectionPrev = "prev"
+)
+
+// ErrInvalidCursor will throw if the given cursor is malformed or has been tampered
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// secret keys the MAC of every cursor, a random one is drawn at startup until SetSecret replaces it
+var secret = randomSecret()
+
+func randomSecret() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// SetSecret will key the cursors with the given secret, so that they outlive a restart and are accepted by every
+// instance sharing it. It is meant to be called once at startup, before any cursor is handed out
+func SetSecret(key []byte) {
+	secret = key
+}
+
+// Cursor represent the position of a page in a listing, it carries the sort keys values
+// of the boundary row along with the filter and sort order the page was produced with
+type Cursor struct {
+	Version   int      `json:"v"`
+	Direction string   `json:"d"`
+	Values    []string `json:"k"`
+	ID        int64    `json:"i"`
+	Filter    string   `json:"f,omitempty"`
+	Sort      string   `json:"s"`
+}
+
+// DecodeCursor will decode the cursor of a listing from user
+func DecodeCursor(encodedCursor string) (Cursor, error) {
+	var c Cursor
+	if err := Open(encodedCursor, &c); err != nil {
+		return Cursor{}, err
+	}
+	if c.Version != cursorVersion || (c.Direction != DirectionNext && c.Direction != DirectionPrev) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// EncodeCursor will encode the cursor of a listing to user
+func EncodeCursor(c Cursor) string {
+	c.Version = cursorVersion
+	return Seal(c)
+}
+
+// Seal will encode the value as JSON followed by its HMAC-SHA256, the value can be read by anyone but a cursor
+// forged or edited without the secret is rejected by Open
+func Seal(v interface{}) string {
+	payload, _ := json.Marshal(v)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(mac(payload))
+}
+
+// Open will verify the cursor produced by Seal and decode its value into v
+func Open(encoded string, v interface{}) error {
+	parts := strings.Split(encoded, ".")
+	if len(parts) != 2 {
+		return ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sum, mac(payload)) {
+		return ErrInvalidCursor
+	}
+
+	if err = json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	return h.Sum(nil)[:macLength]
+}
+
+// EncodeTime will format the time to be stored as cursor value
+func EncodeTime(t time.Time) string {
+	return t.Format(timeFormat)
+}
+
+// DecodeTime will parse the time stored as cursor value
+func DecodeTime(value string) (time.Time, error) {
+	return time.Parse(timeFormat, value)
+}
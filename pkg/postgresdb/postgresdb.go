@@ -0,0 +1,56 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Open will open a connection pool to the database of the given DSN and make sure it is reachable
+func Open(dsn string) (*sql.DB, error) {
+	dbConn, err := sql.Open(`postgres`, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = dbConn.Ping(); err != nil {
+		_ = dbConn.Close()
+		return nil, err
+	}
+	return dbConn, nil
+}
+
+// Args collects the arguments of a statement built piece by piece, handing out the $n placeholder of each one
+type Args []interface{}
+
+// Add will append the value and return its placeholder
+func (a *Args) Add(value interface{}) string {
+	*a = append(*a, value)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// AddAll will append every value and return their placeholders separated by commas, as an IN list expects
+func (a *Args) AddAll(values ...interface{}) string {
+	placeholders := make([]string, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, a.Add(value))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// IsUniqueViolation will tell whether the statement failed on a UNIQUE constraint
+func IsUniqueViolation(err error) bool {
+	return isConstraint(err, "unique_violation")
+}
+
+// IsForeignKeyViolation will tell whether the statement failed on a FOREIGN KEY constraint
+func IsForeignKeyViolation(err error) bool {
+	return isConstraint(err, "foreign_key_violation")
+}
+
+func isConstraint(err error, name string) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == name
+}
@@ -0,0 +1,79 @@
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits are the symbols of a rank in increasing order, a rank compares as a plain string
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// ErrInvalidRange will throw if the lower rank is not strictly before the upper one, or a rank is malformed
+var ErrInvalidRange = errors.New("invalid rank range")
+
+// Between will give a rank sorting strictly after lower and strictly before upper, an empty bound is open.
+// Ranks are fractional: there is always room between two ranks, so a single item moves without touching the others.
+func Between(lower, upper string) (string, error) {
+	if !Valid(lower) || !Valid(upper) || (lower != "" && upper != "" && lower >= upper) {
+		return "", ErrInvalidRange
+	}
+	return midpoint(lower, upper), nil
+}
+
+// Valid will tell whether the rank is made of the rank digits and doesn't end with the lowest one,
+// a trailing lowest digit would leave no room before the rank. The empty rank is valid as an open bound.
+func Valid(r string) bool {
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(digits, r[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(r, digits[:1])
+}
+
+// midpoint will find the shortest rank between the two, the upper one being open when empty
+func midpoint(lower, upper string) string {
+	if upper != "" {
+		// the common prefix is kept as is
+		n := 0
+		for n < len(upper) && digitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			return upper[:n] + midpoint(suffix(lower, n), upper[n:])
+		}
+	}
+
+	low := 0
+	if lower != "" {
+		low = strings.IndexByte(digits, lower[0])
+	}
+	high := len(digits)
+	if upper != "" {
+		high = strings.IndexByte(digits, upper[0])
+	}
+
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+	// the first digits are consecutive, the first digit of a longer upper bound already sorts between
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	return string(digits[low]) + midpoint(suffix(lower, 1), "")
+}
+
+// digitAt will give the digit of the rank at the position, a rank is padded with the lowest digit
+func digitAt(r string, i int) byte {
+	if i < len(r) {
+		return r[i]
+	}
+	return digits[0]
+}
+
+func suffix(r string, i int) string {
+	if i < len(r) {
+		return r[i:]
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package rank_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/pkg/rank"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		lower    string
+		upper    string
+		expected string
+		err      bool
+	}{
+		{lower: "", upper: "", expected: "i"},
+		{lower: "i", upper: "", expected: "r"},
+		{lower: "", upper: "i", expected: "9"},
+		{lower: "i", upper: "j", expected: "ii"},
+		{lower: "1", upper: "11", expected: "10i"},
+		{lower: "z", upper: "", expected: "zi"},
+		{lower: "", upper: "01", expected: "00i"},
+		{lower: "a", upper: "az", expected: "ai"},
+		{lower: "j", upper: "i", err: true},
+		{lower: "i", upper: "i", err: true},
+		{lower: "i0", upper: "", err: true},
+		{lower: "I", upper: "", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lower+"_"+tt.upper, func(t *testing.T) {
+			r, err := rank.Between(tt.lower, tt.upper)
+			if tt.err {
+				assert.Equal(t, rank.ErrInvalidRange, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r)
+			assert.True(t, tt.lower < r)
+			assert.True(t, tt.upper == "" || r < tt.upper)
+		})
+	}
+}
+
+func TestBetweenRepeatedly(t *testing.T) {
+	// inserting again and again at the same place keeps the order and the ranks short enough
+	lower, upper := "i", "j"
+	for i := 0; i < 100; i++ {
+		r, err := rank.Between(lower, upper)
+		require.NoError(t, err)
+		require.True(t, lower < r && r < upper, "%s is not between %s and %s", r, lower, upper)
+		require.True(t, rank.Valid(r))
+		if i%2 == 0 {
+			lower = r
+		} else {
+			upper = r
+		}
+	}
+	assert.True(t, len(lower) < 60)
+}
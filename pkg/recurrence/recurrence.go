@@ -0,0 +1,334 @@
+package recurrence
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency represent the period a rule repeats on
+type Frequency string
+
+const (
+	// Daily repeats every day
+	Daily Frequency = "DAILY"
+	// Weekly repeats every week, the weeks start on monday
+	Weekly Frequency = "WEEKLY"
+	// Monthly repeats every month
+	Monthly Frequency = "MONTHLY"
+	// Yearly repeats every year on the month and day of the start
+	Yearly Frequency = "YEARLY"
+)
+
+const (
+	untilFormat     = "20060102T150405Z"
+	untilDateFormat = "20060102"
+
+	// maxPeriods bounds the search of a rule that never matches again, e.g. every 12 months on the 30th starting in february
+	maxPeriods = 1000
+)
+
+// ErrInvalidRule will throw if the rule is malformed or use a part that is not supported
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of the RFC 5545 RRULE made of FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+// The occurrences keep the wall clock time of the start in its location, so they don't drift across DST changes,
+// and a day missing from a month, e.g. the 31st, is skipped rather than moved.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Count      int
+	// Until is the last instant an occurrence may start at, zero when the rule has no end
+	Until time.Time
+	// untilDate tells the Until was given as a date, it then covers the whole day in the location of the start
+	untilDate bool
+}
+
+// Parse will build the rule from its textual form, e.g. `FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE`
+func Parse(ruleS string) (Rule, error) {
+	ruleS = strings.TrimPrefix(strings.TrimSpace(ruleS), "RRULE:")
+	if ruleS == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	r := Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(ruleS, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || seen[kv[0]] {
+			return Rule{}, ErrInvalidRule
+		}
+		seen[kv[0]] = true
+
+		var err error
+		switch key, value := kv[0], kv[1]; key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly && r.Freq != Yearly {
+				return Rule{}, ErrInvalidRule
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(value)
+		case "COUNT":
+			r.Count, err = positive(value)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		case "WKST":
+			// only the default week start is supported
+			if value != "MO" {
+				err = ErrInvalidRule
+			}
+		default:
+			err = ErrInvalidRule
+		}
+		if err != nil {
+			return Rule{}, ErrInvalidRule
+		}
+	}
+
+	if r.Freq == "" || (r.Count > 0 && !r.Until.IsZero()) {
+		return Rule{}, ErrInvalidRule
+	}
+	if r.Freq == Yearly && (len(r.ByDay) > 0 || len(r.ByMonthDay) > 0) {
+		return Rule{}, ErrInvalidRule
+	}
+	return r, nil
+}
+
+func positive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, ErrInvalidRule
+	}
+	return n, nil
+}
+
+func (r *Rule) parseUntil(value string) (err error) {
+	if len(value) == len(untilDateFormat) {
+		r.Until, err = time.Parse(untilDateFormat, value)
+		r.untilDate = true
+		return
+	}
+	r.Until, err = time.Parse(untilFormat, value)
+	return
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	days := make([]time.Weekday, 0)
+	seen := map[time.Weekday]bool{}
+	for _, name := range strings.Split(value, ",") {
+		// ordinal weekdays such as 1MO or -1FR are not supported
+		day, ok := weekdays[name]
+		if !ok || seen[day] {
+			return nil, ErrInvalidRule
+		}
+		seen[day] = true
+		days = append(days, day)
+	}
+
+	sort.Slice(days, func(i, j int) bool { return weekdayIndex(days[i]) < weekdayIndex(days[j]) })
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	days := make([]int, 0)
+	for _, dayS := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(dayS)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, ErrInvalidRule
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// String will format the rule in its canonical textual form
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			names = append(names, strings.ToUpper(day.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDateFormat))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilFormat))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next will get the first occurrence of the series starting at start that comes strictly after the given time,
+// false when the series is over. The COUNT is not consumed here, the caller tracks how many occurrences are left.
+func (r Rule) Next(start, after time.Time) (time.Time, bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	for period := 0; period < maxPeriods; period++ {
+		for _, occurrence := range r.candidates(start, period*interval) {
+			if occurrence.Before(start) || !occurrence.After(after) {
+				continue
+			}
+			if !r.within(occurrence) {
+				return time.Time{}, false
+			}
+			return occurrence, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r Rule) within(occurrence time.Time) bool {
+	if r.Until.IsZero() {
+		return true
+	}
+	if r.untilDate {
+		y, m, d := occurrence.Date()
+		return !time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(r.Until)
+	}
+	return !occurrence.After(r.Until)
+}
+
+// candidates will list in order the occurrences of the given period, the period being counted from the start
+func (r Rule) candidates(start time.Time, offset int) []time.Time {
+	y, m, d := start.Date()
+	switch r.Freq {
+	case Daily:
+		day := time.Date(y, m, d+offset, 0, 0, 0, 0, time.UTC)
+		return r.filter(start, []time.Time{day})
+	case Weekly:
+		monday := time.Date(y, m, d-weekdayIndex(start.Weekday())+7*offset, 0, 0, 0, 0, time.UTC)
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{start.Weekday()}
+		}
+		days := make([]time.Time, 0, len(byDay))
+		for _, weekday := range byDay {
+			days = append(days, monday.AddDate(0, 0, weekdayIndex(weekday)))
+		}
+		return r.filter(start, days)
+	case Monthly:
+		first := time.Date(y, m+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		return r.filter(start, r.monthDays(first, d))
+	case Yearly:
+		first := time.Date(y+offset, m, 1, 0, 0, 0, 0, time.UTC)
+		return r.filter(start, validDay(first, d))
+	}
+	return nil
+}
+
+// monthDays will list the days of the month the rule occurs on, the day of the start being the default
+func (r Rule) monthDays(first time.Time, startDay int) []time.Time {
+	if len(r.ByMonthDay) > 0 {
+		days := make([]time.Time, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day = daysIn(first) + day + 1
+			}
+			days = append(days, validDay(first, day)...)
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+		return days
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]time.Time, 0, daysIn(first))
+		for day := 1; day <= daysIn(first); day++ {
+			days = append(days, first.AddDate(0, 0, day-1))
+		}
+		return days
+	}
+
+	return validDay(first, startDay)
+}
+
+// filter will keep the days matching the BYDAY and BYMONTHDAY parts and set them at the wall clock time of the start
+func (r Rule) filter(start time.Time, days []time.Time) []time.Time {
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		if len(r.ByDay) > 0 && !hasWeekday(r.ByDay, day.Weekday()) {
+			continue
+		}
+		if len(r.ByMonthDay) > 0 && !hasMonthDay(r.ByMonthDay, day) {
+			continue
+		}
+		occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(),
+			start.Hour(), start.Minute(), start.Second(), 0, start.Location()))
+	}
+	return occurrences
+}
+
+// validDay will give the day of the month, or nothing when the month is too short for it
+func validDay(first time.Time, day int) []time.Time {
+	if day < 1 || day > daysIn(first) {
+		return nil
+	}
+	return []time.Time{first.AddDate(0, 0, day-1)}
+}
+
+func daysIn(first time.Time) int {
+	return first.AddDate(0, 1, -1).Day()
+}
+
+// weekdayIndex will give the position of the day in a week starting on monday
+func weekdayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func hasWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMonthDay(days []int, day time.Time) bool {
+	for _, d := range days {
+		if d < 0 {
+			d = daysIn(day.AddDate(0, 0, 1-day.Day())) + d + 1
+		}
+		if d == day.Day() {
+			return true
+		}
+	}
+	return false
+}
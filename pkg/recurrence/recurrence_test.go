@@ -0,0 +1,249 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/pkg/recurrence"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule      string
+		canonical string
+		err       bool
+	}{
+		{rule: "FREQ=DAILY", canonical: "FREQ=DAILY"},
+		{rule: "RRULE:FREQ=WEEKLY;BYDAY=WE,MO;INTERVAL=2", canonical: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", canonical: "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3"},
+		{rule: "FREQ=YEARLY;UNTIL=20301231T000000Z", canonical: "FREQ=YEARLY;UNTIL=20301231T000000Z"},
+		{rule: "FREQ=DAILY;UNTIL=20301231;WKST=MO", canonical: "FREQ=DAILY;UNTIL=20301231"},
+		{rule: "", err: true},
+		{rule: "FREQ=HOURLY", err: true},
+		{rule: "INTERVAL=2", err: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", err: true},
+		{rule: "FREQ=DAILY;FREQ=WEEKLY", err: true},
+		{rule: "FREQ=WEEKLY;BYDAY=1MO", err: true},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,MO", err: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", err: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=0", err: true},
+		{rule: "FREQ=DAILY;COUNT=2;UNTIL=20301231", err: true},
+		{rule: "FREQ=YEARLY;BYDAY=MO", err: true},
+		{rule: "FREQ=DAILY;BYHOUR=9", err: true},
+		{rule: "FREQ=DAILY;WKST=SU", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := recurrence.Parse(tt.rule)
+			if tt.err {
+				assert.Equal(t, recurrence.ErrInvalidRule, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.canonical, r.String())
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		after time.Time
+		next  []time.Time
+		// over tells the series has no occurrence after the listed ones
+		over bool
+	}{
+		{
+			name:  "daily",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 3, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "daily-keeps-wall-clock-across-spring-forward",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2021, 3, 27, 9, 0, 0, 0, berlin),
+			next: []time.Time{
+				time.Date(2021, 3, 28, 9, 0, 0, 0, berlin),
+				time.Date(2021, 3, 29, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:  "weekly-keeps-wall-clock-across-fall-back",
+			rule:  "FREQ=WEEKLY",
+			start: time.Date(2021, 10, 25, 9, 0, 0, 0, berlin),
+			next: []time.Time{
+				time.Date(2021, 11, 1, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:  "daily-in-skipped-hour",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2021, 3, 27, 2, 30, 0, 0, berlin),
+			next: []time.Time{
+				time.Date(2021, 3, 28, 3, 30, 0, 0, berlin),
+				time.Date(2021, 3, 29, 2, 30, 0, 0, berlin),
+			},
+		},
+		{
+			name:  "weekdays-only",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: time.Date(2021, 3, 5, 9, 0, 0, 0, time.UTC), // friday
+			next: []time.Time{
+				time.Date(2021, 3, 8, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 9, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "every-other-week-on-two-days",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			start: time.Date(2021, 3, 3, 18, 0, 0, 0, time.UTC), // wednesday
+			next: []time.Time{
+				time.Date(2021, 3, 15, 18, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 17, 18, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 29, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "weekly-on-sunday-ends-the-week",
+			rule:  "FREQ=WEEKLY;BYDAY=SU",
+			start: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC), // monday
+			next: []time.Time{
+				time.Date(2021, 3, 7, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 14, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly-skips-short-months",
+			rule:  "FREQ=MONTHLY",
+			start: time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 5, 31, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly-on-last-day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 4, 30, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly-on-last-day-of-leap-february",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly-on-several-days",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=15,1",
+			start: time.Date(2021, 3, 10, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 3, 15, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 4, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 4, 15, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly-on-fridays",
+			rule:  "FREQ=MONTHLY;BYDAY=FR",
+			start: time.Date(2021, 4, 30, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 5, 7, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 5, 14, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "monthly-on-friday-the-13th",
+			rule:  "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			start: time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 8, 13, 9, 0, 0, 0, time.UTC),
+				time.Date(2022, 5, 13, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "yearly-on-leap-day",
+			rule:  "FREQ=YEARLY",
+			start: time.Date(2020, 2, 29, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "until-time",
+			rule:  "FREQ=DAILY;UNTIL=20210303T090000Z",
+			start: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 3, 9, 0, 0, 0, time.UTC),
+			},
+			over: true,
+		},
+		{
+			name:  "until-date-covers-the-whole-day",
+			rule:  "FREQ=DAILY;UNTIL=20210302",
+			start: time.Date(2021, 3, 1, 23, 0, 0, 0, berlin),
+			next: []time.Time{
+				time.Date(2021, 3, 2, 23, 0, 0, 0, berlin),
+			},
+			over: true,
+		},
+		{
+			name:  "after-later-than-start",
+			rule:  "FREQ=WEEKLY",
+			start: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC),
+			after: time.Date(2021, 3, 20, 0, 0, 0, 0, time.UTC),
+			next: []time.Time{
+				time.Date(2021, 3, 22, 9, 0, 0, 0, time.UTC),
+				time.Date(2021, 3, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "never-matching",
+			rule:  "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30",
+			start: time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC),
+			over:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := recurrence.Parse(tt.rule)
+			require.NoError(t, err)
+
+			after := tt.after
+			if after.IsZero() {
+				after = tt.start
+			}
+			for _, expected := range tt.next {
+				next, ok := r.Next(tt.start, after)
+				require.True(t, ok, "expected %s", expected)
+				assert.True(t, expected.Equal(next), "expected %s, got %s", expected, next)
+				after = next
+			}
+
+			_, ok := r.Next(tt.start, after)
+			assert.Equal(t, !tt.over, ok)
+		})
+	}
+}
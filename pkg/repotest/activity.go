@@ -0,0 +1,205 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// RunActivity will check the backend honors the contract of domain.ActivityRepository
+func RunActivity(t *testing.T, factory Factory) {
+	t.Run("store-then-get", func(t *testing.T) {
+		testActivityStoreThenGet(t, factory(t).Activity)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		testActivityPagination(t, factory(t).Activity)
+	})
+	t.Run("update", func(t *testing.T) {
+		testActivityUpdate(t, factory(t).Activity)
+	})
+	t.Run("delete", func(t *testing.T) {
+		testActivityDelete(t, factory(t).Activity)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		testActivityNotFound(t, factory(t).Activity)
+	})
+}
+
+func storeActivity(t *testing.T, repo domain.ActivityRepository, title string, createdAt time.Time) domain.Activity {
+	a := domain.Activity{
+		Email:     "bagus@gmail.com",
+		Title:     title,
+		UpdatedAt: createdAt,
+		CreatedAt: createdAt,
+	}
+	require.NoError(t, repo.Store(ctx(), &a))
+	require.NotZero(t, a.ID)
+	return a
+}
+
+func testActivityStoreThenGet(t *testing.T, repo domain.ActivityRepository) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	a := domain.Activity{
+		Email:     "Bagus@gmail.com",
+		Title:     "Belanja Bulanan",
+		UpdatedAt: time.Date(2021, 3, 1, 17, 0, 0, 600000000, wib),
+		CreatedAt: time.Date(2021, 3, 1, 17, 0, 0, 0, wib),
+	}
+	require.NoError(t, repo.Store(ctx(), &a))
+	require.NotZero(t, a.ID)
+	other := storeActivity(t, repo, "Olahraga", at)
+	assert.True(t, other.ID > a.ID, "the ids are not increasing")
+
+	res, err := repo.GetByID(ctx(), a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, a.ID, res.ID)
+	assert.Equal(t, "Bagus@gmail.com", res.Email)
+	assert.Equal(t, "Belanja Bulanan", res.Title)
+	assert.False(t, res.Archived)
+	assertTime(t, at.Add(time.Second), res.UpdatedAt, "updated_at")
+	assertTime(t, at, res.CreatedAt, "created_at")
+	assert.Nil(t, res.DeletedAt)
+
+	res, err = repo.GetByTitle(ctx(), "belanja bulanan")
+	require.NoError(t, err)
+	assert.Equal(t, a.ID, res.ID)
+
+	res, err = repo.GetByID(ctx(), other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Olahraga", res.Title)
+}
+
+func testActivityPagination(t *testing.T, repo domain.ActivityRepository) {
+	// the first two share their creation time so that the id breaks the tie across the boundary of the first page
+	var expected []int64
+	for i, createdAt := range []time.Time{at, at, at.Add(time.Hour), at.Add(2 * time.Hour), at.Add(3 * time.Hour)} {
+		a := storeActivity(t, repo, "Activity "+string(rune('A'+i)), createdAt)
+		expected = append(expected, a.ID)
+	}
+	archived := storeActivity(t, repo, "Archived", at.Add(4*time.Hour))
+	require.NoError(t, repo.SetArchived(ctx(), archived.ID, true, at.Add(4*time.Hour)))
+
+	var last string
+	fetch := func(filter domain.ActivityFilter) func(cursor string) ([]int64, string, string) {
+		return func(cursor string) ([]int64, string, string) {
+			res, nextCursor, prevCursor, err := repo.Fetch(ctx(), cursor, 2, filter)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(res))
+			for _, a := range res {
+				ids = append(ids, a.ID)
+			}
+			if prevCursor != "" {
+				last = prevCursor
+			}
+			return ids, nextCursor, prevCursor
+		}
+	}
+
+	pages := walk(t, "", true, fetch(domain.ActivityFilter{}))
+	assert.Equal(t, [][]int64{expected[0:2], expected[2:4], expected[4:5]}, pages)
+
+	pages = walk(t, last, false, fetch(domain.ActivityFilter{}))
+	assert.Equal(t, [][]int64{expected[2:4], expected[0:2]}, pages)
+
+	pages = walk(t, "", true, fetch(domain.ActivityFilter{IncludeArchived: true}))
+	assert.Equal(t, [][]int64{expected[0:2], expected[2:4], {expected[4], archived.ID}}, pages)
+}
+
+func testActivityUpdate(t *testing.T, repo domain.ActivityRepository) {
+	a := storeActivity(t, repo, "Belanja", at)
+	other := storeActivity(t, repo, "Olahraga", at)
+	require.NoError(t, repo.SetArchived(ctx(), a.ID, true, at.Add(time.Minute)))
+
+	updatedAt := at.Add(time.Hour)
+	a.Email = "joko@gmail.com"
+	a.Title = "Belanja Mingguan"
+	a.UpdatedAt = updatedAt
+	a.CreatedAt = at.Add(2 * time.Hour)
+	require.NoError(t, repo.Update(ctx(), &a))
+
+	res, err := repo.GetByID(ctx(), a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "joko@gmail.com", res.Email)
+	assert.Equal(t, "Belanja Mingguan", res.Title)
+	assert.True(t, res.Archived, "the update wrote the archived flag")
+	assertTime(t, updatedAt, res.UpdatedAt, "updated_at")
+	assertTime(t, at, res.CreatedAt, "created_at")
+
+	res, err = repo.GetByID(ctx(), other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "bagus@gmail.com", res.Email)
+	assert.Equal(t, "Olahraga", res.Title)
+	assertTime(t, at, res.UpdatedAt, "updated_at")
+
+	// updating with the values it already holds is no different from any other update
+	require.NoError(t, repo.Update(ctx(), &a))
+}
+
+func testActivityDelete(t *testing.T, repo domain.ActivityRepository) {
+	a := storeActivity(t, repo, "Belanja", at)
+	other := storeActivity(t, repo, "Olahraga", at)
+
+	deletedAt := at.Add(time.Hour)
+	require.NoError(t, repo.Delete(ctx(), a.ID, deletedAt))
+
+	_, err := repo.GetByID(ctx(), a.ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repo.GetByTitle(ctx(), a.Title)
+	assert.Equal(t, domain.ErrNotFound, err)
+	res, _, _, err := repo.Fetch(ctx(), "", 10, domain.ActivityFilter{IncludeArchived: true})
+	require.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, other.ID, res[0].ID)
+	}
+
+	deleted, err := repo.GetDeletedByID(ctx(), a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Belanja", deleted.Title)
+	assertTimePtr(t, &deletedAt, deleted.DeletedAt, "deleted_at")
+	_, err = repo.GetDeletedByID(ctx(), other.ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	trash, err := repo.FetchDeleted(ctx())
+	require.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.Equal(t, a.ID, trash[0].ID)
+	}
+
+	assert.Error(t, repo.Delete(ctx(), a.ID, deletedAt))
+	assert.Error(t, repo.Update(ctx(), &a))
+
+	require.NoError(t, repo.Restore(ctx(), a.ID))
+	res1, err := repo.GetByID(ctx(), a.ID)
+	require.NoError(t, err)
+	assert.Nil(t, res1.DeletedAt)
+	assert.Error(t, repo.Restore(ctx(), a.ID))
+	trash, err = repo.FetchDeleted(ctx())
+	require.NoError(t, err)
+	assert.Len(t, trash, 0)
+}
+
+func testActivityNotFound(t *testing.T, repo domain.ActivityRepository) {
+	a := storeActivity(t, repo, "Belanja", at)
+	unknown := a.ID + 100
+
+	_, err := repo.GetByID(ctx(), unknown)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repo.GetByTitle(ctx(), "Olahraga")
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repo.GetDeletedByID(ctx(), unknown)
+	assert.Equal(t, domain.ErrNotFound, err)
+
+	assert.Error(t, repo.Update(ctx(), &domain.Activity{ID: unknown, Email: "bagus@gmail.com", Title: "Olahraga", UpdatedAt: at}))
+	assert.Error(t, repo.SetArchived(ctx(), unknown, true, at))
+	assert.Error(t, repo.Delete(ctx(), unknown, at))
+	assert.Error(t, repo.Restore(ctx(), unknown))
+
+	res, next, prev, err := repo.Fetch(ctx(), "", 10, domain.ActivityFilter{})
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Empty(t, next)
+	assert.Empty(t, prev)
+}
@@ -0,0 +1,77 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// Repositories are the repositories of the backend under test, sharing one database
+type Repositories struct {
+	Activity domain.ActivityRepository
+	Todo     domain.TodoRepository
+}
+
+// Factory will open the repositories of the backend on an empty database, it is called once by every test of the
+// contract and is expected to release what it opens through t.Cleanup
+type Factory func(t *testing.T) Repositories
+
+// Run will check the backend honors the contract shared by every implementation of domain.ActivityRepository and
+// domain.TodoRepository: what is stored reads back the same, the pages chain up without gap or overlap, an update
+// persists, a delete moves to the trash and what does not exist is reported as such
+func Run(t *testing.T, factory Factory) {
+	t.Run("activity", func(t *testing.T) {
+		RunActivity(t, factory)
+	})
+	t.Run("todo", func(t *testing.T) {
+		RunTodo(t, factory)
+	})
+}
+
+// at is the reference time of the contract, the stored times are read back in UTC and rounded to the second
+var at = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func assertTime(t *testing.T, expected time.Time, actual time.Time, field string) {
+	assert.True(t, expected.Equal(actual), "%s: expected %s, got %s", field, expected, actual)
+	assert.Equal(t, time.UTC, actual.Location(), "%s is not in UTC", field)
+}
+
+func assertTimePtr(t *testing.T, expected *time.Time, actual *time.Time, field string) {
+	if expected == nil {
+		assert.Nil(t, actual, field)
+		return
+	}
+	if assert.NotNil(t, actual, field) {
+		assertTime(t, *expected, *actual, field)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// walk will fetch every page following the cursors in the given direction, starting from the given cursor
+func walk(t *testing.T, cursor string, next bool, fetch func(cursor string) (ids []int64, nextCursor string, prevCursor string)) (pages [][]int64) {
+	for i := 0; i < 10; i++ {
+		ids, nextCursor, prevCursor := fetch(cursor)
+		pages = append(pages, ids)
+
+		cursor = prevCursor
+		if next {
+			cursor = nextCursor
+		}
+		if cursor == "" {
+			return
+		}
+	}
+	t.Fatal("the cursors do not come to an end")
+	return
+}
+
+func ctx() context.Context {
+	return context.TODO()
+}
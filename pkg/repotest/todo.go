@@ -0,0 +1,290 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// RunTodo will check the backend honors the contract of domain.TodoRepository
+func RunTodo(t *testing.T, factory Factory) {
+	t.Run("store-then-get", func(t *testing.T) {
+		testTodoStoreThenGet(t, factory(t))
+	})
+	t.Run("pagination", func(t *testing.T) {
+		testTodoPagination(t, factory(t))
+	})
+	t.Run("update", func(t *testing.T) {
+		testTodoUpdate(t, factory(t))
+	})
+	t.Run("delete", func(t *testing.T) {
+		testTodoDelete(t, factory(t))
+	})
+	t.Run("not-found", func(t *testing.T) {
+		testTodoNotFound(t, factory(t))
+	})
+}
+
+func storeTodo(t *testing.T, repo domain.TodoRepository, activityGroupID int64, title string, priority domain.Priority, createdAt time.Time) domain.Todo {
+	todo := domain.Todo{
+		ActivityGroupID: domain.Activity{ID: activityGroupID},
+		Title:           title,
+		Status:          domain.TodoStatusTodo,
+		Priority:        priority,
+		UpdatedAt:       createdAt,
+		CreatedAt:       createdAt,
+	}
+	require.NoError(t, repo.Store(ctx(), &todo))
+	require.NotZero(t, todo.ID)
+	return todo
+}
+
+func testTodoStoreThenGet(t *testing.T, repos Repositories) {
+	a := storeActivity(t, repos.Activity, "Belanja", at)
+
+	wib := time.FixedZone("WIB", 7*60*60)
+	dueAt := time.Date(2021, 3, 5, 17, 0, 0, 400000000, wib)
+	todo := domain.Todo{
+		ActivityGroupID: domain.Activity{ID: a.ID},
+		Title:           "Beli Sayur",
+		Notes:           "**bayam** dan kangkung",
+		Status:          domain.TodoStatusInProgress,
+		Priority:        domain.PriorityHigh,
+		DueAt:           &dueAt,
+		Recurrence:      "FREQ=WEEKLY",
+		TimeZone:        "Asia/Jakarta",
+		Tags:            []string{"pasar", "dapur"},
+		Position:        "a0",
+		UpdatedAt:       at,
+		CreatedAt:       at,
+	}
+	require.NoError(t, repos.Todo.Store(ctx(), &todo))
+	require.NotZero(t, todo.ID)
+	detached := storeTodo(t, repos.Todo, 0, "Tanpa Activity", domain.PriorityLow, at)
+	assert.True(t, detached.ID > todo.ID, "the ids are not increasing")
+
+	res, err := repos.Todo.GetByID(ctx(), todo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, todo.ID, res.ID)
+	assert.Equal(t, a.ID, res.ActivityGroupID.ID)
+	assert.Equal(t, "Beli Sayur", res.Title)
+	assert.Equal(t, "**bayam** dan kangkung", res.Notes)
+	assert.Equal(t, domain.TodoStatusInProgress, res.Status)
+	assert.Equal(t, domain.PriorityHigh, res.Priority)
+	assertTimePtr(t, timePtr(time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC)), res.DueAt, "due_at")
+	assert.Equal(t, "FREQ=WEEKLY", res.Recurrence)
+	assert.Equal(t, "Asia/Jakarta", res.TimeZone)
+	assert.Nil(t, res.CompletedAt)
+	assert.Equal(t, []string{"dapur", "pasar"}, res.Tags)
+	assert.Equal(t, "a0", res.Position)
+	assertTime(t, at, res.UpdatedAt, "updated_at")
+	assertTime(t, at, res.CreatedAt, "created_at")
+	assert.Nil(t, res.DeletedAt)
+
+	res, err = repos.Todo.GetByTitle(ctx(), "beli sayur")
+	require.NoError(t, err)
+	assert.Equal(t, todo.ID, res.ID)
+	assert.Equal(t, []string{"dapur", "pasar"}, res.Tags)
+
+	res, err = repos.Todo.GetByID(ctx(), detached.ID)
+	require.NoError(t, err)
+	assert.Zero(t, res.ActivityGroupID.ID)
+	assert.Empty(t, res.Tags)
+	assert.Nil(t, res.DueAt)
+
+	assert.Error(t, repos.Todo.Store(ctx(), &domain.Todo{
+		ActivityGroupID: domain.Activity{ID: a.ID + 100},
+		Title:           "Beli Buah",
+		Status:          domain.TodoStatusTodo,
+		Priority:        domain.PriorityNormal,
+		UpdatedAt:       at,
+		CreatedAt:       at,
+	}), "the todo was stored with an unknown activity group")
+}
+
+func testTodoPagination(t *testing.T, repos Repositories) {
+	a := storeActivity(t, repos.Activity, "Belanja", at)
+	other := storeActivity(t, repos.Activity, "Olahraga", at)
+
+	// the first two share their creation time so that the id breaks the tie across the boundary of the first page,
+	// the last two share their priority the same way
+	priorities := []domain.Priority{domain.PriorityLow, domain.PriorityVeryHigh, domain.PriorityNormal, domain.PriorityHigh, domain.PriorityHigh}
+	createdAts := []time.Time{at, at, at.Add(time.Hour), at.Add(2 * time.Hour), at.Add(3 * time.Hour)}
+	var todos []domain.Todo
+	for i := range priorities {
+		todos = append(todos, storeTodo(t, repos.Todo, a.ID, "Todo "+string(rune('A'+i)), priorities[i], createdAts[i]))
+	}
+	storeTodo(t, repos.Todo, other.ID, "Lari Pagi", domain.PriorityVeryHigh, at)
+	ids := func(indexes ...int) []int64 {
+		res := make([]int64, 0, len(indexes))
+		for _, i := range indexes {
+			res = append(res, todos[i].ID)
+		}
+		return res
+	}
+
+	var last string
+	fetch := func(sort domain.TodoSort) func(cursor string) ([]int64, string, string) {
+		return func(cursor string) ([]int64, string, string) {
+			res, nextCursor, prevCursor, err := repos.Todo.Fetch(ctx(), cursor, 2, domain.TodoFilter{ActivityGroupID: a.ID}, sort)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(res))
+			for _, todo := range res {
+				ids = append(ids, todo.ID)
+			}
+			if prevCursor != "" {
+				last = prevCursor
+			}
+			return ids, nextCursor, prevCursor
+		}
+	}
+
+	pages := walk(t, "", true, fetch(nil))
+	assert.Equal(t, [][]int64{ids(0, 1), ids(2, 3), ids(4)}, pages)
+
+	pages = walk(t, last, false, fetch(nil))
+	assert.Equal(t, [][]int64{ids(2, 3), ids(0, 1)}, pages)
+
+	byPriority := domain.TodoSort{{Field: "priority", Descending: true}}
+	pages = walk(t, "", true, fetch(byPriority))
+	assert.Equal(t, [][]int64{ids(1, 4), ids(3, 2), ids(0)}, pages)
+
+	pages = walk(t, last, false, fetch(byPriority))
+	assert.Equal(t, [][]int64{ids(3, 2), ids(1, 4)}, pages)
+}
+
+func testTodoUpdate(t *testing.T, repos Repositories) {
+	a := storeActivity(t, repos.Activity, "Belanja", at)
+	other := storeActivity(t, repos.Activity, "Olahraga", at)
+	todo := storeTodo(t, repos.Todo, a.ID, "Beli Sayur", domain.PriorityNormal, at)
+	todo.Tags = []string{"pasar", "dapur"}
+	require.NoError(t, repos.Todo.Update(ctx(), &todo))
+	sibling := storeTodo(t, repos.Todo, a.ID, "Beli Buah", domain.PriorityLow, at)
+
+	updatedAt := at.Add(time.Hour)
+	completedAt := at.Add(30 * time.Minute)
+	todo.ActivityGroupID = domain.Activity{ID: other.ID}
+	todo.Title = "Beli Sayur Segar"
+	todo.Notes = "di pasar pagi"
+	todo.Status = domain.TodoStatusDone
+	todo.Priority = domain.PriorityVeryHigh
+	todo.CompletedAt = &completedAt
+	todo.Tags = []string{"dapur", "mingguan"}
+	todo.Position = "b0"
+	todo.UpdatedAt = updatedAt
+	todo.CreatedAt = at.Add(2 * time.Hour)
+	require.NoError(t, repos.Todo.Update(ctx(), &todo))
+
+	res, err := repos.Todo.GetByID(ctx(), todo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, res.ActivityGroupID.ID)
+	assert.Equal(t, "Beli Sayur Segar", res.Title)
+	assert.Equal(t, "di pasar pagi", res.Notes)
+	assert.Equal(t, domain.TodoStatusDone, res.Status)
+	assert.Equal(t, domain.PriorityVeryHigh, res.Priority)
+	assertTimePtr(t, &completedAt, res.CompletedAt, "completed_at")
+	assert.Equal(t, []string{"dapur", "mingguan"}, res.Tags)
+	assert.Equal(t, "b0", res.Position)
+	assertTime(t, updatedAt, res.UpdatedAt, "updated_at")
+	assertTime(t, at, res.CreatedAt, "created_at")
+
+	res, err = repos.Todo.GetByID(ctx(), sibling.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Beli Buah", res.Title)
+	assertTime(t, at, res.UpdatedAt, "updated_at")
+
+	// updating a todo writes to the todo table alone, the activities it moved from and to are left as they were
+	for _, expected := range []domain.Activity{a, other} {
+		res, err := repos.Activity.GetByID(ctx(), expected.ID)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Email, res.Email)
+		assert.Equal(t, expected.Title, res.Title)
+		assertTime(t, expected.UpdatedAt, res.UpdatedAt, "activity updated_at")
+	}
+
+	todo.Tags = nil
+	todo.CompletedAt = nil
+	require.NoError(t, repos.Todo.Update(ctx(), &todo))
+	res, err = repos.Todo.GetByID(ctx(), todo.ID)
+	require.NoError(t, err)
+	assert.Empty(t, res.Tags)
+	assert.Nil(t, res.CompletedAt)
+
+	todo.ActivityGroupID = domain.Activity{ID: other.ID + 100}
+	assert.Error(t, repos.Todo.Update(ctx(), &todo), "the todo was moved to an unknown activity group")
+}
+
+func testTodoDelete(t *testing.T, repos Repositories) {
+	a := storeActivity(t, repos.Activity, "Belanja", at)
+	todo := storeTodo(t, repos.Todo, a.ID, "Beli Sayur", domain.PriorityNormal, at)
+	other := storeTodo(t, repos.Todo, a.ID, "Beli Buah", domain.PriorityNormal, at)
+
+	deletedAt := at.Add(time.Hour)
+	require.NoError(t, repos.Todo.Delete(ctx(), todo.ID, deletedAt))
+
+	_, err := repos.Todo.GetByID(ctx(), todo.ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repos.Todo.GetByTitle(ctx(), todo.Title)
+	assert.Equal(t, domain.ErrNotFound, err)
+	res, _, _, err := repos.Todo.Fetch(ctx(), "", 10, domain.TodoFilter{}, nil)
+	require.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, other.ID, res[0].ID)
+	}
+
+	deleted, err := repos.Todo.GetDeletedByID(ctx(), todo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Beli Sayur", deleted.Title)
+	assertTimePtr(t, &deletedAt, deleted.DeletedAt, "deleted_at")
+	_, err = repos.Todo.GetDeletedByID(ctx(), other.ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	trash, err := repos.Todo.FetchDeleted(ctx())
+	require.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.Equal(t, todo.ID, trash[0].ID)
+	}
+
+	assert.Error(t, repos.Todo.Delete(ctx(), todo.ID, deletedAt))
+	assert.Error(t, repos.Todo.Update(ctx(), &todo))
+
+	require.NoError(t, repos.Todo.Restore(ctx(), todo.ID))
+	restored, err := repos.Todo.GetByID(ctx(), todo.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Error(t, repos.Todo.Restore(ctx(), todo.ID))
+	trash, err = repos.Todo.FetchDeleted(ctx())
+	require.NoError(t, err)
+	assert.Len(t, trash, 0)
+
+	// deleting the todo leaves its activity in place
+	_, err = repos.Activity.GetByID(ctx(), a.ID)
+	assert.NoError(t, err)
+}
+
+func testTodoNotFound(t *testing.T, repos Repositories) {
+	todo := storeTodo(t, repos.Todo, 0, "Beli Sayur", domain.PriorityNormal, at)
+	unknown := todo.ID + 100
+
+	_, err := repos.Todo.GetByID(ctx(), unknown)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repos.Todo.GetByTitle(ctx(), "Beli Buah")
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repos.Todo.GetDeletedByID(ctx(), unknown)
+	assert.Equal(t, domain.ErrNotFound, err)
+
+	missing := todo
+	missing.ID = unknown
+	assert.Error(t, repos.Todo.Update(ctx(), &missing))
+	assert.Error(t, repos.Todo.Delete(ctx(), unknown, at))
+	assert.Error(t, repos.Todo.Restore(ctx(), unknown))
+
+	res, next, prev, err := repos.Todo.Fetch(ctx(), "", 10, domain.TodoFilter{}, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Empty(t, next)
+	assert.Empty(t, prev)
+}
@@ -0,0 +1,141 @@
+package sqlitedb
+
+import (
+	"database/sql"
+	"net/url"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Schema is the equivalent of todolist.sql, the text columns compare regardless of case like the utf8_unicode_ci
+// collation does, for ASCII only, but the position one. The full-text indexes have no equivalent, searching goes
+// through search/repository/index instead.
+const Schema = `
+CREATE TABLE IF NOT EXISTS activity (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  email VARCHAR(45) NOT NULL COLLATE NOCASE,
+  title VARCHAR(45) NOT NULL COLLATE NOCASE,
+  archived BOOLEAN NOT NULL DEFAULT 0,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS activity_created_at_id ON activity (created_at, id);
+CREATE INDEX IF NOT EXISTS activity_deleted_at ON activity (deleted_at);
+
+CREATE TABLE IF NOT EXISTS todo (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  activity_group_id INTEGER DEFAULT NULL REFERENCES activity (id) ON DELETE RESTRICT,
+  title VARCHAR(45) NOT NULL COLLATE NOCASE,
+  notes TEXT NOT NULL DEFAULT '' COLLATE NOCASE,
+  status VARCHAR(16) NOT NULL DEFAULT 'todo' COLLATE NOCASE,
+  priority TINYINT NOT NULL,
+  due_at DATETIME DEFAULT NULL,
+  recurrence VARCHAR(255) NOT NULL DEFAULT '' COLLATE NOCASE,
+  time_zone VARCHAR(64) NOT NULL DEFAULT '' COLLATE NOCASE,
+  completed_at DATETIME DEFAULT NULL,
+  position VARCHAR(64) NOT NULL DEFAULT '' COLLATE BINARY,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS todo_created_at_id ON todo (created_at, id);
+CREATE INDEX IF NOT EXISTS todo_activity_group_id ON todo (activity_group_id);
+CREATE INDEX IF NOT EXISTS todo_deleted_at ON todo (deleted_at);
+CREATE INDEX IF NOT EXISTS todo_status ON todo (status);
+CREATE INDEX IF NOT EXISTS todo_due_at ON todo (due_at);
+CREATE INDEX IF NOT EXISTS todo_activity_group_id_position ON todo (activity_group_id, position);
+
+CREATE TABLE IF NOT EXISTS todo_item (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  todo_id INTEGER NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+  title VARCHAR(255) NOT NULL COLLATE NOCASE,
+  checked BOOLEAN NOT NULL DEFAULT 0,
+  position INTEGER NOT NULL DEFAULT 0,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS todo_item_todo_id_position ON todo_item (todo_id, position);
+
+CREATE TABLE IF NOT EXISTS tag (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(45) NOT NULL COLLATE NOCASE UNIQUE,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS todo_tag (
+  todo_id INTEGER NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+  tag_id INTEGER NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+  PRIMARY KEY (todo_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS todo_tag_tag_id ON todo_tag (tag_id);
+
+CREATE TABLE IF NOT EXISTS template (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  title VARCHAR(45) NOT NULL COLLATE NOCASE,
+  email VARCHAR(45) NOT NULL COLLATE NOCASE,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS template_todo (
+  template_id INTEGER NOT NULL REFERENCES template (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  title VARCHAR(45) NOT NULL COLLATE NOCASE,
+  priority TINYINT NOT NULL,
+  PRIMARY KEY (template_id, position)
+);
+`
+
+// Open will open the database file at the given path and create the tables it lacks. The foreign keys are enforced,
+// the times are read back in UTC and a writer waits for the running one instead of failing right away
+func Open(path string) (*sql.DB, error) {
+	val := url.Values{}
+	val.Add("_loc", "UTC")
+	val.Add("_foreign_keys", "1")
+	val.Add("_busy_timeout", "5000")
+	val.Add("_txlock", "immediate")
+	val.Add("_journal_mode", "WAL")
+	dbConn, err := sql.Open(`sqlite3`, "file:"+path+"?"+val.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = dbConn.Exec(Schema); err != nil {
+		_ = dbConn.Close()
+		return nil, err
+	}
+	return dbConn, nil
+}
+
+// Time will bring the time to what a DATETIME column of MySQL stores, the UTC time rounded to the second.
+// Every time is written this way so that they compare as text in the order of time
+func Time(t time.Time) time.Time {
+	return t.UTC().Round(time.Second)
+}
+
+// TimePtr will bring the time to what a nullable DATETIME column stores, see Time
+func TimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := Time(*t)
+	return &stored
+}
+
+// IsUniqueViolation will tell whether the statement failed on a UNIQUE constraint
+func IsUniqueViolation(err error) bool {
+	return isConstraint(err, sqlite3.ErrConstraintUnique)
+}
+
+// IsForeignKeyViolation will tell whether the statement failed on a FOREIGN KEY constraint
+func IsForeignKeyViolation(err error) bool {
+	return isConstraint(err, sqlite3.ErrConstraintForeignKey)
+}
+
+func isConstraint(err error, code sqlite3.ErrNoExtended) bool {
+	sqliteErr, ok := err.(sqlite3.Error)
+	return ok && sqliteErr.ExtendedCode == code
+}
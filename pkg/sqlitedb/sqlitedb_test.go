@@ -0,0 +1,96 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+func tempPath(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "sqlitedb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	return filepath.Join(dir, "todolist.db")
+}
+
+func TestOpen(t *testing.T) {
+	path := tempPath(t)
+	db, err := sqlitedb.Open(path)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO activity (email, title) VALUES (?, ?)", "Bagus@gmail.com", "Judul")
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	// the tables already there are kept along with their rows
+	db, err = sqlitedb.Open(path)
+	assert.NoError(t, err)
+	defer db.Close()
+	var total int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM activity WHERE title = ?", "JUDUL").Scan(&total))
+	assert.Equal(t, 1, total)
+}
+
+func TestConstraints(t *testing.T) {
+	db, err := sqlitedb.Open(tempPath(t))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("INSERT INTO todo (activity_group_id, title, priority) VALUES (?, ?, ?)", 42, "Judul", 3)
+	assert.True(t, sqlitedb.IsForeignKeyViolation(err))
+	assert.False(t, sqlitedb.IsUniqueViolation(err))
+
+	_, err = db.Exec("INSERT INTO tag (name) VALUES (?), (?)", "urgent", "Urgent")
+	assert.True(t, sqlitedb.IsUniqueViolation(err))
+	assert.False(t, sqlitedb.IsForeignKeyViolation(err))
+
+	assert.False(t, sqlitedb.IsUniqueViolation(nil))
+	assert.False(t, sqlitedb.IsUniqueViolation(errors.New("unexpected")))
+}
+
+func TestTime(t *testing.T) {
+	db, err := sqlitedb.Open(tempPath(t))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 500000000, time.FixedZone("WIB", 7*60*60))
+	_, err = db.Exec("INSERT INTO activity (email, title, updated_at, created_at) VALUES (?, ?, ?, ?)", "Bagus@gmail.com", "Judul", sqlitedb.Time(createdAt), sqlitedb.Time(createdAt))
+	assert.NoError(t, err)
+
+	var stored time.Time
+	assert.NoError(t, db.QueryRow("SELECT created_at FROM activity").Scan(&stored))
+	assert.Equal(t, time.Date(2021, 3, 1, 3, 0, 1, 0, time.UTC), stored)
+
+	assert.Nil(t, sqlitedb.TimePtr(nil))
+	assert.Equal(t, stored, *sqlitedb.TimePtr(&createdAt))
+}
+
+func TestWithinTransaction(t *testing.T) {
+	db, err := sqlitedb.Open(tempPath(t))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	failure := errors.New("unexpected")
+	err = transaction.NewSQLTransactor(db).WithinTransaction(context.TODO(), func(ctx context.Context) error {
+		_, err := transaction.Conn(ctx, db).ExecContext(ctx, "INSERT INTO activity (email, title) VALUES (?, ?)", "Bagus@gmail.com", "Judul")
+		assert.NoError(t, err)
+		return failure
+	})
+	assert.Equal(t, failure, err)
+
+	var total int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM activity").Scan(&total))
+	assert.Equal(t, 0, total)
+}
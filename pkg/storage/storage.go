@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	// register the mysql driver
+	_ "github.com/go-sql-driver/mysql"
+
+	_activityMemoryRepo "github.com/bxcodec/go-clean-arch/activity/repository/memory"
+	_activityRepo "github.com/bxcodec/go-clean-arch/activity/repository/mysql"
+	_activityPostgresRepo "github.com/bxcodec/go-clean-arch/activity/repository/postgres"
+	_activitySqliteRepo "github.com/bxcodec/go-clean-arch/activity/repository/sqlite"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+	"github.com/bxcodec/go-clean-arch/pkg/postgresdb"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+	_searchIndexRepo "github.com/bxcodec/go-clean-arch/search/repository/index"
+	_searchRepo "github.com/bxcodec/go-clean-arch/search/repository/mysql"
+	_tagMemoryRepo "github.com/bxcodec/go-clean-arch/tag/repository/memory"
+	_tagRepo "github.com/bxcodec/go-clean-arch/tag/repository/mysql"
+	_tagPostgresRepo "github.com/bxcodec/go-clean-arch/tag/repository/postgres"
+	_tagSqliteRepo "github.com/bxcodec/go-clean-arch/tag/repository/sqlite"
+	_todoMemoryRepo "github.com/bxcodec/go-clean-arch/todo/repository/memory"
+	_todoRepo "github.com/bxcodec/go-clean-arch/todo/repository/mysql"
+	_todoPostgresRepo "github.com/bxcodec/go-clean-arch/todo/repository/postgres"
+	_todoSqliteRepo "github.com/bxcodec/go-clean-arch/todo/repository/sqlite"
+	_todoItemMemoryRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/memory"
+	_todoItemRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/mysql"
+	_todoItemPostgresRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/postgres"
+	_todoItemSqliteRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/sqlite"
+)
+
+// Config represent the database section of the configuration
+type Config struct {
+	Driver string
+	Host   string
+	Port   string
+	User   string
+	Pass   string
+	Name   string
+	// SSLMode is the sslmode of the postgres driver, disable when none is given
+	SSLMode string
+	// Path is the database file of the sqlite driver
+	Path string
+}
+
+// Repositories holds every repository of a backend, along with the transactor running units of work on it
+type Repositories struct {
+	Activity   domain.ActivityRepository
+	Todo       domain.TodoRepository
+	TodoItem   domain.TodoItemRepository
+	Tag        domain.TagRepository
+	Template   domain.TemplateRepository
+	Transactor domain.Transactor
+	Search     domain.SearchRepository
+
+	close func() error
+}
+
+// Close will release the connection to the database
+func (r *Repositories) Close() error {
+	if r.close == nil {
+		return nil
+	}
+	return r.close()
+}
+
+// Driver will open the repositories of a backend
+type Driver func(cfg Config) (*Repositories, error)
+
+var drivers = map[string]Driver{
+	"mysql":    openMysql,
+	"postgres": openPostgres,
+	"memory":   openMemory,
+	"sqlite":   openSqlite,
+}
+
+// Drivers will list the name of every known driver
+func Drivers() []string {
+	res := make([]string, 0, len(drivers))
+	for name := range drivers {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Open will open the repositories of the configured driver, mysql when none is given
+func Open(cfg Config) (*Repositories, error) {
+	name := cfg.Driver
+	if name == "" {
+		name = "mysql"
+	}
+
+	driver, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown database.driver %q, expected one of %s", cfg.Driver, strings.Join(Drivers(), ", "))
+	}
+	return driver(cfg)
+}
+
+// openMysql expects the tables of todolist.sql, the datetimes are read and written in UTC. A database created by the
+// original schema, holding Asia/Jakarta datetimes and the is_active column, is brought up to date by todolist.upgrade.sql
+func openMysql(cfg Config) (*Repositories, error) {
+	connection := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", cfg.User, cfg.Pass, cfg.Host, cfg.Port, cfg.Name)
+	val := url.Values{}
+	val.Add("parseTime", "1")
+	val.Add("loc", "UTC")
+	val.Add("clientFoundRows", "true")
+	dsn := fmt.Sprintf("%s?%s", connection, val.Encode())
+	dbConn, err := sql.Open(`mysql`, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err = dbConn.Ping(); err != nil {
+		_ = dbConn.Close()
+		return nil, err
+	}
+
+	return &Repositories{
+		Activity:   _activityRepo.NewMysqlActivityRepository(dbConn),
+		Todo:       _todoRepo.NewMysqlTodoRepository(dbConn),
+		TodoItem:   _todoItemRepo.NewMysqlTodoItemRepository(dbConn),
+		Tag:        _tagRepo.NewMysqlTagRepository(dbConn),
+		Template:   _activityRepo.NewMysqlTemplateRepository(dbConn),
+		Transactor: transaction.NewSQLTransactor(dbConn),
+		Search:     _searchRepo.NewMysqlSearchRepository(dbConn),
+		close:      dbConn.Close,
+	}, nil
+}
+
+// openPostgres expects the tables of todolist.postgres.sql. Like with MySQL the times are read back in UTC, and
+// searching goes through the activities and todos as there is no full-text index
+func openPostgres(cfg Config) (*Repositories, error) {
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	val := url.Values{}
+	val.Add("sslmode", sslMode)
+	val.Add("timezone", "UTC")
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Pass),
+		Host:     net.JoinHostPort(cfg.Host, cfg.Port),
+		Path:     "/" + cfg.Name,
+		RawQuery: val.Encode(),
+	}
+	dbConn, err := postgresdb.Open(dsn.String())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Repositories{
+		Activity:   _activityPostgresRepo.NewPostgresActivityRepository(dbConn),
+		Todo:       _todoPostgresRepo.NewPostgresTodoRepository(dbConn),
+		TodoItem:   _todoItemPostgresRepo.NewPostgresTodoItemRepository(dbConn),
+		Tag:        _tagPostgresRepo.NewPostgresTagRepository(dbConn),
+		Template:   _activityPostgresRepo.NewPostgresTemplateRepository(dbConn),
+		Transactor: transaction.NewSQLTransactor(dbConn),
+		close:      dbConn.Close,
+	}
+	res.Search = _searchIndexRepo.NewIndexSearchRepository(res.Activity, res.Todo)
+	return res, nil
+}
+
+// openMemory keeps everything in the process, it is lost once the server stops. Meant to run the API locally
+// without any database
+func openMemory(cfg Config) (*Repositories, error) {
+	db := memdb.New()
+	res := &Repositories{
+		Activity:   _activityMemoryRepo.NewMemoryActivityRepository(db),
+		Todo:       _todoMemoryRepo.NewMemoryTodoRepository(db),
+		TodoItem:   _todoItemMemoryRepo.NewMemoryTodoItemRepository(db),
+		Tag:        _tagMemoryRepo.NewMemoryTagRepository(db),
+		Template:   _activityMemoryRepo.NewMemoryTemplateRepository(db),
+		Transactor: db,
+	}
+	res.Search = _searchIndexRepo.NewIndexSearchRepository(res.Activity, res.Todo)
+	return res, nil
+}
+
+// openSqlite keeps everything in the file at cfg.Path, creating the tables it lacks. SQLite has no full-text index
+// the way MySQL has, searching goes through the activities and todos instead
+func openSqlite(cfg Config) (*Repositories, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("database.path is required by the sqlite driver")
+	}
+
+	dbConn, err := sqlitedb.Open(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Repositories{
+		Activity:   _activitySqliteRepo.NewSqliteActivityRepository(dbConn),
+		Todo:       _todoSqliteRepo.NewSqliteTodoRepository(dbConn),
+		TodoItem:   _todoItemSqliteRepo.NewSqliteTodoItemRepository(dbConn),
+		Tag:        _tagSqliteRepo.NewSqliteTagRepository(dbConn),
+		Template:   _activitySqliteRepo.NewSqliteTemplateRepository(dbConn),
+		Transactor: transaction.NewSQLTransactor(dbConn),
+		close:      dbConn.Close,
+	}
+	res.Search = _searchIndexRepo.NewIndexSearchRepository(res.Activity, res.Todo)
+	return res, nil
+}
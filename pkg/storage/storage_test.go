@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/storage"
+)
+
+func TestDrivers(t *testing.T) {
+	assert.Equal(t, []string{"memory", "mysql", "postgres", "sqlite"}, storage.Drivers())
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, cfg := range []storage.Config{
+		{Driver: "memory"},
+		{Driver: "sqlite", Path: filepath.Join(dir, "todolist.db")},
+	} {
+		t.Run(cfg.Driver, func(t *testing.T) {
+			repos, err := storage.Open(cfg)
+			assert.NoError(t, err)
+			defer func() {
+				assert.NoError(t, repos.Close())
+			}()
+
+			err = repos.Transactor.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+				return repos.Activity.Store(ctx, &domain.Activity{Email: "Bagus@gmail.com", Title: "Judul"})
+			})
+			assert.NoError(t, err)
+
+			a, err := repos.Activity.GetByTitle(context.TODO(), "Judul")
+			assert.NoError(t, err)
+			todo := &domain.Todo{ActivityGroupID: a, Title: "Todo", Priority: domain.PriorityNormal}
+			assert.NoError(t, repos.Todo.Store(context.TODO(), todo))
+
+			results, _, err := repos.Search.Search(context.TODO(), "judul", "", 10)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, results)
+		})
+	}
+}
+
+func TestOpenFailure(t *testing.T) {
+	t.Run("unknown-driver", func(t *testing.T) {
+		_, err := storage.Open(storage.Config{Driver: "oracle"})
+		assert.EqualError(t, err, `unknown database.driver "oracle", expected one of memory, mysql, postgres, sqlite`)
+	})
+
+	t.Run("sqlite-without-path", func(t *testing.T) {
+		_, err := storage.Open(storage.Config{Driver: "sqlite"})
+		assert.Error(t, err)
+	})
+
+	t.Run("mysql-unreachable", func(t *testing.T) {
+		_, err := storage.Open(storage.Config{Host: "127.0.0.1", Port: "1", User: "user", Pass: "123123", Name: "todolist"})
+		assert.Error(t, err)
+	})
+
+	t.Run("postgres-unreachable", func(t *testing.T) {
+		_, err := storage.Open(storage.Config{Driver: "postgres", Host: "127.0.0.1", Port: "1", User: "user", Pass: "123123", Name: "todolist"})
+		assert.Error(t, err)
+	})
+}
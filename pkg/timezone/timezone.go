@@ -0,0 +1,44 @@
+package timezone
+
+import (
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// Caller will get the time zone of the caller from the tz query param or the X-Timezone header, the fallback one is
+// used when the caller tells none and UTC when the fallback is nil
+func Caller(c echo.Context, fallback *time.Location) (*time.Location, error) {
+	name := c.QueryParam("tz")
+	if name == "" {
+		name = c.Request().Header.Get("X-Timezone")
+	}
+	if name == "" {
+		if fallback != nil {
+			return fallback, nil
+		}
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, domain.ErrBadParamInput
+	}
+	return loc, nil
+}
+
+// Recurrence will set the time zone of the caller on the recurring todo about to be created without any
+func Recurrence(c echo.Context, td *domain.Todo, fallback *time.Location) error {
+	if td.Recurrence == "" || td.TimeZone != "" {
+		return nil
+	}
+
+	loc, err := Caller(c, fallback)
+	if err != nil {
+		return err
+	}
+	td.TimeZone = loc.String()
+	return nil
+}
@@ -0,0 +1,67 @@
+package timezone_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/timezone"
+)
+
+func TestCaller(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		target   string
+		header   string
+		fallback *time.Location
+		want     string
+		err      error
+	}{
+		{name: "query-param", target: "/todo?tz=Asia/Tokyo", header: "Europe/Paris", want: "Asia/Tokyo"},
+		{name: "header", target: "/todo", header: "Europe/Paris", want: "Europe/Paris"},
+		{name: "fallback", target: "/todo", fallback: jakarta, want: "Asia/Jakarta"},
+		{name: "utc", target: "/todo", want: "UTC"},
+		{name: "unknown", target: "/todo?tz=Mars/Olympus", err: domain.ErrBadParamInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(echo.GET, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("X-Timezone", tt.header)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			loc, err := timezone.Caller(c, tt.fallback)
+			assert.Equal(t, tt.err, err)
+			if err == nil {
+				assert.Equal(t, tt.want, loc.String())
+			}
+		})
+	}
+}
+
+func TestRecurrence(t *testing.T) {
+	req := httptest.NewRequest(echo.POST, "/todo", nil)
+	req.Header.Set("X-Timezone", "Asia/Tokyo")
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	td := domain.Todo{Recurrence: "FREQ=DAILY"}
+	require.NoError(t, timezone.Recurrence(c, &td, nil))
+	assert.Equal(t, "Asia/Tokyo", td.TimeZone)
+
+	td = domain.Todo{Recurrence: "FREQ=DAILY", TimeZone: "Europe/Paris"}
+	require.NoError(t, timezone.Recurrence(c, &td, nil))
+	assert.Equal(t, "Europe/Paris", td.TimeZone)
+
+	td = domain.Todo{}
+	require.NoError(t, timezone.Recurrence(c, &td, nil))
+	assert.Empty(t, td.TimeZone)
+}
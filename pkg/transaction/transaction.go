@@ -0,0 +1,103 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type txKey struct{}
+
+type savepointKey struct{}
+
+// DBTX represent the methods shared by *sql.DB and *sql.Tx used by the repositories
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Conn will return the transaction carried by the context, or the given connection when there is none
+func Conn(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+type sqlTransactor struct {
+	Conn *sql.DB
+}
+
+// NewSQLTransactor will create an object that represent the domain.Transactor interface
+func NewSQLTransactor(Conn *sql.DB) domain.Transactor {
+	return &sqlTransactor{Conn}
+}
+
+// WithinTransaction will run the function inside a transaction that is committed when the function succeed,
+// a call made while a transaction is already running joins the running one
+func (t *sqlTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				logrus.Error(errRollback)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	return
+}
+
+// WithinSavepoint will run the function inside a savepoint of the running transaction, so a failure of the function
+// only rolls back what it wrote. Without a running transaction it behaves like WithinTransaction
+func (t *sqlTransactor) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return t.WithinTransaction(ctx, fn)
+	}
+
+	depth, _ := ctx.Value(savepointKey{}).(int)
+	depth++
+	name := "sp_" + strconv.Itoa(depth)
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+		if err != nil {
+			if _, errRollback := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); errRollback != nil {
+				logrus.Error(errRollback)
+			}
+			return
+		}
+		_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	}()
+
+	err = fn(context.WithValue(ctx, savepointKey{}, depth))
+	return
+}
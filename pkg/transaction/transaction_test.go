@@ -0,0 +1,95 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+func TestWithinTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	transactor := transaction.NewSQLTransactor(db)
+
+	t.Run("commit", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM todo").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("DELETE FROM activity").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			_, err := transaction.Conn(ctx, db).ExecContext(ctx, "DELETE FROM todo")
+			if err != nil {
+				return err
+			}
+
+			// nested call joins the running transaction
+			return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				_, err := transaction.Conn(ctx, db).ExecContext(ctx, "DELETE FROM activity")
+				return err
+			})
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		errExpected := errors.New("Unexpected Error")
+		err := transactor.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			return errExpected
+		})
+		assert.Equal(t, errExpected, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithinSavepoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	transactor := transaction.NewSQLTransactor(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM todo").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM activity").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	errExpected := errors.New("Unexpected Error")
+	var errSavepoint error
+	err = transactor.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+		err := transactor.WithinSavepoint(ctx, func(ctx context.Context) error {
+			_, err := transaction.Conn(ctx, db).ExecContext(ctx, "DELETE FROM todo")
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		// the failure only rolls back to the savepoint, the transaction is still committed
+		errSavepoint = transactor.WithinSavepoint(ctx, func(ctx context.Context) error {
+			if _, err := transaction.Conn(ctx, db).ExecContext(ctx, "DELETE FROM activity"); err != nil {
+				return err
+			}
+			return errExpected
+		})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, errExpected, errSavepoint)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
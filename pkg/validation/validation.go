@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// Enum is implemented by the types accepting only a closed set of values
+type Enum interface {
+	IsValid() bool
+	Values() []string
+}
+
+// New will create the validator reporting the fields by their json name and knowing the `enum` rule
+func New() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	_ = validate.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		enum, ok := fl.Field().Interface().(Enum)
+		return ok && enum.IsValid()
+	})
+	return validate
+}
+
+// Struct will validate the given struct and return the message of every invalid field, nil when it is valid
+func Struct(s interface{}) (map[string]string, error) {
+	err := New().Struct(s)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil, err
+	}
+
+	fields := make(map[string]string, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fields[fieldPath(fieldErr)] = message(fieldErr)
+	}
+	return fields, nil
+}
+
+// fieldPath will drop the struct name from the namespace, e.g. `Todo.activity_group_id.email`
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func message(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "enum":
+		if enum, ok := fieldErr.Value().(Enum); ok {
+			return "must be one of " + strings.Join(enum.Values(), ", ")
+		}
+	case "max":
+		switch fieldErr.Kind() {
+		case reflect.String:
+			return "must be at most " + fieldErr.Param() + " characters long"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			return "must hold at most " + fieldErr.Param() + " items"
+		}
+		return "must be at most " + fieldErr.Param()
+	}
+	return "failed on the '" + fieldErr.Tag() + "' rule"
+}
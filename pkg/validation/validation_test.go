@@ -0,0 +1,49 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/pkg/validation"
+)
+
+type color string
+
+func (c color) IsValid() bool {
+	return c == "red" || c == "blue"
+}
+
+func (c color) Values() []string {
+	return []string{"red", "blue"}
+}
+
+type owner struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type paint struct {
+	Color color  `json:"color" validate:"required,enum"`
+	Shade color  `json:"shade" validate:"omitempty,enum"`
+	Owner owner  `json:"owner"`
+	Note  string `json:"-" validate:"required"`
+	Label string `json:"label" validate:"max=5"`
+	Tags  []int  `json:"tags" validate:"max=2"`
+}
+
+func TestStruct(t *testing.T) {
+	fields, err := validation.Struct(&paint{Color: "red", Owner: owner{Name: "bagus"}, Note: "x"})
+	assert.NoError(t, err)
+	assert.Nil(t, fields)
+
+	fields, err = validation.Struct(&paint{Color: "green", Shade: "pink", Label: "héllo!", Tags: []int{1, 2, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"color":      "must be one of red, blue",
+		"shade":      "must be one of red, blue",
+		"owner.name": "is required",
+		"Note":       "is required",
+		"label":      "must be at most 5 characters long",
+		"tags":       "must hold at most 2 items",
+	}, fields)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// ResponseError represent the reseponse error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// SearchHandler  represent the httphandler for the search
+type SearchHandler struct {
+	SUsecase domain.SearchUsecase
+}
+
+// NewSearchHandler will initialize the search/ resources endpoint
+func NewSearchHandler(e *echo.Echo, us domain.SearchUsecase) {
+	handler := &SearchHandler{
+		SUsecase: us,
+	}
+	e.GET("/search", handler.Search)
+}
+
+// Search will rank the activities and todos matching the q param, the next page is given by the X-Cursor header
+func (a *SearchHandler) Search(c echo.Context) error {
+	numS := c.QueryParam("num")
+	num, _ := strconv.Atoi(numS)
+	cursor := c.QueryParam("cursor")
+	ctx := c.Request().Context()
+
+	res, nextCursor, err := a.SUsecase.Search(ctx, c.QueryParam("q"), cursor, int64(num))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	return c.JSON(http.StatusOK, res)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	logrus.Error(err)
+	switch err {
+	case domain.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case domain.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
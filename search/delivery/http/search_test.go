@@ -0,0 +1,72 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	searchHTTP "github.com/bxcodec/go-clean-arch/search/delivery/http"
+)
+
+func TestSearch(t *testing.T) {
+	mockResults := []domain.SearchResult{{
+		Kind:       domain.SearchKindTodo,
+		ID:         7,
+		Title:      "Buy milk",
+		Score:      1.5,
+		Highlights: map[string]string{"title": "Buy <mark>milk</mark>"},
+	}}
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(mocks.SearchUsecaseMock)
+		mockUCase.On("Search", mock.Anything, "buy milk", "abc", int64(5)).Return(mockResults, "next", nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/search?q=buy+milk&cursor=abc&num=5", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := searchHTTP.SearchHandler{
+			SUsecase: mockUCase,
+		}
+		err = handler.Search(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "next", rec.Header().Get("X-Cursor"))
+		var res []domain.SearchResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, mockResults, res)
+		mockUCase.AssertExpectations(t)
+	})
+
+	t.Run("bad-query", func(t *testing.T) {
+		mockUCase := new(mocks.SearchUsecaseMock)
+		mockUCase.On("Search", mock.Anything, "", "", int64(0)).Return(nil, "", domain.ErrBadParamInput).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/search", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := searchHTTP.SearchHandler{
+			SUsecase: mockUCase,
+		}
+		err = handler.Search(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/fulltext"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+)
+
+const (
+	cursorVersion = 1
+
+	// excerptSize is about how many characters of the notes are given around the match
+	excerptSize = 160
+)
+
+// ErrInvalidCursor will throw if the given cursor is malformed, has been tampered or belongs to another query
+var ErrInvalidCursor = pagination.ErrInvalidCursor
+
+// Cursor represent the last result of a page, the next page starts right after it in the ranking
+type Cursor struct {
+	Version int               `json:"v"`
+	Score   string            `json:"s"`
+	Kind    domain.SearchKind `json:"k"`
+	ID      int64             `json:"i"`
+	Query   string            `json:"q"`
+}
+
+// NormalizeQuery will give the canonical representation of the query to be carried by the cursor
+func NormalizeQuery(query string) string {
+	return strings.Join(fulltext.Terms(query), " ")
+}
+
+// NewCursor will build the cursor pointing after the result
+func NewCursor(res domain.SearchResult, query string) Cursor {
+	return Cursor{
+		Score: fulltext.FormatScore(res.Score),
+		Kind:  res.Kind,
+		ID:    res.ID,
+		Query: NormalizeQuery(query),
+	}
+}
+
+// After will tell whether the result ranks after the cursor
+func (c Cursor) After(res domain.SearchResult) bool {
+	score, _ := strconv.ParseFloat(c.Score, 64)
+	if res.Score != score {
+		return res.Score < score
+	}
+	if res.Kind != c.Kind {
+		return res.Kind > c.Kind
+	}
+	return res.ID > c.ID
+}
+
+// Highlights will give the highlighted title, along with an excerpt of the notes when they hold a term
+func Highlights(title string, notes string, terms []string) map[string]string {
+	highlights := map[string]string{"title": fulltext.Highlight(title, terms)}
+	if excerpt := fulltext.Excerpt(notes, terms, excerptSize); excerpt != "" {
+		highlights["notes"] = excerpt
+	}
+	return highlights
+}
+
+// Sort will rank the results the best match first, the ties broken by kind then id
+func Sort(res []domain.SearchResult) {
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		if res[i].Kind != res[j].Kind {
+			return res[i].Kind < res[j].Kind
+		}
+		return res[i].ID < res[j].ID
+	})
+}
+
+// DecodeCursor will decode the cursor from user, it has to be produced by the same query
+func DecodeCursor(encodedCursor string, query string) (Cursor, error) {
+	var c Cursor
+	if err := pagination.Open(encodedCursor, &c); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if c.Version != cursorVersion || c.Query != NormalizeQuery(query) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if _, err := strconv.ParseFloat(c.Score, 64); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// EncodeCursor will encode the cursor to user
+func EncodeCursor(c Cursor) string {
+	c.Version = cursorVersion
+	return pagination.Seal(c)
+}
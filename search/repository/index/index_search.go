@@ -0,0 +1,122 @@
+package index
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/fulltext"
+	"github.com/bxcodec/go-clean-arch/search/repository"
+)
+
+// pageSize is how many rows are read at once from the repositories to build the index
+const pageSize = 100
+
+type indexSearchRepository struct {
+	activity domain.ActivityRepository
+	todo     domain.TodoRepository
+}
+
+// NewIndexSearchRepository will create an object that represent the domain.SearchRepository interface for the
+// backends without full-text search, every search indexes the activities and the todos read from the repositories
+func NewIndexSearchRepository(ar domain.ActivityRepository, td domain.TodoRepository) domain.SearchRepository {
+	return &indexSearchRepository{
+		activity: ar,
+		todo:     td,
+	}
+}
+
+func key(kind domain.SearchKind, id int64) string {
+	return string(kind) + ":" + strconv.FormatInt(id, 10)
+}
+
+// document represent an indexed activity or todo, an activity has no notes
+type document struct {
+	result domain.SearchResult
+	notes  string
+}
+
+// documents will index every activity, archived ones included, and every todo
+func (m *indexSearchRepository) documents(ctx context.Context) (*fulltext.Index, map[string]document, error) {
+	ix := fulltext.New()
+	docs := map[string]document{}
+
+	cursor := ""
+	for {
+		list, next, _, err := m.activity.Fetch(ctx, cursor, pageSize, domain.ActivityFilter{IncludeArchived: true})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, a := range list {
+			k := key(domain.SearchKindActivity, a.ID)
+			ix.Add(k, a.Title)
+			docs[k] = document{result: domain.SearchResult{Kind: domain.SearchKindActivity, ID: a.ID, Title: a.Title}}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	cursor = ""
+	for {
+		list, next, _, err := m.todo.Fetch(ctx, cursor, pageSize, domain.TodoFilter{}, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range list {
+			k := key(domain.SearchKindTodo, t.ID)
+			ix.Add(k, t.Title, t.Notes)
+			docs[k] = document{result: domain.SearchResult{Kind: domain.SearchKindTodo, ID: t.ID, Title: t.Title}, notes: t.Notes}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	return ix, docs, nil
+}
+
+func (m *indexSearchRepository) Search(ctx context.Context, query string, cursor string, num int64) (res []domain.SearchResult, nextCursor string, err error) {
+	var after *repository.Cursor
+	if cursor != "" {
+		c, err := repository.DecodeCursor(cursor, query)
+		if err != nil {
+			return nil, "", domain.ErrBadParamInput
+		}
+		after = &c
+	}
+
+	ix, docs, err := m.documents(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the index breaks the ties by key, the results are ranked by kind and numeric id like the other backends
+	matches := ix.Search(query)
+	ranked := make([]domain.SearchResult, 0, len(matches))
+	notes := make(map[string]string, len(matches))
+	for _, match := range matches {
+		doc := docs[match.Key]
+		doc.result.Score = match.Score
+		ranked = append(ranked, doc.result)
+		notes[match.Key] = doc.notes
+	}
+	repository.Sort(ranked)
+
+	terms := fulltext.Terms(query)
+	res = make([]domain.SearchResult, 0)
+	for _, r := range ranked {
+		if after != nil && !after.After(r) {
+			continue
+		}
+		if len(res) == int(num) {
+			nextCursor = repository.EncodeCursor(repository.NewCursor(res[len(res)-1], query))
+			break
+		}
+		r.Highlights = repository.Highlights(r.Title, notes[key(r.Kind, r.ID)], terms)
+		res = append(res, r)
+	}
+	return
+}
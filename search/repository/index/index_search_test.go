@@ -0,0 +1,70 @@
+package index_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	"github.com/bxcodec/go-clean-arch/search/repository/index"
+)
+
+func TestSearch(t *testing.T) {
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo.On("Fetch", mock.Anything, "", int64(100), domain.ActivityFilter{IncludeArchived: true}).
+		Return([]domain.Activity{{ID: 2, Title: "Milk run"}}, "", "", nil)
+	mockTodoRepo.On("Fetch", mock.Anything, "", int64(100), domain.TodoFilter{}, domain.TodoSort(nil)).
+		Return([]domain.Todo{{ID: 7, Title: "Buy milk"}, {ID: 8, Title: "Call mom", Notes: "About the *milk* delivery"}}, "next", "", nil)
+	mockTodoRepo.On("Fetch", mock.Anything, "next", int64(100), domain.TodoFilter{}, domain.TodoSort(nil)).
+		Return([]domain.Todo{{ID: 9, Title: "Milk, more milk"}}, "", "", nil)
+
+	a := index.NewIndexSearchRepository(mockActivityRepo, mockTodoRepo)
+
+	list, nextCursor, err := a.Search(context.TODO(), "MILK", "", int64(2))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nextCursor)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, int64(9), list[0].ID)
+		assert.Equal(t, "<mark>Milk</mark>, more <mark>milk</mark>", list[0].Highlights["title"])
+		// the ties are broken by kind then id
+		assert.Equal(t, domain.SearchKindActivity, list[1].Kind)
+		assert.InDelta(t, 2*list[1].Score, list[0].Score, 0.00001)
+	}
+
+	list, nextCursor, err = a.Search(context.TODO(), "milk", nextCursor, int64(2))
+	assert.NoError(t, err)
+	assert.Empty(t, nextCursor)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, domain.SearchResult{
+			Kind:       domain.SearchKindTodo,
+			ID:         7,
+			Title:      "Buy milk",
+			Score:      list[0].Score,
+			Highlights: map[string]string{"title": "Buy <mark>milk</mark>"},
+		}, list[0])
+		// the notes are searched too
+		assert.Equal(t, map[string]string{"title": "Call mom", "notes": "About the *<mark>milk</mark>* delivery"}, list[1].Highlights)
+	}
+
+	_, _, err = a.Search(context.TODO(), "mom", "garbage", int64(2))
+	assert.Equal(t, domain.ErrBadParamInput, err)
+
+	mockActivityRepo.AssertExpectations(t)
+	mockTodoRepo.AssertExpectations(t)
+}
+
+func TestSearchError(t *testing.T) {
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockActivityRepo.On("Fetch", mock.Anything, "", int64(100), mock.Anything).Return(nil, "", "", errors.New("Unexpected Error"))
+
+	a := index.NewIndexSearchRepository(mockActivityRepo, new(mocks.TodoRepositoryMock))
+
+	list, _, err := a.Search(context.TODO(), "milk", "", int64(2))
+	assert.Error(t, err)
+	assert.Nil(t, list)
+}
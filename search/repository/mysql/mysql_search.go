@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/fulltext"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+	"github.com/bxcodec/go-clean-arch/search/repository"
+)
+
+type mysqlSearchRepository struct {
+	Conn *sql.DB
+}
+
+// NewMysqlSearchRepository will create an object that represent the domain.SearchRepository interface,
+// it relies on the FULLTEXT indexes built with the ngram parser so a term matches inside the words as well
+func NewMysqlSearchRepository(Conn *sql.DB) domain.SearchRepository {
+	return &mysqlSearchRepository{Conn}
+}
+
+// searchQuery ranks the activities and the todos together, the score is rounded so the cursor compares it exactly
+const searchQuery = `SELECT kind, id, title, notes, score FROM (
+		SELECT 'activity' AS kind, id, title, '' AS notes, ROUND(MATCH(title) AGAINST (? IN NATURAL LANGUAGE MODE), 6) AS score
+			FROM activity WHERE deleted_at IS NULL AND MATCH(title) AGAINST (? IN NATURAL LANGUAGE MODE)
+		UNION ALL
+		SELECT 'todo' AS kind, id, title, notes, ROUND(MATCH(title, notes) AGAINST (? IN NATURAL LANGUAGE MODE), 6) AS score
+			FROM todo WHERE deleted_at IS NULL AND MATCH(title, notes) AGAINST (? IN NATURAL LANGUAGE MODE)
+	) AS found`
+
+func (m *mysqlSearchRepository) Search(ctx context.Context, query string, cursor string, num int64) (res []domain.SearchResult, nextCursor string, err error) {
+	where := ""
+	args := []interface{}{query, query, query, query}
+	if cursor != "" {
+		c, err := repository.DecodeCursor(cursor, query)
+		if err != nil {
+			return nil, "", domain.ErrBadParamInput
+		}
+		where = " WHERE score < ? OR (score = ? AND (kind > ? OR (kind = ? AND id > ?)))"
+		args = append(args, c.Score, c.Score, c.Kind, c.Kind, c.ID)
+	}
+
+	// fetch one more row than requested to know whether there is a next page
+	args = append(args, num+1)
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, searchQuery+where+` ORDER BY score DESC, kind ASC, id ASC LIMIT ?`, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, "", err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	terms := fulltext.Terms(query)
+	res = make([]domain.SearchResult, 0)
+	for rows.Next() {
+		r := domain.SearchResult{}
+		var notes string
+		err = rows.Scan(
+			&r.Kind,
+			&r.ID,
+			&r.Title,
+			&notes,
+			&r.Score,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, "", err
+		}
+		r.Highlights = repository.Highlights(r.Title, notes, terms)
+		res = append(res, r)
+	}
+
+	if len(res) > int(num) {
+		res = res[:num]
+		nextCursor = repository.EncodeCursor(repository.NewCursor(res[len(res)-1], query))
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/search/repository"
+	searchMysqlRepo "github.com/bxcodec/go-clean-arch/search/repository/mysql"
+)
+
+func TestSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	a := searchMysqlRepo.NewMysqlSearchRepository(db)
+
+	query := "SELECT kind, id, title, notes, score FROM \\(.*MATCH\\(title\\) AGAINST \\(\\? IN NATURAL LANGUAGE MODE\\).*\\) AS found"
+	order := " ORDER BY score DESC, kind ASC, id ASC LIMIT \\?"
+
+	t.Run("first-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"kind", "id", "title", "notes", "score"}).
+			AddRow("todo", 7, "Buy milk", "Oat milk, not cow", 1.5).
+			AddRow("activity", 2, "Milk run", "", 0.75)
+
+		mock.ExpectQuery(query+order).WithArgs("milk", "milk", "milk", "milk", int64(2)).WillReturnRows(rows)
+		list, nextCursor, err := a.Search(context.TODO(), "milk", "", int64(1))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.SearchResult{{
+			Kind:       domain.SearchKindTodo,
+			ID:         7,
+			Title:      "Buy milk",
+			Score:      1.5,
+			Highlights: map[string]string{"title": "Buy <mark>milk</mark>", "notes": "Oat <mark>milk</mark>, not cow"},
+		}}, list)
+
+		decoded, err := repository.DecodeCursor(nextCursor, "Milk")
+		assert.NoError(t, err)
+		assert.Equal(t, repository.Cursor{Version: 1, Score: "1.500000", Kind: domain.SearchKindTodo, ID: 7, Query: "milk"}, decoded)
+	})
+
+	t.Run("next-page", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"kind", "id", "title", "notes", "score"}).
+			AddRow("activity", 2, "Milk run", "", 0.75)
+
+		cursor := repository.EncodeCursor(repository.Cursor{Score: "1.500000", Kind: domain.SearchKindTodo, ID: 7, Query: "milk"})
+		mock.ExpectQuery(query+" WHERE score < \\? OR \\(score = \\? AND \\(kind > \\? OR \\(kind = \\? AND id > \\?\\)\\)\\)"+order).
+			WithArgs("milk", "milk", "milk", "milk", "1.500000", "1.500000", domain.SearchKindTodo, domain.SearchKindTodo, int64(7), int64(2)).
+			WillReturnRows(rows)
+		list, nextCursor, err := a.Search(context.TODO(), "milk", cursor, int64(1))
+		assert.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("cursor-of-another-query", func(t *testing.T) {
+		cursor := repository.EncodeCursor(repository.Cursor{Score: "1.500000", Kind: domain.SearchKindTodo, ID: 7, Query: "milk"})
+		_, _, err := a.Search(context.TODO(), "bread", cursor, int64(1))
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,42 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	ucase "github.com/bxcodec/go-clean-arch/search/usecase"
+)
+
+func TestSearch(t *testing.T) {
+	mockResults := []domain.SearchResult{{Kind: domain.SearchKindTodo, ID: 7, Title: "Buy milk", Score: 1.5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockSearchRepo := new(mocks.SearchRepositoryMock)
+		mockSearchRepo.On("Search", mock.Anything, "milk", "cursor", int64(10)).Return(mockResults, "next", nil).Once()
+
+		u := ucase.NewSearchUsecase(mockSearchRepo, time.Second*2)
+
+		list, nextCursor, err := u.Search(context.TODO(), "milk", "cursor", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockResults, list)
+		assert.Equal(t, "next", nextCursor)
+		mockSearchRepo.AssertExpectations(t)
+	})
+	t.Run("query-without-words", func(t *testing.T) {
+		mockSearchRepo := new(mocks.SearchRepositoryMock)
+
+		u := ucase.NewSearchUsecase(mockSearchRepo, time.Second*2)
+
+		_, _, err := u.Search(context.TODO(), " ?! ", "", 5)
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockSearchRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/fulltext"
+)
+
+type searchUsecase struct {
+	search         domain.SearchRepository
+	contextTimeout time.Duration
+}
+
+// NewSearchUsecase will create new an searchUsecase object representation of domain.SearchUsecase interface
+func NewSearchUsecase(sr domain.SearchRepository, timeout time.Duration) domain.SearchUsecase {
+	return &searchUsecase{
+		search:         sr,
+		contextTimeout: timeout,
+	}
+}
+
+// Search will rank the activities and todos matching the query, a query without any word is rejected
+func (a *searchUsecase) Search(c context.Context, query string, cursor string, num int64) (res []domain.SearchResult, nextCursor string, err error) {
+	if len(fulltext.Terms(query)) == 0 {
+		return nil, "", domain.ErrBadParamInput
+	}
+	if num <= 0 {
+		num = 10
+	}
+
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.search.Search(ctx, query, cursor, num)
+}
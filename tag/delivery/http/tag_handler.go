@@ -0,0 +1,218 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/mergepatch"
+	"github.com/bxcodec/go-clean-arch/pkg/validation"
+)
+
+// ResponseError represent the reseponse error struct
+type ResponseError struct {
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// mergeRequest is the body of the merge of a tag into another one
+type mergeRequest struct {
+	Into int64 `json:"into" validate:"required"`
+}
+
+// TagHandler  represent the httphandler for tag
+type TagHandler struct {
+	TUsecase domain.TagUsecase
+}
+
+// NewTagHandler will initialize the tag/ resources endpoint
+func NewTagHandler(e *echo.Echo, us domain.TagUsecase) {
+	handler := &TagHandler{
+		TUsecase: us,
+	}
+	e.GET("/tag", handler.FetchTag)
+	e.POST("/tag", handler.Store)
+	e.GET("/tag/:id", handler.GetByID)
+	e.PATCH("/tag/:id", handler.Patch)
+	e.DELETE("/tag/:id", handler.Delete)
+	e.POST("/tag/:id/merge", handler.Merge)
+}
+
+// FetchTag will fetch every tag ordered by name
+func (a *TagHandler) FetchTag(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	listTag, err := a.TUsecase.Fetch(ctx)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, listTag)
+}
+
+// GetByID will get tag by given id
+func (a *TagHandler) GetByID(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	tag, err := a.TUsecase.GetByID(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+func isRequestValid(m interface{}) (bool, ResponseError) {
+	fields, err := validation.Struct(m)
+	if err != nil {
+		return false, ResponseError{Message: err.Error()}
+	}
+	if len(fields) > 0 {
+		return false, ResponseError{Message: domain.ErrBadParamInput.Error(), Errors: fields}
+	}
+	return true, ResponseError{}
+}
+
+// Store will store the tag by given request body
+func (a *TagHandler) Store(c echo.Context) (err error) {
+	var tag domain.Tag
+	err = c.Bind(&tag)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&tag); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	err = a.TUsecase.Store(ctx, &tag)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, tag)
+}
+
+// Patch will rename the tag by given param and JSON merge-patch body
+func (a *TagHandler) Patch(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	if !mergepatch.IsMediaType(c.Request().Header.Get(echo.HeaderContentType)) {
+		return c.JSON(http.StatusUnsupportedMediaType, ResponseError{Message: mergepatch.ErrMediaType.Error()})
+	}
+	patch, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	id := int64(idP)
+	ctx := c.Request().Context()
+
+	existed, err := a.TUsecase.GetByID(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	original, err := json.Marshal(existed)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseError{Message: err.Error()})
+	}
+
+	patched, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var tag domain.Tag
+	err = json.Unmarshal(patched, &tag)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	tag.ID = id
+
+	if ok, resErr := isRequestValid(&tag); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	err = a.TUsecase.Update(ctx, &tag)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// Merge will merge the tag given by param into the one given by the request body
+func (a *TagHandler) Merge(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req mergeRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	tag, err := a.TUsecase.Merge(ctx, int64(idP), req.Into)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// Delete will delete tag by given param, the todos lose the tag
+func (a *TagHandler) Delete(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	err = a.TUsecase.Delete(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	logrus.Error(err)
+	switch err {
+	case domain.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case domain.ErrNotFound:
+		return http.StatusNotFound
+	case domain.ErrConflict:
+		return http.StatusConflict
+	case domain.ErrBadParamInput:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,220 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	tagHTTP "github.com/bxcodec/go-clean-arch/tag/delivery/http"
+)
+
+func TestFetch(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("Fetch", mock.Anything).Return([]domain.Tag{{ID: 1, Name: "urgent"}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/tag", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.FetchTag(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStore(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(tag *domain.Tag) bool {
+		return tag.Name == "urgent"
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/tag", strings.NewReader(`{"name":"urgent"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreConflict(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.AnythingOfType("*domain.Tag")).Return(domain.ErrConflict)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/tag", strings.NewReader(`{"name":"urgent"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestPatch(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, int64(1)).Return(domain.Tag{ID: 1, Name: "urgent"}, nil)
+	mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(tag *domain.Tag) bool {
+		return tag.ID == 1 && tag.Name == "asap"
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/tag/1", strings.NewReader(`{"name":"asap"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("tag/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestPatchTooLong(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, int64(1)).Return(domain.Tag{ID: 1, Name: "urgent"}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/tag/1", strings.NewReader(`{"name":"`+strings.Repeat("a", 46)+`"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("tag/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPatchJSON(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/tag/1", strings.NewReader(`{"name":"asap"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("tag/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	mockUCase.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestMerge(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("Merge", mock.Anything, int64(5), int64(3)).Return(domain.Tag{ID: 3, Name: "urgent"}, nil)
+
+	e := echo.New()
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+
+	req, err := http.NewRequest(echo.POST, "/tag/5/merge", strings.NewReader(`{"into":3}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("tag/:id/merge")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	err = handler.Merge(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"urgent"`)
+	mockUCase.AssertExpectations(t)
+
+	// the tag to merge into is required
+	req, err = http.NewRequest(echo.POST, "/tag/5/merge", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetPath("tag/:id/merge")
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	err = handler.Merge(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"into":"is required"`)
+}
+
+func TestDelete(t *testing.T) {
+	mockUCase := new(mocks.TagUsecaseMock)
+	mockUCase.On("Delete", mock.Anything, int64(1)).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/tag/1", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("tag/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	handler := tagHTTP.TagHandler{
+		TUsecase: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
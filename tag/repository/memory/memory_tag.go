@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+)
+
+type memoryTagRepository struct {
+	DB *memdb.DB
+}
+
+// NewMemoryTagRepository will create an object that represent the domain.TagRepository interface,
+// the tags are kept in the given in-memory database
+func NewMemoryTagRepository(db *memdb.DB) domain.TagRepository {
+	return &memoryTagRepository{db}
+}
+
+// getOne will give the tag matching, the names are unique so a single one may match
+func (m *memoryTagRepository) getOne(ctx context.Context, match func(tag domain.Tag) bool) (res domain.Tag, err error) {
+	found := false
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, tag := range t.Tags {
+			if match(tag) {
+				res, found = tag, true
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	if !found {
+		return domain.Tag{}, domain.ErrNotFound
+	}
+	return
+}
+
+// taken will tell whether another tag already has the name, the names are compared regardless of case
+// like the unique key of the name column does
+func taken(t *memdb.Tables, name string, id int64) bool {
+	for _, tag := range t.Tags {
+		if tag.ID != id && strings.EqualFold(tag.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memoryTagRepository) Fetch(ctx context.Context) (res []domain.Tag, err error) {
+	res = make([]domain.Tag, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, tag := range t.Tags {
+			res = append(res, tag)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return
+}
+
+func (m *memoryTagRepository) GetByID(ctx context.Context, id int64) (res domain.Tag, err error) {
+	return m.getOne(ctx, func(tag domain.Tag) bool {
+		return tag.ID == id
+	})
+}
+
+func (m *memoryTagRepository) GetByName(ctx context.Context, name string) (res domain.Tag, err error) {
+	return m.getOne(ctx, func(tag domain.Tag) bool {
+		return strings.EqualFold(tag.Name, name)
+	})
+}
+
+// Store will refuse a name already taken with domain.ErrConflict
+func (m *memoryTagRepository) Store(ctx context.Context, a *domain.Tag) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		if taken(t, a.Name, 0) {
+			return domain.ErrConflict
+		}
+
+		row := *a
+		row.ID = t.NextID("tag")
+		row.UpdatedAt = memdb.Time(a.UpdatedAt)
+		row.CreatedAt = memdb.Time(a.CreatedAt)
+		t.Tags[row.ID] = row
+		a.ID = row.ID
+		return nil
+	})
+}
+
+// Update will refuse a name already taken by another tag with domain.ErrConflict
+func (m *memoryTagRepository) Update(ctx context.Context, ar *domain.Tag) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		tag, ok := t.Tags[ar.ID]
+		if !ok {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+		if taken(t, ar.Name, ar.ID) {
+			return domain.ErrConflict
+		}
+
+		tag.Name = ar.Name
+		tag.UpdatedAt = memdb.Time(ar.UpdatedAt)
+		t.Tags[ar.ID] = tag
+		return nil
+	})
+}
+
+// Delete will delete the tag, its links to the todos go along like the foreign key does
+func (m *memoryTagRepository) Delete(ctx context.Context, id int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		if _, ok := t.Tags[id]; !ok {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		delete(t.Tags, id)
+		for todoID, tags := range t.TodoTags {
+			delete(tags, id)
+			if len(tags) == 0 {
+				delete(t.TodoTags, todoID)
+			}
+		}
+		return nil
+	})
+}
+
+// MoveTodos will put the todos tagged with the first tag under the second one, a todo already carrying both keeps a single link
+func (m *memoryTagRepository) MoveTodos(ctx context.Context, from int64, into int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, tags := range t.TodoTags {
+			if tags[from] {
+				delete(tags, from)
+				tags[into] = true
+			}
+		}
+		return nil
+	})
+}
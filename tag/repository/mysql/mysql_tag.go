@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type mysqlTagRepository struct {
+	Conn *sql.DB
+}
+
+// NewMysqlTagRepository will create an object that represent the domain.TagRepository interface
+func NewMysqlTagRepository(Conn *sql.DB) domain.TagRepository {
+	return &mysqlTagRepository{Conn}
+}
+
+func (m *mysqlTagRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Tag, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Tag, 0)
+	for rows.Next() {
+		t := domain.Tag{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *mysqlTagRepository) getOne(ctx context.Context, query string, args ...interface{}) (res domain.Tag, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *mysqlTagRepository) Fetch(ctx context.Context) (res []domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag ORDER BY name ASC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *mysqlTagRepository) GetByID(ctx context.Context, id int64) (res domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag WHERE ID = ?`
+
+	return m.getOne(ctx, query, id)
+}
+
+func (m *mysqlTagRepository) GetByName(ctx context.Context, name string) (res domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag WHERE name = ?`
+
+	return m.getOne(ctx, query, name)
+}
+
+func (m *mysqlTagRepository) Store(ctx context.Context, a *domain.Tag) (err error) {
+	query := `INSERT tag SET name=?, updated_at=?, created_at=?`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, a.Name, a.UpdatedAt, a.CreatedAt)
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return
+}
+
+func (m *mysqlTagRepository) Update(ctx context.Context, ar *domain.Tag) (err error) {
+	query := `UPDATE tag set name=?, updated_at=? WHERE ID = ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Name, ar.UpdatedAt, ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *mysqlTagRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := "DELETE FROM tag WHERE id = ?"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+// MoveTodos will put the todos tagged with the first tag under the second one, a todo already carrying both keeps a single link
+func (m *mysqlTagRepository) MoveTodos(ctx context.Context, from int64, into int64) (err error) {
+	conn := transaction.Conn(ctx, m.Conn)
+	_, err = conn.ExecContext(ctx, `INSERT INTO todo_tag (todo_id, tag_id) SELECT src.todo_id, ? FROM todo_tag src
+  						WHERE src.tag_id = ? AND NOT EXISTS (SELECT 1 FROM todo_tag dst WHERE dst.todo_id = src.todo_id AND dst.tag_id = ?)`, into, from, into)
+	if err != nil {
+		return
+	}
+
+	_, err = conn.ExecContext(ctx, "DELETE FROM todo_tag WHERE tag_id = ?", from)
+	return
+}
@@ -0,0 +1,127 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	tagMysqlRepo "github.com/bxcodec/go-clean-arch/tag/repository/mysql"
+)
+
+var columns = []string{"id", "name", "updated_at", "created_at"}
+
+func TestFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows(columns).
+		AddRow(2, "backend", now, now).
+		AddRow(1, "urgent", now, now)
+
+	mock.ExpectQuery("SELECT id, name, updated_at, created_at FROM tag ORDER BY name ASC").WillReturnRows(rows)
+
+	a := tagMysqlRepo.NewMysqlTagRepository(db)
+	list, err := a.Fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "backend", list[0].Name)
+}
+
+func TestGetByName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, name, updated_at, created_at FROM tag WHERE name = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(query).WithArgs("urgent").WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "urgent", now, now))
+
+		a := tagMysqlRepo.NewMysqlTagRepository(db)
+		tag, err := a.GetByName(context.TODO(), "urgent")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), tag.ID)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("later").WillReturnRows(sqlmock.NewRows(columns))
+
+		a := tagMysqlRepo.NewMysqlTagRepository(db)
+		_, err := a.GetByName(context.TODO(), "later")
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	tag := &domain.Tag{Name: "urgent", CreatedAt: now, UpdatedAt: now}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("INSERT tag SET name=\\?, updated_at=\\?, created_at=\\?")
+	prep.ExpectExec().WithArgs(tag.Name, tag.UpdatedAt, tag.CreatedAt).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := tagMysqlRepo.NewMysqlTagRepository(db)
+	err = a.Store(context.TODO(), tag)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), tag.ID)
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Now()
+	tag := &domain.Tag{ID: 12, Name: "urgent", CreatedAt: now, UpdatedAt: now}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("UPDATE tag set name=\\?, updated_at=\\? WHERE ID = \\?")
+	prep.ExpectExec().WithArgs(tag.Name, tag.UpdatedAt, tag.ID).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := tagMysqlRepo.NewMysqlTagRepository(db)
+	err = a.Update(context.TODO(), tag)
+	assert.NoError(t, err)
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("DELETE FROM tag WHERE id = \\?")
+	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := tagMysqlRepo.NewMysqlTagRepository(db)
+	err = a.Delete(context.TODO(), 12)
+	assert.NoError(t, err)
+}
+
+func TestMoveTodos(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	mock.ExpectExec("INSERT INTO todo_tag \\(todo_id, tag_id\\) SELECT src.todo_id, \\? FROM todo_tag src WHERE src.tag_id = \\? "+
+		"AND NOT EXISTS \\(SELECT 1 FROM todo_tag dst WHERE dst.todo_id = src.todo_id AND dst.tag_id = \\?\\)").
+		WithArgs(3, 5, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM todo_tag WHERE tag_id = \\?").WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := tagMysqlRepo.NewMysqlTagRepository(db)
+	err = a.MoveTodos(context.TODO(), 5, 3)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/postgresdb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type postgresTagRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresTagRepository will create an object that represent the domain.TagRepository interface
+func NewPostgresTagRepository(Conn *sql.DB) domain.TagRepository {
+	return &postgresTagRepository{Conn}
+}
+
+func (m *postgresTagRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Tag, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Tag, 0)
+	for rows.Next() {
+		t := domain.Tag{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *postgresTagRepository) getOne(ctx context.Context, query string, args ...interface{}) (res domain.Tag, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *postgresTagRepository) Fetch(ctx context.Context) (res []domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag ORDER BY name ASC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *postgresTagRepository) GetByID(ctx context.Context, id int64) (res domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag WHERE ID = $1`
+
+	return m.getOne(ctx, query, id)
+}
+
+func (m *postgresTagRepository) GetByName(ctx context.Context, name string) (res domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag WHERE name = $1`
+
+	return m.getOne(ctx, query, name)
+}
+
+// Store will refuse a name already taken with domain.ErrConflict
+func (m *postgresTagRepository) Store(ctx context.Context, a *domain.Tag) (err error) {
+	query := `INSERT INTO tag (name, updated_at, created_at) VALUES ($1, $2, $3) RETURNING id`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	err = stmt.QueryRowContext(ctx, a.Name, a.UpdatedAt, a.CreatedAt).Scan(&a.ID)
+	if postgresdb.IsUniqueViolation(err) {
+		return domain.ErrConflict
+	}
+	return
+}
+
+// Update will refuse a name already taken by another tag with domain.ErrConflict
+func (m *postgresTagRepository) Update(ctx context.Context, ar *domain.Tag) (err error) {
+	query := `UPDATE tag set name=$1, updated_at=$2 WHERE ID = $3`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Name, ar.UpdatedAt, ar.ID)
+	if postgresdb.IsUniqueViolation(err) {
+		return domain.ErrConflict
+	}
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresTagRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := "DELETE FROM tag WHERE id = $1"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+// MoveTodos will put the todos tagged with the first tag under the second one, a todo already carrying both keeps a single link
+func (m *postgresTagRepository) MoveTodos(ctx context.Context, from int64, into int64) (err error) {
+	conn := transaction.Conn(ctx, m.Conn)
+	_, err = conn.ExecContext(ctx, "INSERT INTO todo_tag (todo_id, tag_id) SELECT todo_id, $1::BIGINT FROM todo_tag WHERE tag_id = $2 ON CONFLICT DO NOTHING", into, from)
+	if err != nil {
+		return
+	}
+
+	_, err = conn.ExecContext(ctx, "DELETE FROM todo_tag WHERE tag_id = $1", from)
+	return
+}
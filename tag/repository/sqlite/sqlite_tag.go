@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type sqliteTagRepository struct {
+	Conn *sql.DB
+}
+
+// NewSqliteTagRepository will create an object that represent the domain.TagRepository interface
+func NewSqliteTagRepository(Conn *sql.DB) domain.TagRepository {
+	return &sqliteTagRepository{Conn}
+}
+
+func (m *sqliteTagRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Tag, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Tag, 0)
+	for rows.Next() {
+		t := domain.Tag{}
+		err = rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *sqliteTagRepository) getOne(ctx context.Context, query string, args ...interface{}) (res domain.Tag, err error) {
+	list, err := m.fetch(ctx, query, args...)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *sqliteTagRepository) Fetch(ctx context.Context) (res []domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag ORDER BY name ASC`
+
+	return m.fetch(ctx, query)
+}
+
+func (m *sqliteTagRepository) GetByID(ctx context.Context, id int64) (res domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag WHERE ID = ?`
+
+	return m.getOne(ctx, query, id)
+}
+
+func (m *sqliteTagRepository) GetByName(ctx context.Context, name string) (res domain.Tag, err error) {
+	query := `SELECT id, name, updated_at, created_at FROM tag WHERE name = ?`
+
+	return m.getOne(ctx, query, name)
+}
+
+// Store will refuse a name already taken with domain.ErrConflict
+func (m *sqliteTagRepository) Store(ctx context.Context, a *domain.Tag) (err error) {
+	query := `INSERT INTO tag (name, updated_at, created_at) VALUES (?, ?, ?)`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, a.Name, sqlitedb.Time(a.UpdatedAt), sqlitedb.Time(a.CreatedAt))
+	if sqlitedb.IsUniqueViolation(err) {
+		return domain.ErrConflict
+	}
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return
+}
+
+// Update will refuse a name already taken by another tag with domain.ErrConflict
+func (m *sqliteTagRepository) Update(ctx context.Context, ar *domain.Tag) (err error) {
+	query := `UPDATE tag set name=?, updated_at=? WHERE ID = ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Name, sqlitedb.Time(ar.UpdatedAt), ar.ID)
+	if sqlitedb.IsUniqueViolation(err) {
+		return domain.ErrConflict
+	}
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteTagRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := "DELETE FROM tag WHERE id = ?"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+// MoveTodos will put the todos tagged with the first tag under the second one, a todo already carrying both keeps a single link
+func (m *sqliteTagRepository) MoveTodos(ctx context.Context, from int64, into int64) (err error) {
+	conn := transaction.Conn(ctx, m.Conn)
+	_, err = conn.ExecContext(ctx, "INSERT OR IGNORE INTO todo_tag (todo_id, tag_id) SELECT todo_id, ? FROM todo_tag WHERE tag_id = ?", into, from)
+	if err != nil {
+		return
+	}
+
+	_, err = conn.ExecContext(ctx, "DELETE FROM todo_tag WHERE tag_id = ?", from)
+	return
+}
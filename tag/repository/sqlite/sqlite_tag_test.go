@@ -0,0 +1,133 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	tagSqliteRepo "github.com/bxcodec/go-clean-arch/tag/repository/sqlite"
+	todoSqliteRepo "github.com/bxcodec/go-clean-arch/todo/repository/sqlite"
+)
+
+// newDB will open a database in a file of its own, removed once the test is over
+func newDB(t *testing.T) *sql.DB {
+	dir, err := ioutil.TempDir("", "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sqlitedb.Open(filepath.Join(dir, "todolist.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+	return db
+}
+
+func storeTags(t *testing.T, repo domain.TagRepository, names ...string) []domain.Tag {
+	res := make([]domain.Tag, 0, len(names))
+	for _, name := range names {
+		tag := domain.Tag{Name: name}
+		assert.NoError(t, repo.Store(context.TODO(), &tag))
+		res = append(res, tag)
+	}
+	return res
+}
+
+func TestFetch(t *testing.T) {
+	repo := tagSqliteRepo.NewSqliteTagRepository(newDB(t))
+	storeTags(t, repo, "work", "home")
+
+	list, err := repo.Fetch(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, "home", list[0].Name)
+		assert.Equal(t, "work", list[1].Name)
+	}
+}
+
+func TestGetByName(t *testing.T) {
+	repo := tagSqliteRepo.NewSqliteTagRepository(newDB(t))
+	stored := storeTags(t, repo, "home")
+
+	tag, err := repo.GetByName(context.TODO(), "home")
+	assert.NoError(t, err)
+	assert.Equal(t, stored[0].ID, tag.ID)
+
+	_, err = repo.GetByName(context.TODO(), "work")
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repo.GetByID(context.TODO(), stored[0].ID+1)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestStore(t *testing.T) {
+	repo := tagSqliteRepo.NewSqliteTagRepository(newDB(t))
+	storeTags(t, repo, "home")
+
+	assert.Equal(t, domain.ErrConflict, repo.Store(context.TODO(), &domain.Tag{Name: "Home"}))
+}
+
+func TestUpdate(t *testing.T) {
+	repo := tagSqliteRepo.NewSqliteTagRepository(newDB(t))
+	stored := storeTags(t, repo, "home", "work")
+
+	tag := stored[0]
+	tag.Name = "house"
+	assert.NoError(t, repo.Update(context.TODO(), &tag))
+	res, err := repo.GetByID(context.TODO(), tag.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "house", res.Name)
+
+	tag.Name = "work"
+	assert.Equal(t, domain.ErrConflict, repo.Update(context.TODO(), &tag))
+	assert.Error(t, repo.Update(context.TODO(), &domain.Tag{ID: stored[1].ID + 1, Name: "other"}))
+}
+
+func TestDelete(t *testing.T) {
+	db := newDB(t)
+	repo := tagSqliteRepo.NewSqliteTagRepository(db)
+	todos := todoSqliteRepo.NewSqliteTodoRepository(db)
+	todo := &domain.Todo{Title: "Judul", Priority: domain.PriorityNormal, Tags: []string{"home", "work"}}
+	assert.NoError(t, todos.Store(context.TODO(), todo))
+
+	tag, err := repo.GetByName(context.TODO(), "home")
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Delete(context.TODO(), tag.ID))
+
+	// the links to the todos go along with the tag
+	res, err := todos.GetByID(context.TODO(), todo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"work"}, res.Tags)
+	assert.Error(t, repo.Delete(context.TODO(), tag.ID))
+}
+
+func TestMoveTodos(t *testing.T) {
+	db := newDB(t)
+	repo := tagSqliteRepo.NewSqliteTagRepository(db)
+	todos := todoSqliteRepo.NewSqliteTodoRepository(db)
+	both := &domain.Todo{Title: "both", Priority: domain.PriorityNormal, Tags: []string{"home", "house"}}
+	single := &domain.Todo{Title: "single", Priority: domain.PriorityNormal, Tags: []string{"house"}}
+	assert.NoError(t, todos.Store(context.TODO(), both))
+	assert.NoError(t, todos.Store(context.TODO(), single))
+
+	from, err := repo.GetByName(context.TODO(), "house")
+	assert.NoError(t, err)
+	into, err := repo.GetByName(context.TODO(), "home")
+	assert.NoError(t, err)
+	assert.NoError(t, repo.MoveTodos(context.TODO(), from.ID, into.ID))
+
+	for _, todo := range []*domain.Todo{both, single} {
+		res, err := todos.GetByID(context.TODO(), todo.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"home"}, res.Tags)
+	}
+}
@@ -0,0 +1,147 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	ucase "github.com/bxcodec/go-clean-arch/tag/usecase"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockTagRepo := new(mocks.TagRepositoryMock)
+
+	t.Run("success", func(t *testing.T) {
+		mockTagRepo.On("GetByName", mock.Anything, "urgent").Return(domain.Tag{}, domain.ErrNotFound).Once()
+		mockTagRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Tag")).Return(nil).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		tag := domain.Tag{Name: " Urgent "}
+		err := u.Store(context.TODO(), &tag)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "urgent", tag.Name)
+		assert.Equal(t, now, tag.CreatedAt)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("existing-name", func(t *testing.T) {
+		mockTagRepo.On("GetByName", mock.Anything, "urgent").Return(domain.Tag{ID: 1, Name: "urgent"}, nil).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		tag := domain.Tag{Name: "URGENT"}
+		err := u.Store(context.TODO(), &tag)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("blank-name", func(t *testing.T) {
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		tag := domain.Tag{Name: "  "}
+		err := u.Store(context.TODO(), &tag)
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := createdAt.Add(time.Hour)
+	mockTagRepo := new(mocks.TagRepositoryMock)
+	mockTag := domain.Tag{ID: 1, Name: "urgent", CreatedAt: createdAt, UpdatedAt: createdAt}
+
+	t.Run("rename", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, int64(1)).Return(mockTag, nil).Once()
+		mockTagRepo.On("GetByName", mock.Anything, "asap").Return(domain.Tag{}, domain.ErrNotFound).Once()
+		mockTagRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Tag")).Return(nil).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		tag := domain.Tag{ID: 1, Name: "ASAP"}
+		err := u.Update(context.TODO(), &tag)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "asap", tag.Name)
+		assert.Equal(t, createdAt, tag.CreatedAt)
+		assert.Equal(t, now, tag.UpdatedAt)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("name-of-another-tag", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, int64(1)).Return(mockTag, nil).Once()
+		mockTagRepo.On("GetByName", mock.Anything, "backend").Return(domain.Tag{ID: 2, Name: "backend"}, nil).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		tag := domain.Tag{ID: 1, Name: "backend"}
+		err := u.Update(context.TODO(), &tag)
+
+		assert.Equal(t, domain.ErrConflict, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepositoryMock)
+	source := domain.Tag{ID: 5, Name: "asap"}
+	target := domain.Tag{ID: 3, Name: "urgent"}
+
+	t.Run("success", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+		mockTagRepo.On("GetByID", mock.Anything, target.ID).Return(target, nil).Once()
+		mockTagRepo.On("MoveTodos", mock.Anything, source.ID, target.ID).Return(nil).Once()
+		mockTagRepo.On("Delete", mock.Anything, source.ID).Return(nil).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{}, time.Second*2)
+
+		res, err := u.Merge(context.TODO(), source.ID, target.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, target, res)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("error-moving-todos", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+		mockTagRepo.On("GetByID", mock.Anything, target.ID).Return(target, nil).Once()
+		mockTagRepo.On("MoveTodos", mock.Anything, source.ID, target.ID).Return(errors.New("Unexpected Error")).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{}, time.Second*2)
+
+		_, err := u.Merge(context.TODO(), source.ID, target.ID)
+
+		assert.Error(t, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+	t.Run("into-itself", func(t *testing.T) {
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{}, time.Second*2)
+
+		_, err := u.Merge(context.TODO(), source.ID, source.ID)
+
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+	t.Run("unknown-target", func(t *testing.T) {
+		mockTagRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+		mockTagRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.Tag{}, domain.ErrNotFound).Once()
+		u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{}, time.Second*2)
+
+		_, err := u.Merge(context.TODO(), source.ID, 9)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTagRepo.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepositoryMock)
+
+	mockTagRepo.On("GetByID", mock.Anything, int64(1)).Return(domain.Tag{ID: 1, Name: "urgent"}, nil).Once()
+	mockTagRepo.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+	u := ucase.NewTagUsecase(mockTagRepo, new(mocks.TransactorMock), &mocks.ClockMock{}, time.Second*2)
+
+	err := u.Delete(context.TODO(), 1)
+
+	assert.NoError(t, err)
+	mockTagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type tagUsecase struct {
+	tag            domain.TagRepository
+	transactor     domain.Transactor
+	clock          domain.Clock
+	contextTimeout time.Duration
+}
+
+// NewTagUsecase will create new an tagUsecase object representation of domain.TagUsecase interface
+func NewTagUsecase(tg domain.TagRepository, tx domain.Transactor, clk domain.Clock, timeout time.Duration) domain.TagUsecase {
+	return &tagUsecase{
+		tag:            tg,
+		transactor:     tx,
+		clock:          clk,
+		contextTimeout: timeout,
+	}
+}
+
+func (a *tagUsecase) Fetch(c context.Context) (res []domain.Tag, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.tag.Fetch(ctx)
+}
+
+func (a *tagUsecase) GetByID(c context.Context, id int64) (res domain.Tag, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.tag.GetByID(ctx, id)
+}
+
+// checkName will normalize the name of the tag and make sure no other tag has it, two tags are merged rather than given the same name
+func (a *tagUsecase) checkName(ctx context.Context, m *domain.Tag) error {
+	m.Name = domain.NormalizeTagName(m.Name)
+	if m.Name == "" {
+		return domain.ErrBadParamInput
+	}
+
+	sameName, err := a.tag.GetByName(ctx, m.Name)
+	if err != nil && err != domain.ErrNotFound {
+		return err
+	}
+	if sameName.ID != 0 && sameName.ID != m.ID {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (a *tagUsecase) Store(c context.Context, m *domain.Tag) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	m.ID = 0
+	if err = a.checkName(ctx, m); err != nil {
+		return
+	}
+
+	m.CreatedAt = a.clock.Now()
+	m.UpdatedAt = m.CreatedAt
+	return a.tag.Store(ctx, m)
+}
+
+func (a *tagUsecase) Update(c context.Context, m *domain.Tag) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	existedTag, err := a.tag.GetByID(ctx, m.ID)
+	if err != nil {
+		return
+	}
+	if err = a.checkName(ctx, m); err != nil {
+		return
+	}
+
+	m.CreatedAt = existedTag.CreatedAt
+	m.UpdatedAt = a.clock.Now()
+	return a.tag.Update(ctx, m)
+}
+
+// Merge will move the todos of the tag under the tag it is merged into, then delete it
+func (a *tagUsecase) Merge(c context.Context, id int64, into int64) (res domain.Tag, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if id == into {
+		return domain.Tag{}, domain.ErrBadParamInput
+	}
+	if _, err = a.tag.GetByID(ctx, id); err != nil {
+		return domain.Tag{}, err
+	}
+	res, err = a.tag.GetByID(ctx, into)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := a.tag.MoveTodos(ctx, id, into)
+		if err != nil {
+			return err
+		}
+		return a.tag.Delete(ctx, id)
+	})
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	return
+}
+
+func (a *tagUsecase) Delete(c context.Context, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if _, err = a.tag.GetByID(ctx, id); err != nil {
+		return
+	}
+	return a.tag.Delete(ctx, id)
+}
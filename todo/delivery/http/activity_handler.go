@@ -1,35 +1,197 @@
 package http
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo"
 	"github.com/sirupsen/logrus"
-	validator "gopkg.in/go-playground/validator.v9"
 
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/markdown"
+	"github.com/bxcodec/go-clean-arch/pkg/mergepatch"
+	"github.com/bxcodec/go-clean-arch/pkg/timezone"
+	"github.com/bxcodec/go-clean-arch/pkg/validation"
 )
 
 // ResponseError represent the reseponse error struct
 type ResponseError struct {
-	Message string `json:"message"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// copyRequest is the body of the copy of a todo into an activity group
+type copyRequest struct {
+	ActivityGroupID int64 `json:"activity_group_id" validate:"required"`
+	ResetStatus     bool  `json:"reset_status"`
+}
+
+// moveManyRequest is the body of the move of several todos into an activity group
+type moveManyRequest struct {
+	IDs             []int64 `json:"ids" validate:"required,min=1,dive,min=1"`
+	ActivityGroupID int64   `json:"activity_group_id" validate:"required"`
+}
+
+// copyManyRequest is the body of the copy of several todos into an activity group
+type copyManyRequest struct {
+	IDs             []int64 `json:"ids" validate:"required,min=1,dive,min=1"`
+	ActivityGroupID int64   `json:"activity_group_id" validate:"required"`
+	ResetStatus     bool    `json:"reset_status"`
+}
+
+// bulkRequest is the body of a batch of writes on todos, all_or_nothing when no mode is given
+type bulkRequest struct {
+	Mode       domain.BulkMode        `json:"mode" validate:"omitempty,enum"`
+	Operations []domain.TodoOperation `json:"operations" validate:"required,min=1,max=500,dive"`
+}
+
+// bulkResult is the outcome of the operation at Index of a batch, Status being the one of the single endpoint
+type bulkResult struct {
+	Index  int          `json:"index"`
+	Status int          `json:"status"`
+	Todo   *domain.Todo `json:"todo,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// bulkResponse is the body answering a batch of writes on todos
+type bulkResponse struct {
+	Mode    domain.BulkMode `json:"mode"`
+	Results []bulkResult    `json:"results"`
 }
 
 // TodoHandler  represent the httphandler for article
 type TodoHandler struct {
 	AUsecase domain.TodoUsecase
+	// Location is the time zone of the callers not telling theirs, UTC when it is nil
+	Location *time.Location
 }
 
 // NewArticleHandler will initialize the articles/ resources endpoint
-func NewTodoHandler(e *echo.Echo, us domain.TodoUsecase) {
+func NewTodoHandler(e *echo.Echo, us domain.TodoUsecase, loc *time.Location) {
 	handler := &TodoHandler{
 		AUsecase: us,
+		Location: loc,
 	}
 	e.GET("/todo", handler.FetchTodo)
 	e.POST("/todo", handler.Store)
+	e.POST("/todo/move", handler.MoveMany)
+	e.POST("/todo/copy", handler.CopyMany)
+	e.POST("/todo/bulk", handler.Bulk)
 	e.GET("/todo/:id", handler.GetByID)
+	e.PUT("/todo/:id", handler.Update)
+	e.PATCH("/todo/:id", handler.Patch)
 	e.DELETE("/todo/:id", handler.Delete)
+	e.POST("/todo/:id/complete", handler.Complete)
+	e.POST("/todo/:id/reopen", handler.Reopen)
+	e.POST("/todo/:id/move", handler.Move)
+	e.POST("/todo/:id/copy", handler.Copy)
+	e.POST("/todo/:id/restore", handler.Restore)
+}
+
+// parseDue will parse the due bound either as a RFC3339 time or as a date in the caller's time zone,
+// a date stands for the whole day so it is moved to the next midnight when it is the upper bound
+func parseDue(value string, loc *time.Location, upper bool) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return nil, domain.ErrBadParamInput
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// parseFilter will build the todo filter from the given query params
+func parseFilter(c echo.Context, loc *time.Location) (filter domain.TodoFilter, err error) {
+	if activityGroupID := c.QueryParam("activity_group_id"); activityGroupID != "" {
+		filter.ActivityGroupID, err = strconv.ParseInt(activityGroupID, 10, 64)
+		if err != nil {
+			return domain.TodoFilter{}, domain.ErrBadParamInput
+		}
+	}
+
+	if statusS := c.QueryParam("status"); statusS != "" {
+		filter.Status, err = domain.ParseTodoStatuses(statusS)
+		if err != nil {
+			return domain.TodoFilter{}, err
+		}
+	}
+
+	// is_active is kept for the older clients, it maps to the open or closed statuses
+	if isActiveS := c.QueryParam("is_active"); isActiveS != "" {
+		if filter.Status != nil {
+			return domain.TodoFilter{}, domain.ErrBadParamInput
+		}
+		switch isActiveS {
+		case "1":
+			filter.Status = domain.ActiveTodoStatuses
+		case "0":
+			filter.Status = domain.InactiveTodoStatuses
+		default:
+			return domain.TodoFilter{}, domain.ErrBadParamInput
+		}
+	}
+
+	if priorityS := c.QueryParam("priority"); priorityS != "" {
+		priority, err := domain.ParsePriority(priorityS)
+		if err != nil {
+			return domain.TodoFilter{}, err
+		}
+		filter.Priority = &priority
+	}
+
+	if dueAfter := c.QueryParam("due_after"); dueAfter != "" {
+		filter.DueAfter, err = parseDue(dueAfter, loc, false)
+		if err != nil {
+			return domain.TodoFilter{}, err
+		}
+	}
+
+	if dueBefore := c.QueryParam("due_before"); dueBefore != "" {
+		filter.DueBefore, err = parseDue(dueBefore, loc, true)
+		if err != nil {
+			return domain.TodoFilter{}, err
+		}
+	}
+
+	if overdue := c.QueryParam("overdue"); overdue != "" {
+		filter.Overdue, err = strconv.ParseBool(overdue)
+		if err != nil {
+			return domain.TodoFilter{}, domain.ErrBadParamInput
+		}
+	}
+
+	// the tag param is repeated, e.g. `tag=urgent&tag=backend`, tag_match tells whether any or all of them are required
+	matchS := c.QueryParam("tag_match")
+	filter.Tags = domain.NormalizeTags(c.QueryParams()["tag"])
+	if len(filter.Tags) > 0 || matchS != "" {
+		match, err := domain.ParseTagMatch(matchS)
+		if err != nil || len(filter.Tags) == 0 {
+			return domain.TodoFilter{}, domain.ErrBadParamInput
+		}
+		filter.TagMatch = match
+	}
+
+	return filter, nil
+}
+
+// renderHTML will tell whether the render param asks for the notes rendered as HTML along with the Markdown
+func renderHTML(c echo.Context) (bool, error) {
+	switch c.QueryParam("render") {
+	case "":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, domain.ErrBadParamInput
+	}
 }
 
 // FetchArticle will fetch the article based on given params
@@ -39,12 +201,38 @@ func (a *TodoHandler) FetchTodo(c echo.Context) error {
 	cursor := c.QueryParam("cursor")
 	ctx := c.Request().Context()
 
-	listAr, nextCursor, err := a.AUsecase.Fetch(ctx, cursor, int64(num))
+	render, err := renderHTML(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	loc, err := timezone.Caller(c, a.Location)
 	if err != nil {
 		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
 	}
 
+	filter, err := parseFilter(c, loc)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	sort, err := domain.ParseTodoSort(c.QueryParam("sort"))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	listAr, nextCursor, prevCursor, err := a.AUsecase.Fetch(ctx, cursor, int64(num), filter, sort)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+	if render {
+		for i := range listAr {
+			listAr[i].NotesHTML = markdown.Render(listAr[i].Notes)
+		}
+	}
+
 	c.Response().Header().Set(`X-Cursor`, nextCursor)
+	c.Response().Header().Set(`X-Prev-Cursor`, prevCursor)
 	return c.JSON(http.StatusOK, listAr)
 }
 
@@ -58,21 +246,31 @@ func (a *TodoHandler) GetByID(c echo.Context) error {
 	id := int64(idP)
 	ctx := c.Request().Context()
 
+	render, err := renderHTML(c)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
 	art, err := a.AUsecase.GetByID(ctx, id)
 	if err != nil {
 		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
 	}
+	if render {
+		art.NotesHTML = markdown.Render(art.Notes)
+	}
 
 	return c.JSON(http.StatusOK, art)
 }
 
-func isRequestValid(m *domain.Todo) (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(m)
+func isRequestValid(m interface{}) (bool, ResponseError) {
+	fields, err := validation.Struct(m)
 	if err != nil {
-		return false, err
+		return false, ResponseError{Message: err.Error()}
 	}
-	return true, nil
+	if len(fields) > 0 {
+		return false, ResponseError{Message: domain.ErrBadParamInput.Error(), Errors: fields}
+	}
+	return true, ResponseError{}
 }
 
 // Store will store the article by given request body
@@ -83,9 +281,12 @@ func (a *TodoHandler) Store(c echo.Context) (err error) {
 		return c.JSON(http.StatusUnprocessableEntity, err.Error())
 	}
 
-	var ok bool
-	if ok, err = isRequestValid(&article); !ok {
-		return c.JSON(http.StatusBadRequest, err.Error())
+	if ok, resErr := isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	if err = timezone.Recurrence(c, &article, a.Location); err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
 	}
 
 	ctx := c.Request().Context()
@@ -97,6 +298,85 @@ func (a *TodoHandler) Store(c echo.Context) (err error) {
 	return c.JSON(http.StatusCreated, article)
 }
 
+// Update will replace the article by given param and request body
+func (a *TodoHandler) Update(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var article domain.Todo
+	err = c.Bind(&article)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	article.ID = int64(idP)
+
+	if ok, resErr := isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	err = a.AUsecase.Update(ctx, &article)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
+// Patch will partially update the article by given param and JSON merge-patch body
+func (a *TodoHandler) Patch(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	if !mergepatch.IsMediaType(c.Request().Header.Get(echo.HeaderContentType)) {
+		return c.JSON(http.StatusUnsupportedMediaType, ResponseError{Message: mergepatch.ErrMediaType.Error()})
+	}
+	patch, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	id := int64(idP)
+	ctx := c.Request().Context()
+
+	existed, err := a.AUsecase.GetByID(ctx, id)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	original, err := json.Marshal(existed)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseError{Message: err.Error()})
+	}
+
+	patched, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var article domain.Todo
+	err = json.Unmarshal(patched, &article)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	article.ID = id
+
+	if ok, resErr := isRequestValid(&article); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	err = a.AUsecase.Update(ctx, &article)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
 // Delete will delete article by given param
 func (a *TodoHandler) Delete(c echo.Context) error {
 	idP, err := strconv.Atoi(c.Param("id"))
@@ -115,6 +395,212 @@ func (a *TodoHandler) Delete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// Complete will mark the todo as done by given param
+func (a *TodoHandler) Complete(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	todo, err := a.AUsecase.Complete(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Restore will bring the todo given by param back from the trash
+func (a *TodoHandler) Restore(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	todo, err := a.AUsecase.Restore(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Move will drop the todo given by param next to the neighbors, or at the end of the activity group, given by the request body
+func (a *TodoHandler) Move(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var move domain.TodoMove
+	err = c.Bind(&move)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	if move == (domain.TodoMove{}) {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: domain.ErrBadParamInput.Error()})
+	}
+
+	ctx := c.Request().Context()
+	todo, err := a.AUsecase.Move(ctx, int64(idP), move)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Copy will store a copy of the todo given by param into the activity group given by the request body
+func (a *TodoHandler) Copy(c echo.Context) (err error) {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var req copyRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	todo, err := a.AUsecase.Copy(ctx, int64(idP), domain.TodoCopy{ActivityGroupID: req.ActivityGroupID, ResetStatus: req.ResetStatus})
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, todo)
+}
+
+// MoveMany will move the todos given by the request body to the end of its activity group, all of them or none
+func (a *TodoHandler) MoveMany(c echo.Context) (err error) {
+	var req moveManyRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	todos, err := a.AUsecase.MoveMany(ctx, req.IDs, req.ActivityGroupID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, todos)
+}
+
+// CopyMany will copy the todos given by the request body to the end of its activity group, all of them or none
+func (a *TodoHandler) CopyMany(c echo.Context) (err error) {
+	var req copyManyRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	todos, err := a.AUsecase.CopyMany(ctx, req.IDs, domain.TodoCopy{ActivityGroupID: req.ActivityGroupID, ResetStatus: req.ResetStatus})
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, todos)
+}
+
+// Bulk will run the operations given by the request body in a single transaction, answering 200 when all of them
+// succeed, 207 when some failed in best_effort mode and the status of the failed operation in all_or_nothing mode
+func (a *TodoHandler) Bulk(c echo.Context) (err error) {
+	var req bulkRequest
+	err = c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	if ok, resErr := isRequestValid(&req); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+	if req.Mode == "" {
+		req.Mode = domain.BulkAllOrNothing
+	}
+	// the todos created in bulk follow the caller's time zone as the ones created one by one
+	for _, op := range req.Operations {
+		if op.Op != domain.TodoOperationCreate || op.Todo == nil {
+			continue
+		}
+		if err = timezone.Recurrence(c, op.Todo, a.Location); err != nil {
+			return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+		}
+	}
+
+	ctx := c.Request().Context()
+	results, err := a.AUsecase.Bulk(ctx, req.Operations, req.Mode)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	status := http.StatusOK
+	res := bulkResponse{Mode: req.Mode, Results: make([]bulkResult, len(results))}
+	for i, result := range results {
+		res.Results[i] = bulkResult{Index: i, Status: operationStatus(req.Operations[i].Op), Todo: result.Todo}
+		if result.Err == nil {
+			continue
+		}
+		res.Results[i].Status = getStatusCode(result.Err)
+		res.Results[i].Error = result.Err.Error()
+		if req.Mode == domain.BulkBestEffort {
+			status = http.StatusMultiStatus
+		} else if result.Err != domain.ErrBulkAborted {
+			status = res.Results[i].Status
+		}
+	}
+
+	return c.JSON(status, res)
+}
+
+// operationStatus will give the status the single endpoint of the operation answers on success
+func operationStatus(op domain.TodoOperationKind) int {
+	switch op {
+	case domain.TodoOperationCreate:
+		return http.StatusCreated
+	case domain.TodoOperationDelete:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+// Reopen will move the done or cancelled todo back to todo by given param
+func (a *TodoHandler) Reopen(c echo.Context) error {
+	idP, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+
+	todo, err := a.AUsecase.Reopen(ctx, int64(idP))
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
 func getStatusCode(err error) int {
 	if err == nil {
 		return http.StatusOK
@@ -128,6 +614,18 @@ func getStatusCode(err error) int {
 		return http.StatusNotFound
 	case domain.ErrConflict:
 		return http.StatusConflict
+	case domain.ErrBadParamInput:
+		return http.StatusBadRequest
+	case domain.ErrUnknownActivity:
+		return http.StatusUnprocessableEntity
+	case domain.ErrActivityHasTodos:
+		return http.StatusConflict
+	case domain.ErrInvalidTransition:
+		return http.StatusConflict
+	case domain.ErrActivityArchived:
+		return http.StatusConflict
+	case domain.ErrBulkAborted:
+		return http.StatusFailedDependency
 	default:
 		return http.StatusInternalServerError
 	}
@@ -9,62 +9,66 @@ import (
 	"testing"
 	"time"
 
-	"github.com/bxcodec/faker"
 	"github.com/labstack/echo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	activityHTTP "github.com/bxcodec/go-clean-arch/activity/delivery/http"
 	"github.com/bxcodec/go-clean-arch/domain"
 	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	todoHTTP "github.com/bxcodec/go-clean-arch/todo/delivery/http"
 )
 
 func TestFetch(t *testing.T) {
-	var mockArticle domain.Activity
-	err := faker.FakeData(&mockArticle)
-	assert.NoError(t, err)
-	mockUCase := new(mocks.ActivityUsecaseMock)
-	mockListArticle := make([]domain.Activity, 0)
-	mockListArticle = append(mockListArticle, mockArticle)
+	mockTodo := domain.Todo{
+		ID:              7,
+		ActivityGroupID: domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"},
+		Title:           "Title",
+		Status:          domain.TodoStatusInProgress,
+		Priority:        3,
+	}
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockListTodo := make([]domain.Todo, 0)
+	mockListTodo = append(mockListTodo, mockTodo)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(mockListArticle, "10", nil)
+	mockUCase.On("Fetch", mock.Anything, cursor, int64(num), domain.TodoFilter{}, domain.TodoSort(nil)).Return(mockListTodo, "10", "8", nil)
 
 	e := echo.New()
-	req, err := http.NewRequest(echo.GET, "/activity?num=1&cursor="+cursor, strings.NewReader(""))
+	req, err := http.NewRequest(echo.GET, "/todo?num=1&cursor="+cursor, strings.NewReader(""))
 	assert.NoError(t, err)
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	handler := activityHTTP.ArticleHandler{
+	handler := todoHTTP.TodoHandler{
 		AUsecase: mockUCase,
 	}
-	err = handler.FetchActivity(c)
+	err = handler.FetchTodo(c)
 	require.NoError(t, err)
 
 	responseCursor := rec.Header().Get("X-Cursor")
 	assert.Equal(t, "10", responseCursor)
+	assert.Equal(t, "8", rec.Header().Get("X-Prev-Cursor"))
 	assert.Equal(t, http.StatusOK, rec.Code)
 	mockUCase.AssertExpectations(t)
 }
 
 func TestFetchError(t *testing.T) {
-	mockUCase := new(mocks.ActivityUsecaseMock)
+	mockUCase := new(mocks.TodoUsecaseMock)
 	num := 1
 	cursor := "2"
-	mockUCase.On("Fetch", mock.Anything, cursor, int64(num)).Return(nil, "", domain.ErrInternalServerError)
+	mockUCase.On("Fetch", mock.Anything, cursor, int64(num), domain.TodoFilter{}, domain.TodoSort(nil)).Return(nil, "", "", domain.ErrInternalServerError)
 
 	e := echo.New()
-	req, err := http.NewRequest(echo.GET, "/activity?num=1&cursor="+cursor, strings.NewReader(""))
+	req, err := http.NewRequest(echo.GET, "/todo?num=1&cursor="+cursor, strings.NewReader(""))
 	assert.NoError(t, err)
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	handler := activityHTTP.ArticleHandler{
+	handler := todoHTTP.TodoHandler{
 		AUsecase: mockUCase,
 	}
-	err = handler.FetchActivity(c)
+	err = handler.FetchTodo(c)
 	require.NoError(t, err)
 
 	responseCursor := rec.Header().Get("X-Cursor")
@@ -73,27 +77,264 @@ func TestFetchError(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 }
 
-func TestGetByID(t *testing.T) {
-	var mockArticle domain.Activity
-	err := faker.FakeData(&mockArticle)
+func TestFetchWithFilter(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	priority := domain.PriorityVeryHigh
+	filter := domain.TodoFilter{
+		ActivityGroupID: 3,
+		Status:          domain.ActiveTodoStatuses,
+		Priority:        &priority,
+	}
+	mockUCase.On("Fetch", mock.Anything, "", int64(10), filter, domain.TodoSort(nil)).Return([]domain.Todo{}, "", "", nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo?num=10&activity_group_id=3&is_active=1&priority=very-high", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchTodo(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchWithStatus(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	filter := domain.TodoFilter{
+		Status: []domain.TodoStatus{domain.TodoStatusDone, domain.TodoStatusCancelled},
+	}
+	mockUCase.On("Fetch", mock.Anything, "", int64(0), filter, domain.TodoSort(nil)).Return([]domain.Todo{}, "", "", nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo?status=done,cancelled", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchTodo(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+
+	for _, invalidQuery := range []string{"status=paused", "is_active=2", "status=done&is_active=1"} {
+		req, err = http.NewRequest(echo.GET, "/todo?"+invalidQuery, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		err = handler.FetchTodo(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestFetchWithTags(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	filter := domain.TodoFilter{
+		Tags:     []string{"backend", "urgent"},
+		TagMatch: domain.TagMatchAll,
+	}
+	mockUCase.On("Fetch", mock.Anything, "", int64(0), filter, domain.TodoSort(nil)).Return([]domain.Todo{}, "", "", nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo?tag=Urgent&tag=backend&tag=urgent&tag_match=all", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchTodo(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+
+	for _, invalidQuery := range []string{"tag=urgent&tag_match=some", "tag_match=all"} {
+		req, err = http.NewRequest(echo.GET, "/todo?"+invalidQuery, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		err = handler.FetchTodo(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestFetchWithDue(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	dueAt := func(loc *time.Location, day int) *time.Time {
+		t := time.Date(2021, 3, day, 0, 0, 0, 0, loc)
+		return &t
+	}
+	dueBefore := time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		query  string
+		header string
+		filter domain.TodoFilter
+	}{
+		{
+			name:   "default-location",
+			query:  "due_after=2021-03-01&due_before=2021-03-01",
+			filter: domain.TodoFilter{DueAfter: dueAt(jakarta, 1), DueBefore: dueAt(jakarta, 2)},
+		},
+		{
+			name:   "header-location",
+			query:  "due_after=2021-03-01",
+			header: "Asia/Tokyo",
+			filter: domain.TodoFilter{DueAfter: dueAt(tokyo, 1)},
+		},
+		{
+			name:   "query-location",
+			query:  "overdue=true&tz=Asia/Tokyo&due_before=2021-03-05T10:00:00Z",
+			header: "Asia/Jakarta",
+			filter: domain.TodoFilter{DueBefore: &dueBefore, Overdue: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUCase := new(mocks.TodoUsecaseMock)
+			mockUCase.On("Fetch", mock.Anything, "", int64(0), mock.MatchedBy(func(filter domain.TodoFilter) bool {
+				return sameTime(filter.DueAfter, tt.filter.DueAfter) && sameTime(filter.DueBefore, tt.filter.DueBefore) &&
+					filter.Overdue == tt.filter.Overdue
+			}), domain.TodoSort(nil)).Return([]domain.Todo{}, "", "", nil)
+
+			e := echo.New()
+			req, err := http.NewRequest(echo.GET, "/todo?"+tt.query, strings.NewReader(""))
+			assert.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set("X-Timezone", tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := todoHTTP.TodoHandler{
+				AUsecase: mockUCase,
+				Location: jakarta,
+			}
+			err = handler.FetchTodo(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			mockUCase.AssertExpectations(t)
+		})
+	}
+
+	for _, invalidQuery := range []string{"due_after=yesterday", "overdue=maybe", "tz=Mars/Olympus"} {
+		req, err := http.NewRequest(echo.GET, "/todo?"+invalidQuery, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		handler := todoHTTP.TodoHandler{
+			AUsecase: new(mocks.TodoUsecaseMock),
+		}
+		err = handler.FetchTodo(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestFetchWithInvalidFilter(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo?priority=urgent", strings.NewReader(""))
 	assert.NoError(t, err)
 
-	mockUCase := new(mocks.ActivityUsecaseMock)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchTodo(c)
+	require.NoError(t, err)
 
-	num := int(mockArticle.ID)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUCase.AssertNotCalled(t, "Fetch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
 
-	mockUCase.On("GetByID", mock.Anything, int64(num)).Return(mockArticle, nil)
+func TestFetchWithSort(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	sort := domain.TodoSort{{Field: "priority", Descending: true}, {Field: "created_at"}}
+	mockUCase.On("Fetch", mock.Anything, "", int64(0), domain.TodoFilter{}, sort).Return([]domain.Todo{}, "", "", nil)
 
 	e := echo.New()
-	req, err := http.NewRequest(echo.GET, "/activity/"+strconv.Itoa(num), strings.NewReader(""))
+	req, err := http.NewRequest(echo.GET, "/todo?sort=-priority,created_at", strings.NewReader(""))
 	assert.NoError(t, err)
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	c.SetPath("activity/:id")
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.FetchTodo(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+
+	for _, invalidSort := range []string{"-id", "priority,-priority", "notes"} {
+		req, err = http.NewRequest(echo.GET, "/todo?sort="+invalidSort, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		err = handler.FetchTodo(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              7,
+		ActivityGroupID: domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"},
+		Title:           "Title",
+		Status:          domain.TodoStatusInProgress,
+		Priority:        3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+
+	num := int(mockTodo.ID)
+
+	mockUCase.On("GetByID", mock.Anything, int64(num)).Return(mockTodo, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo/"+strconv.Itoa(num), strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id")
 	c.SetParamNames("id")
 	c.SetParamValues(strconv.Itoa(num))
-	handler := activityHTTP.ArticleHandler{
+	handler := todoHTTP.TodoHandler{
 		AUsecase: mockUCase,
 	}
 	err = handler.GetByID(c)
@@ -104,32 +345,34 @@ func TestGetByID(t *testing.T) {
 }
 
 func TestStore(t *testing.T) {
-	mockArticle := domain.Activity{
-		Email:     "Content",
-		Title:     "Title",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	mockTodo := domain.Todo{
+		ActivityGroupID: domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"},
+		Title:           "Title",
+		Status:          domain.TodoStatusTodo,
+		Priority:        3,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
 
-	tempMockArticle := mockArticle
-	tempMockArticle.ID = 0
-	mockUCase := new(mocks.ActivityUsecaseMock)
+	tempMockTodo := mockTodo
+	tempMockTodo.ID = 0
+	mockUCase := new(mocks.TodoUsecaseMock)
 
-	j, err := json.Marshal(tempMockArticle)
+	j, err := json.Marshal(tempMockTodo)
 	assert.NoError(t, err)
 
-	mockUCase.On("Store", mock.Anything, mock.AnythingOfType("*domain.Activity")).Return(nil)
+	mockUCase.On("Store", mock.Anything, mock.AnythingOfType("*domain.Todo")).Return(nil)
 
 	e := echo.New()
-	req, err := http.NewRequest(echo.POST, "/activity", strings.NewReader(string(j)))
+	req, err := http.NewRequest(echo.POST, "/todo", strings.NewReader(string(j)))
 	assert.NoError(t, err)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	c.SetPath("/activity")
+	c.SetPath("/todo")
 
-	handler := activityHTTP.ArticleHandler{
+	handler := todoHTTP.TodoHandler{
 		AUsecase: mockUCase,
 	}
 	err = handler.Store(c)
@@ -139,27 +382,231 @@ func TestStore(t *testing.T) {
 	mockUCase.AssertExpectations(t)
 }
 
-func TestDelete(t *testing.T) {
-	var mockArticle domain.Activity
-	err := faker.FakeData(&mockArticle)
+func TestStorePriority(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+		return td.Priority == domain.PriorityHigh
+	})).Return(nil)
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	activity := `"activity_group_id":{"id":2,"email":"bagus@gmail.com","title":"Work"}`
+
+	for _, body := range []string{`{"title":"Title","priority":"high",` + activity + `}`, `{"title":"Title","priority":4,` + activity + `}`} {
+		req, err := http.NewRequest(echo.POST, "/todo", strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err = handler.Store(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"priority":"high"`)
+	}
+	mockUCase.AssertExpectations(t)
+
+	for _, priority := range []string{`"urgent"`, `9`, `true`} {
+		req, err := http.NewRequest(echo.POST, "/todo", strings.NewReader(`{"title":"Title","priority":`+priority+`,`+activity+`}`))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		err = handler.Store(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var resErr todoHTTP.ResponseError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resErr))
+		assert.Equal(t, "must be one of very-low, low, normal, high, very-high", resErr.Errors["priority"])
+	}
+}
+
+func TestStoreRecurrence(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+		return td.Recurrence == "FREQ=WEEKLY" && td.TimeZone == "Europe/Berlin"
+	})).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/todo", strings.NewReader(
+		`{"title":"Chore","priority":"normal","recurrence":"FREQ=WEEKLY","activity_group_id":{"id":2,"email":"bagus@gmail.com","title":"Work"}}`))
 	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Timezone", "Europe/Berlin")
 
-	mockUCase := new(mocks.ActivityUsecaseMock)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
 
-	num := int(mockArticle.ID)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              12,
+		ActivityGroupID: domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"},
+		Title:           "Title",
+		Status:          domain.TodoStatusTodo,
+		Priority:        3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+
+	j, err := json.Marshal(mockTodo)
+	assert.NoError(t, err)
+
+	mockUCase.On("Update", mock.Anything, mock.AnythingOfType("*domain.Todo")).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/todo/12", strings.NewReader(string(j)))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Update(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestPatch(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              12,
+		ActivityGroupID: domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"},
+		Title:           "Title",
+		Status:          domain.TodoStatusTodo,
+		Priority:        3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockTodo, nil)
+	mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+		return td.ID == 12 && td.Priority == 5 && td.Title == "Title" && td.ActivityGroupID.ID == 2
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/todo/12", strings.NewReader(`{"priority":5}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestPatchDetached(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:       12,
+		Title:    "Title",
+		Status:   domain.TodoStatusTodo,
+		Priority: 3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, int64(12)).Return(mockTodo, nil)
+	mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+		return td.ID == 12 && td.Priority == 5 && td.ActivityGroupID.ID == 0
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/todo/12", strings.NewReader(`{"priority":5}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreActivityByID(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+		return td.ActivityGroupID.ID == 2
+	})).Return(nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/todo", strings.NewReader(`{"title":"Title","priority":"high","activity_group_id":{"id":2}}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              7,
+		ActivityGroupID: domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"},
+		Title:           "Title",
+		Status:          domain.TodoStatusInProgress,
+		Priority:        3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+
+	num := int(mockTodo.ID)
 
 	mockUCase.On("Delete", mock.Anything, int64(num)).Return(nil)
 
 	e := echo.New()
-	req, err := http.NewRequest(echo.DELETE, "/activity/"+strconv.Itoa(num), strings.NewReader(""))
+	req, err := http.NewRequest(echo.DELETE, "/todo/"+strconv.Itoa(num), strings.NewReader(""))
 	assert.NoError(t, err)
 
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	c.SetPath("activity/:id")
+	c.SetPath("todo/:id")
 	c.SetParamNames("id")
 	c.SetParamValues(strconv.Itoa(num))
-	handler := activityHTTP.ArticleHandler{
+	handler := todoHTTP.TodoHandler{
 		AUsecase: mockUCase,
 	}
 	err = handler.Delete(c)
@@ -167,5 +614,431 @@ func TestDelete(t *testing.T) {
 
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 	mockUCase.AssertExpectations(t)
+}
+
+func TestComplete(t *testing.T) {
+	completedAt := time.Now()
+	mockTodo := domain.Todo{
+		ID:          12,
+		Title:       "Title",
+		Status:      domain.TodoStatusDone,
+		Priority:    3,
+		CompletedAt: &completedAt,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Complete", mock.Anything, int64(12)).Return(mockTodo, nil).Once()
+	mockUCase.On("Complete", mock.Anything, int64(13)).Return(domain.Todo{}, domain.ErrInvalidTransition).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	req, err := http.NewRequest(echo.POST, "/todo/12/complete", strings.NewReader(""))
+	assert.NoError(t, err)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/complete")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	err = handler.Complete(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"done"`)
+
+	req, err = http.NewRequest(echo.POST, "/todo/13/complete", strings.NewReader(""))
+	assert.NoError(t, err)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetPath("todo/:id/complete")
+	c.SetParamNames("id")
+	c.SetParamValues("13")
+	err = handler.Complete(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestReopen(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:       12,
+		Title:    "Title",
+		Status:   domain.TodoStatusTodo,
+		Priority: 3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Reopen", mock.Anything, int64(12)).Return(mockTodo, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/todo/12/reopen", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/reopen")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.Reopen(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"completed_at":null`)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestRestore(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Restore", mock.Anything, int64(7)).Return(domain.Todo{ID: 7, Title: "Title"}, nil).Once()
+	mockUCase.On("Restore", mock.Anything, int64(8)).Return(domain.Todo{}, domain.ErrUnknownActivity).Once()
+	mockUCase.On("Restore", mock.Anything, int64(9)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		id   string
+		code int
+	}{
+		{id: "7", code: http.StatusOK},
+		{id: "8", code: http.StatusUnprocessableEntity},
+		{id: "9", code: http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(echo.POST, "/todo/"+tt.id+"/restore", strings.NewReader(""))
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("todo/:id/restore")
+		c.SetParamNames("id")
+		c.SetParamValues(tt.id)
+		err = handler.Restore(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.id)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestMove(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              12,
+		Title:           "Title",
+		ActivityGroupID: domain.Activity{ID: 2},
+		Position:        "m",
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Move", mock.Anything, int64(12), domain.TodoMove{After: 10, Before: 11}).Return(mockTodo, nil).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	req, err := http.NewRequest(echo.POST, "/todo/12/move", strings.NewReader(`{"after":10,"before":11}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/move")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	err = handler.Move(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"position":"m"`)
+
+	req, err = http.NewRequest(echo.POST, "/todo/12/move", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetPath("todo/:id/move")
+	c.SetParamNames("id")
+	c.SetParamValues("12")
+	err = handler.Move(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCopy(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              31,
+		Title:           "Title (copy)",
+		ActivityGroupID: domain.Activity{ID: 5},
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Copy", mock.Anything, int64(12), domain.TodoCopy{ActivityGroupID: 5, ResetStatus: true}).Return(mockTodo, nil).Once()
+	mockUCase.On("Copy", mock.Anything, int64(12), domain.TodoCopy{ActivityGroupID: 6}).Return(domain.Todo{}, domain.ErrUnknownActivity).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		body string
+		code int
+	}{
+		{body: `{"activity_group_id":5,"reset_status":true}`, code: http.StatusCreated},
+		{body: `{"activity_group_id":6}`, code: http.StatusUnprocessableEntity},
+		{body: `{}`, code: http.StatusBadRequest},
+	} {
+		req, err := http.NewRequest(echo.POST, "/todo/12/copy", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("todo/:id/copy")
+		c.SetParamNames("id")
+		c.SetParamValues("12")
+		err = handler.Copy(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestMoveMany(t *testing.T) {
+	mockTodos := []domain.Todo{
+		{ID: 12, Title: "First", ActivityGroupID: domain.Activity{ID: 5}},
+		{ID: 13, Title: "Second", ActivityGroupID: domain.Activity{ID: 5}},
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("MoveMany", mock.Anything, []int64{12, 13}, int64(5)).Return(mockTodos, nil).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		body string
+		code int
+	}{
+		{body: `{"ids":[12,13],"activity_group_id":5}`, code: http.StatusOK},
+		{body: `{"ids":[],"activity_group_id":5}`, code: http.StatusBadRequest},
+		{body: `{"ids":[12,0],"activity_group_id":5}`, code: http.StatusBadRequest},
+		{body: `{"ids":[12]}`, code: http.StatusBadRequest},
+	} {
+		req, err := http.NewRequest(echo.POST, "/todo/move", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("todo/move")
+		err = handler.MoveMany(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestCopyMany(t *testing.T) {
+	mockTodos := []domain.Todo{
+		{ID: 31, Title: "First (copy)", ActivityGroupID: domain.Activity{ID: 5}},
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("CopyMany", mock.Anything, []int64{12}, domain.TodoCopy{ActivityGroupID: 5}).Return(mockTodos, nil).Once()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/todo/copy", strings.NewReader(`{"ids":[12],"activity_group_id":5}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/copy")
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+	err = handler.CopyMany(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"title":"First (copy)"`)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestBulk(t *testing.T) {
+	mockTodo := domain.Todo{ID: 12, Title: "First", ActivityGroupID: domain.Activity{ID: 5}}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Bulk", mock.Anything, mock.Anything, domain.BulkBestEffort).Return([]domain.TodoOperationResult{
+		{Todo: &mockTodo},
+		{Err: domain.ErrNotFound},
+	}, nil).Once()
+	mockUCase.On("Bulk", mock.Anything, mock.Anything, domain.BulkAllOrNothing).Return([]domain.TodoOperationResult{
+		{Err: domain.ErrBulkAborted},
+		{Err: domain.ErrConflict},
+	}, nil).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		body     string
+		code     int
+		contains string
+	}{
+		{
+			body:     `{"mode":"best_effort","operations":[{"op":"complete","id":12},{"op":"delete","id":13}]}`,
+			code:     http.StatusMultiStatus,
+			contains: `{"index":1,"status":404,"error":"your requested Item is not found"}`,
+		},
+		{
+			body:     `{"operations":[{"op":"complete","id":12},{"op":"create","todo":{"title":"First","priority":"high","activity_group_id":{"id":5,"email":"bagus@gmail.com","title":"Work"}}}]}`,
+			code:     http.StatusConflict,
+			contains: `{"index":0,"status":424,"error":"operation aborted, another operation of the bulk failed"}`,
+		},
+		{body: `{"operations":[]}`, code: http.StatusBadRequest},
+		{body: `{"mode":"eventually","operations":[{"op":"delete","id":12}]}`, code: http.StatusBadRequest},
+		{body: `{"operations":[{"op":"archive","id":12}]}`, code: http.StatusBadRequest, contains: `"operations[0].op"`},
+		{body: `{"operations":[{"op":"create","todo":{"priority":"high"}}]}`, code: http.StatusBadRequest, contains: `"operations[0].todo.title"`},
+	} {
+		req, err := http.NewRequest(echo.POST, "/todo/bulk", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("todo/bulk")
+		err = handler.Bulk(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+		assert.Contains(t, rec.Body.String(), tt.contains)
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestBulkRecurrence(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("Bulk", mock.Anything, mock.MatchedBy(func(ops []domain.TodoOperation) bool {
+		return len(ops) == 2 && ops[0].Todo.TimeZone == "Europe/Berlin" && ops[1].Todo.TimeZone == "Asia/Tokyo"
+	}), domain.BulkAllOrNothing).Return([]domain.TodoOperationResult{{}, {}}, nil).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	activity := `"activity_group_id":{"id":5,"email":"bagus@gmail.com","title":"Work"}`
+	req, err := http.NewRequest(echo.POST, "/todo/bulk", strings.NewReader(`{"operations":[`+
+		`{"op":"create","todo":{"title":"Chore","priority":"normal","recurrence":"FREQ=WEEKLY",`+activity+`}},`+
+		`{"op":"create","todo":{"title":"Call","priority":"normal","recurrence":"FREQ=DAILY","time_zone":"Asia/Tokyo",`+activity+`}}]}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Timezone", "Europe/Berlin")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/bulk")
+	err = handler.Bulk(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestGetByIDRenderHTML(t *testing.T) {
+	mockTodo := domain.Todo{
+		ID:              7,
+		ActivityGroupID: domain.Activity{ID: 2},
+		Title:           "Title",
+		Notes:           "Ask for **the** invoice <script>",
+		Priority:        3,
+	}
+
+	mockUCase := new(mocks.TodoUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		render string
+		code   int
+	}{
+		{render: "html", code: http.StatusOK},
+		{render: "pdf", code: http.StatusBadRequest},
+	} {
+		req, err := http.NewRequest(echo.GET, "/todo/7?render="+tt.render, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("todo/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+		err = handler.GetByID(c)
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, rec.Code, tt.render)
+
+		if tt.code == http.StatusOK {
+			var res domain.Todo
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, mockTodo.Notes, res.Notes)
+			assert.Equal(t, "<p>Ask for <strong>the</strong> invoice <!-- raw HTML omitted --></p>\n", res.NotesHTML)
+		}
+	}
+
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreTooLong(t *testing.T) {
+	mockUCase := new(mocks.TodoUsecaseMock)
+
+	e := echo.New()
+	handler := todoHTTP.TodoHandler{
+		AUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		todo  domain.Todo
+		field string
+	}{
+		{todo: domain.Todo{Title: strings.Repeat("é", 46)}, field: "title"},
+		{todo: domain.Todo{Title: "Title", Notes: strings.Repeat("a", 10001)}, field: "notes"},
+		{todo: domain.Todo{Title: "Title", Tags: []string{"home", strings.Repeat("é", 46)}}, field: "tags[1]"},
+	} {
+		tt.todo.ActivityGroupID = domain.Activity{ID: 2, Email: "bagus@gmail.com", Title: "Work"}
+		tt.todo.Priority = 3
+		j, err := json.Marshal(tt.todo)
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(echo.POST, "/todo", strings.NewReader(string(j)))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/todo")
+		err = handler.Store(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var res todoHTTP.ResponseError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Contains(t, res.Errors, tt.field)
+	}
 
+	mockUCase.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
 }
@@ -1,30 +1,162 @@
 package repository
 
 import (
-	"encoding/base64"
-	"time"
-)
+	"net/url"
+	"strconv"
+	"strings"
 
-const (
-	timeFormat = "2006-01-02T15:04:05.999Z07:00" // reduce precision from RFC3339Nano as date format
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
 )
 
-// DecodeCursor will decode cursor from user for mysql
-func DecodeCursor(encodedTime string) (time.Time, error) {
-	byt, err := base64.StdEncoding.DecodeString(encodedTime)
-	if err != nil {
-		return time.Time{}, err
+// FilterKey will build the canonical representation of the filter to be carried by the cursor
+func FilterKey(filter domain.TodoFilter) string {
+	val := url.Values{}
+	if filter.ActivityGroupID != 0 {
+		val.Add("activity_group_id", strconv.FormatInt(filter.ActivityGroupID, 10))
+	}
+	for _, status := range filter.Status {
+		val.Add("status", string(status))
+	}
+	if filter.Priority != nil {
+		val.Add("priority", strconv.Itoa(int(*filter.Priority)))
+	}
+	if filter.DueAfter != nil {
+		val.Add("due_after", pagination.EncodeTime(filter.DueAfter.UTC()))
+	}
+	if filter.DueBefore != nil {
+		val.Add("due_before", pagination.EncodeTime(filter.DueBefore.UTC()))
+	}
+	if filter.Overdue {
+		val.Add("overdue", "true")
+	}
+	for _, tag := range filter.Tags {
+		val.Add("tag", tag)
+	}
+	if len(filter.Tags) > 0 && filter.TagMatch == domain.TagMatchAll {
+		val.Add("tag_match", string(domain.TagMatchAll))
+	}
+
+	return val.Encode()
+}
+
+// SortKey describe how a whitelisted sort field is stored and carried by the cursor
+type SortKey struct {
+	Column string
+	Value  func(t domain.Todo) string
+	Parse  func(value string) (interface{}, error)
+}
+
+func parseInt(value string) (interface{}, error) {
+	return strconv.Atoi(value)
+}
+
+func parseString(value string) (interface{}, error) {
+	return value, nil
+}
+
+func parseTime(value string) (interface{}, error) {
+	return pagination.DecodeTime(value)
+}
+
+// SortKeys map every field of domain.TodoSortFields to its column and cursor value
+var SortKeys = map[string]SortKey{
+	"priority": {
+		Column: "priority",
+		Value:  func(t domain.Todo) string { return strconv.Itoa(int(t.Priority)) },
+		Parse:  parseInt,
+	},
+	"title": {
+		Column: "title",
+		Value:  func(t domain.Todo) string { return t.Title },
+		Parse:  parseString,
+	},
+	"position": {
+		Column: "position",
+		Value:  func(t domain.Todo) string { return t.Position },
+		Parse:  parseString,
+	},
+	"created_at": {
+		Column: "created_at",
+		Value:  func(t domain.Todo) string { return pagination.EncodeTime(t.CreatedAt) },
+		Parse:  parseTime,
+	},
+	"updated_at": {
+		Column: "updated_at",
+		Value:  func(t domain.Todo) string { return pagination.EncodeTime(t.UpdatedAt) },
+		Parse:  parseTime,
+	},
+}
+
+// DefaultSort is used when the listing is requested without any order
+var DefaultSort = domain.TodoSort{{Field: "created_at"}}
+
+// NormalizeSort will validate the sort against the whitelist and fall back to the default order
+func NormalizeSort(sort domain.TodoSort) (domain.TodoSort, error) {
+	if len(sort) == 0 {
+		return DefaultSort, nil
+	}
+
+	seen := map[string]bool{}
+	for _, field := range sort {
+		if _, ok := SortKeys[field.Field]; !ok || seen[field.Field] {
+			return nil, domain.ErrBadParamInput
+		}
+		seen[field.Field] = true
+	}
+	return sort, nil
+}
+
+// SortString will build the canonical representation of the sort to be carried by the cursor,
+// the id follows the direction of the last field as it only breaks the tie
+func SortString(sort domain.TodoSort) string {
+	fields := make([]string, 0, len(sort)+1)
+	for _, field := range sort {
+		fields = append(fields, sortField(field.Field, field.Descending))
 	}
+	fields = append(fields, sortField("id", sort[len(sort)-1].Descending))
 
-	timeString := string(byt)
-	t, err := time.Parse(timeFormat, timeString)
+	return strings.Join(fields, ",")
+}
 
-	return t, err
+func sortField(field string, descending bool) string {
+	if descending {
+		return "-" + field
+	}
+	return field
 }
 
-// EncodeCursor will encode cursor from mysql to user
-func EncodeCursor(t time.Time) string {
-	timeString := t.Format(timeFormat)
+// CursorOf will encode the cursor pointing at the given todo of the listing with the given filter and sort
+func CursorOf(t domain.Todo, direction string, filter domain.TodoFilter, sort domain.TodoSort) string {
+	values := make([]string, 0, len(sort))
+	for _, field := range sort {
+		values = append(values, SortKeys[field.Field].Value(t))
+	}
 
-	return base64.StdEncoding.EncodeToString([]byte(timeString))
+	return pagination.EncodeCursor(pagination.Cursor{
+		Direction: direction,
+		Values:    values,
+		ID:        t.ID,
+		Filter:    FilterKey(filter),
+		Sort:      SortString(sort),
+	})
+}
+
+// ParseCursor will decode the cursor of the listing with the given filter and sort along with the sort keys values
+// of its row, in the order of the sort. The cursor of another listing is rejected
+func ParseCursor(cursor string, filter domain.TodoFilter, sort domain.TodoSort) (c pagination.Cursor, values []interface{}, err error) {
+	c, err = pagination.DecodeCursor(cursor)
+	if err != nil || c.Filter != FilterKey(filter) || c.Sort != SortString(sort) || len(c.Values) != len(sort) {
+		return pagination.Cursor{}, nil, domain.ErrBadParamInput
+	}
+
+	values = make([]interface{}, 0, len(sort))
+	for i, field := range sort {
+		value, err := SortKeys[field.Field].Parse(c.Values[i])
+		if err != nil {
+			return pagination.Cursor{}, nil, domain.ErrBadParamInput
+		}
+		values = append(values, value)
+	}
+	return
 }
@@ -0,0 +1,528 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+	"github.com/bxcodec/go-clean-arch/todo/repository"
+)
+
+type memoryTodoRepository struct {
+	DB *memdb.DB
+}
+
+// NewMemoryTodoRepository will create an object that represent the domain.TodoRepository interface,
+// the todos are kept in the given in-memory database
+func NewMemoryTodoRepository(db *memdb.DB) domain.TodoRepository {
+	return &memoryTodoRepository{db}
+}
+
+// storedTodo will give the row of the todo, only the columns of the todo table are kept
+func storedTodo(t domain.Todo) domain.Todo {
+	return domain.Todo{
+		ID:              t.ID,
+		ActivityGroupID: domain.Activity{ID: t.ActivityGroupID.ID},
+		Title:           t.Title,
+		Notes:           t.Notes,
+		Status:          t.Status,
+		Priority:        t.Priority,
+		DueAt:           memdb.TimePtr(t.DueAt),
+		Recurrence:      t.Recurrence,
+		TimeZone:        t.TimeZone,
+		CompletedAt:     memdb.TimePtr(t.CompletedAt),
+		Position:        t.Position,
+		UpdatedAt:       memdb.Time(t.UpdatedAt),
+		CreatedAt:       memdb.Time(t.CreatedAt),
+		DeletedAt:       t.DeletedAt,
+	}
+}
+
+// withTags will give the todo along with the names of its tags in alphabetical order
+func withTags(tables *memdb.Tables, t domain.Todo) domain.Todo {
+	t.Tags = nil
+	for tagID := range tables.TodoTags[t.ID] {
+		t.Tags = append(t.Tags, tables.Tags[tagID].Name)
+	}
+	sort.Strings(t.Tags)
+	return t
+}
+
+// tagID will give the id of the tag of the given name, the tags are matched regardless of case like the name column does
+func tagID(tables *memdb.Tables, name string) (int64, bool) {
+	for id, tag := range tables.Tags {
+		if strings.EqualFold(tag.Name, name) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// setTags will replace the tags of the todo, the tags not known yet are created
+func setTags(tables *memdb.Tables, t *domain.Todo) {
+	links := make(map[int64]bool, len(t.Tags))
+	for _, name := range t.Tags {
+		id, ok := tagID(tables, name)
+		if !ok {
+			id = tables.NextID("tag")
+			tables.Tags[id] = domain.Tag{ID: id, Name: name, UpdatedAt: memdb.Time(t.UpdatedAt), CreatedAt: memdb.Time(t.UpdatedAt)}
+		}
+		links[id] = true
+	}
+
+	if len(links) == 0 {
+		delete(tables.TodoTags, t.ID)
+		return
+	}
+	tables.TodoTags[t.ID] = links
+}
+
+// checkActivityGroup will refuse an activity group that is not stored, like the foreign key of the todo table does
+func checkActivityGroup(tables *memdb.Tables, t *domain.Todo) error {
+	if t.ActivityGroupID.ID == 0 {
+		return nil
+	}
+	if _, ok := tables.Activities[t.ActivityGroupID.ID]; !ok {
+		return domain.ErrUnknownActivity
+	}
+	return nil
+}
+
+// inGroup will tell whether the todo belongs to the activity group, no todo matches the zero id like no column equals NULL
+func inGroup(t domain.Todo, activityGroupID int64) bool {
+	return activityGroupID != 0 && t.ActivityGroupID.ID == activityGroupID
+}
+
+func containsStatus(statuses []domain.TodoStatus, status domain.TodoStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTags will tell whether the todo carries any of the tags, or every one of them when the filter asks so
+func matchTags(tables *memdb.Tables, t domain.Todo, filter domain.TodoFilter) bool {
+	matched := map[int64]bool{}
+	for _, name := range filter.Tags {
+		if id, ok := tagID(tables, name); ok && tables.TodoTags[t.ID][id] {
+			matched[id] = true
+		}
+	}
+
+	if filter.TagMatch == domain.TagMatchAll {
+		return len(matched) == len(filter.Tags)
+	}
+	return len(matched) > 0
+}
+
+// matchFilter will tell whether the todo meets the filter, the overdue todos are the ones still open past the given time
+// and the todos in the trash are never listed
+func matchFilter(tables *memdb.Tables, t domain.Todo, filter domain.TodoFilter, now time.Time) bool {
+	if t.DeletedAt != nil {
+		return false
+	}
+	if filter.ActivityGroupID != 0 && t.ActivityGroupID.ID != filter.ActivityGroupID {
+		return false
+	}
+	if len(filter.Status) > 0 && !containsStatus(filter.Status, t.Status) {
+		return false
+	}
+	if filter.Priority != nil && t.Priority != *filter.Priority {
+		return false
+	}
+	if filter.DueAfter != nil && (t.DueAt == nil || t.DueAt.Before(*filter.DueAfter)) {
+		return false
+	}
+	if filter.DueBefore != nil && (t.DueAt == nil || !t.DueAt.Before(*filter.DueBefore)) {
+		return false
+	}
+	if filter.Overdue && (t.DueAt == nil || !t.DueAt.Before(now) || !containsStatus(domain.ActiveTodoStatuses, t.Status)) {
+		return false
+	}
+	if len(filter.Tags) > 0 && !matchTags(tables, t, filter) {
+		return false
+	}
+	return true
+}
+
+// sortValues will give the values of the sort keys of the todo, as carried by its cursor
+func sortValues(t domain.Todo, sort domain.TodoSort) []interface{} {
+	values := make([]interface{}, 0, len(sort))
+	for _, field := range sort {
+		key := repository.SortKeys[field.Field]
+		value, _ := key.Parse(key.Value(t))
+		values = append(values, value)
+	}
+	return values
+}
+
+// compareValue will compare two values of the sort key, the titles compare regardless of case
+// like the collation of the title column does
+func compareValue(field string, a, b interface{}) int {
+	switch a := a.(type) {
+	case int:
+		b := b.(int)
+		if a == b {
+			return 0
+		}
+		if a < b {
+			return -1
+		}
+		return 1
+	case time.Time:
+		b := b.(time.Time)
+		if a.Equal(b) {
+			return 0
+		}
+		if a.Before(b) {
+			return -1
+		}
+		return 1
+	default:
+		left, right := a.(string), b.(string)
+		if field == "title" {
+			left, right = strings.ToLower(left), strings.ToLower(right)
+		}
+		return strings.Compare(left, right)
+	}
+}
+
+// compareRows will compare the positions of two rows in the listing order, the id breaks the tie
+// and follows the direction of the last field
+func compareRows(sort domain.TodoSort, a []interface{}, aID int64, b []interface{}, bID int64) int {
+	for i, field := range sort {
+		if c := compareValue(field.Field, a[i], b[i]); c != 0 {
+			if field.Descending {
+				return -c
+			}
+			return c
+		}
+	}
+
+	c := 0
+	if aID < bID {
+		c = -1
+	} else if aID > bID {
+		c = 1
+	}
+	if sort[len(sort)-1].Descending {
+		return -c
+	}
+	return c
+}
+
+type listedTodo struct {
+	todo   domain.Todo
+	values []interface{}
+}
+
+func (m *memoryTodoRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) (res []domain.Todo, nextCursor string, prevCursor string, err error) {
+	sort, err = repository.NormalizeSort(sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	direction := pagination.DirectionNext
+	var boundary []interface{}
+	var boundaryID int64
+	if cursor != "" {
+		c, values, err := repository.ParseCursor(cursor, filter, sort)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		direction = c.Direction
+		boundary, boundaryID = values, c.ID
+	}
+
+	now := filter.Now
+	listed := make([]listedTodo, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, todo := range t.Todos {
+			if !matchFilter(t, todo, filter, now) {
+				continue
+			}
+
+			values := sortValues(todo, sort)
+			if cursor != "" {
+				c := compareRows(sort, values, todo.ID, boundary, boundaryID)
+				if (direction == pagination.DirectionNext && c <= 0) || (direction == pagination.DirectionPrev && c >= 0) {
+					continue
+				}
+			}
+			listed = append(listed, listedTodo{todo: withTags(t, todo), values: values})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// the rows of the previous page are taken backward from the cursor like the reversed order of the query does
+	sortListed(listed, sort, direction == pagination.DirectionPrev)
+
+	res = make([]domain.Todo, 0, len(listed))
+	for _, l := range listed {
+		res = append(res, l.todo)
+	}
+	res, nextCursor, prevCursor = repository.Page(res, num, cursor, direction, filter, sort)
+	return
+}
+
+func sortListed(listed []listedTodo, order domain.TodoSort, reversed bool) {
+	sort.Slice(listed, func(i, j int) bool {
+		c := compareRows(order, listed[i].values, listed[i].todo.ID, listed[j].values, listed[j].todo.ID)
+		if reversed {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// getOne will give the todo with the lowest id among the ones matching, in or out of the trash as asked
+func (m *memoryTodoRepository) getOne(ctx context.Context, deleted bool, match func(t domain.Todo) bool) (res domain.Todo, err error) {
+	found := false
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, todo := range t.Todos {
+			if (todo.DeletedAt != nil) != deleted || !match(todo) {
+				continue
+			}
+			if !found || todo.ID < res.ID {
+				res = todo
+				found = true
+			}
+		}
+		if found {
+			res = withTags(t, res)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if !found {
+		return domain.Todo{}, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *memoryTodoRepository) GetByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	return m.getOne(ctx, false, func(t domain.Todo) bool {
+		return t.ID == id
+	})
+}
+
+// GetByTitle will match the title regardless of case, like the collation of the title column does
+func (m *memoryTodoRepository) GetByTitle(ctx context.Context, title string) (res domain.Todo, err error) {
+	return m.getOne(ctx, false, func(t domain.Todo) bool {
+		return strings.EqualFold(t.Title, title)
+	})
+}
+
+func (m *memoryTodoRepository) Store(ctx context.Context, a *domain.Todo) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		if err := checkActivityGroup(t, a); err != nil {
+			return err
+		}
+
+		row := storedTodo(*a)
+		row.ID = t.NextID("todo")
+		row.DeletedAt = nil
+		t.Todos[row.ID] = row
+		a.ID = row.ID
+		setTags(t, a)
+		return nil
+	})
+}
+
+// update will write the change to the todo out of the trash, a missing one is reported the way the mysql repository does
+func (m *memoryTodoRepository) update(ctx context.Context, id int64, change func(t *memdb.Tables, todo *domain.Todo) error) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		todo, ok := t.Todos[id]
+		if !ok || todo.DeletedAt != nil {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		if err := change(t, &todo); err != nil {
+			return err
+		}
+		t.Todos[id] = todo
+		return nil
+	})
+}
+
+func (m *memoryTodoRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	return m.update(ctx, id, func(t *memdb.Tables, todo *domain.Todo) error {
+		todo.DeletedAt = memdb.TimePtr(&deletedAt)
+		return nil
+	})
+}
+
+func (m *memoryTodoRepository) Update(ctx context.Context, ar *domain.Todo) (err error) {
+	return m.update(ctx, ar.ID, func(t *memdb.Tables, todo *domain.Todo) error {
+		if err := checkActivityGroup(t, ar); err != nil {
+			return err
+		}
+
+		row := storedTodo(*ar)
+		row.CreatedAt = todo.CreatedAt
+		row.DeletedAt = todo.DeletedAt
+		*todo = row
+		setTags(t, ar)
+		return nil
+	})
+}
+
+// updateGroup will write the change to every todo of the activity group matching the condition
+func (m *memoryTodoRepository) updateGroup(ctx context.Context, activityGroupID int64, match func(todo domain.Todo) bool, change func(todo *domain.Todo)) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for id, todo := range t.Todos {
+			if inGroup(todo, activityGroupID) && match(todo) {
+				change(&todo)
+				t.Todos[id] = todo
+			}
+		}
+		return nil
+	})
+}
+
+func (m *memoryTodoRepository) DeleteByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	return m.updateGroup(ctx, activityGroupID, func(todo domain.Todo) bool {
+		return todo.DeletedAt == nil
+	}, func(todo *domain.Todo) {
+		todo.DeletedAt = memdb.TimePtr(&deletedAt)
+	})
+}
+
+func (m *memoryTodoRepository) DetachActivityGroup(ctx context.Context, activityGroupID int64) (err error) {
+	return m.updateGroup(ctx, activityGroupID, func(todo domain.Todo) bool {
+		return todo.DeletedAt == nil
+	}, func(todo *domain.Todo) {
+		todo.ActivityGroupID = domain.Activity{}
+	})
+}
+
+func (m *memoryTodoRepository) LastPosition(ctx context.Context, activityGroupID int64) (position string, err error) {
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, todo := range t.Todos {
+			if inGroup(todo, activityGroupID) && todo.DeletedAt == nil && todo.Position > position {
+				position = todo.Position
+			}
+		}
+		return nil
+	})
+	return
+}
+
+func (m *memoryTodoRepository) NeighborPosition(ctx context.Context, activityGroupID int64, position string, next bool) (neighbor string, err error) {
+	found := false
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, todo := range t.Todos {
+			if !inGroup(todo, activityGroupID) || todo.DeletedAt != nil {
+				continue
+			}
+
+			if next && todo.Position > position && (!found || todo.Position < neighbor) ||
+				!next && todo.Position < position && (!found || todo.Position > neighbor) {
+				neighbor = todo.Position
+				found = true
+			}
+		}
+		return nil
+	})
+	return
+}
+
+func (m *memoryTodoRepository) UpdatePosition(ctx context.Context, ar *domain.Todo) (err error) {
+	return m.update(ctx, ar.ID, func(t *memdb.Tables, todo *domain.Todo) error {
+		if err := checkActivityGroup(t, ar); err != nil {
+			return err
+		}
+
+		todo.ActivityGroupID = domain.Activity{ID: ar.ActivityGroupID.ID}
+		todo.Position = ar.Position
+		todo.UpdatedAt = memdb.Time(ar.UpdatedAt)
+		return nil
+	})
+}
+
+func (m *memoryTodoRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	return m.getOne(ctx, true, func(t domain.Todo) bool {
+		return t.ID == id
+	})
+}
+
+func (m *memoryTodoRepository) FetchDeleted(ctx context.Context) (res []domain.Todo, err error) {
+	res = make([]domain.Todo, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, todo := range t.Todos {
+			if todo.DeletedAt != nil {
+				res = append(res, withTags(t, todo))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].DeletedAt.Equal(*res[j].DeletedAt) {
+			return res[i].DeletedAt.After(*res[j].DeletedAt)
+		}
+		return res[i].ID > res[j].ID
+	})
+	return
+}
+
+func (m *memoryTodoRepository) Restore(ctx context.Context, id int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		todo, ok := t.Todos[id]
+		if !ok || todo.DeletedAt == nil {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		todo.DeletedAt = nil
+		t.Todos[id] = todo
+		return nil
+	})
+}
+
+func (m *memoryTodoRepository) RestoreByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	deletedAt = memdb.Time(deletedAt)
+	return m.updateGroup(ctx, activityGroupID, func(todo domain.Todo) bool {
+		return todo.DeletedAt != nil && todo.DeletedAt.Equal(deletedAt)
+	}, func(todo *domain.Todo) {
+		todo.DeletedAt = nil
+	})
+}
+
+// Purge will delete the items and the tag links of the purged todos along with them, like the foreign keys do
+func (m *memoryTodoRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for id, todo := range t.Todos {
+			if todo.DeletedAt == nil || !todo.DeletedAt.Before(before) {
+				continue
+			}
+
+			delete(t.Todos, id)
+			delete(t.TodoTags, id)
+			for itemID, item := range t.TodoItems {
+				if item.TodoID == id {
+					delete(t.TodoItems, itemID)
+				}
+			}
+			purged++
+		}
+		return nil
+	})
+	return
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/bxcodec/go-clean-arch/activity/repository"
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+	"github.com/bxcodec/go-clean-arch/todo/repository"
 )
 
 type mysqlTodoRepository struct {
@@ -21,7 +24,7 @@ func NewMysqlTodoRepository(Conn *sql.DB) domain.TodoRepository {
 }
 
 func (m *mysqlTodoRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Todo, err error) {
-	rows, err := m.Conn.QueryContext(ctx, query, args...)
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
 	if err != nil {
 		logrus.Error(err)
 		return nil, err
@@ -37,13 +40,22 @@ func (m *mysqlTodoRepository) fetch(ctx context.Context, query string, args ...i
 	result = make([]domain.Todo, 0)
 	for rows.Next() {
 		t := domain.Todo{}
-		activityID := int64(0)
+		activityID := sql.NullInt64{}
 		err = rows.Scan(
 			&t.ID,
 			&activityID,
 			&t.Title,
-			&t.IsActive,
+			&t.Notes,
+			&t.Status,
 			&t.Priority,
+			&t.DueAt,
+			&t.Recurrence,
+			&t.TimeZone,
+			&t.CompletedAt,
+			&t.Position,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.DeletedAt,
 		)
 
 		if err != nil {
@@ -51,7 +63,7 @@ func (m *mysqlTodoRepository) fetch(ctx context.Context, query string, args ...i
 			return nil, err
 		}
 		t.ActivityGroupID = domain.Activity{
-			ID: activityID,
+			ID: activityID.Int64,
 		}
 		result = append(result, t)
 	}
@@ -59,69 +71,164 @@ func (m *mysqlTodoRepository) fetch(ctx context.Context, query string, args ...i
 	return result, nil
 }
 
-func (m *mysqlTodoRepository) Fetch(ctx context.Context, cursor string, num int64) (res []domain.Todo, nextCursor string, err error) {
-	query := `SELECT id, activity_group_id, title, is_active, priority
-  						FROM todo WHERE created_at > ? ORDER BY created_at LIMIT ? `
+// fetchTags will load the tags of the given todos in a single query
+func (m *mysqlTodoRepository) fetchTags(ctx context.Context, todos []domain.Todo) (err error) {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(todos))
+	for i, t := range todos {
+		index[t.ID] = i
+	}
+
+	query, args := repository.TagsQuery(dialect{}, todos)
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		var todoID int64
+		var name string
+		err = rows.Scan(&todoID, &name)
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+		if i, ok := index[todoID]; ok {
+			todos[i].Tags = append(todos[i].Tags, name)
+		}
+	}
+	return rows.Err()
+}
+
+// setTags will replace the tags of the todo, the tags not known yet are created
+func (m *mysqlTodoRepository) setTags(ctx context.Context, t *domain.Todo, replace bool) (err error) {
+	conn := transaction.Conn(ctx, m.Conn)
+	if replace {
+		_, err = conn.ExecContext(ctx, "DELETE FROM todo_tag WHERE todo_id = ?", t.ID)
+		if err != nil {
+			return
+		}
+	}
+	if len(t.Tags) == 0 {
+		return
+	}
+
+	args := repository.NewArgs(dialect{})
+	values := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		values = append(values, "("+args.AddAll(tag, t.UpdatedAt, t.UpdatedAt)+")")
+	}
+	// the known tags are left as they are, unlike INSERT IGNORE any other failure is still reported
+	_, err = conn.ExecContext(ctx, "INSERT INTO tag (name, updated_at, created_at) VALUES "+strings.Join(values, ", ")+" ON DUPLICATE KEY UPDATE id = id", args.Values...)
+	if err != nil {
+		return
+	}
+
+	names := repository.NewArgs(dialect{})
+	query := "INSERT INTO todo_tag (todo_id, tag_id) SELECT " + names.Add(t.ID) + ", id FROM tag WHERE name IN (" + names.AddStrings(t.Tags) + ")"
+	_, err = conn.ExecContext(ctx, query, names.Values...)
+	return
+}
 
-	decodedCursor, err := repository.DecodeCursor(cursor)
-	if err != nil && cursor != "" {
-		return nil, "", domain.ErrBadParamInput
+// nullableID will store the todo without activity group as NULL
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
 	}
+	return id
+}
+
+// dialect writes the arguments of the queries with the ? placeholder of MySQL
+type dialect struct{}
+
+func (dialect) Placeholder(n int) string {
+	return "?"
+}
 
-	res, err = m.fetch(ctx, query, decodedCursor, num)
+func (dialect) Value(value interface{}) interface{} {
+	return value
+}
+
+func (m *mysqlTodoRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) (res []domain.Todo, nextCursor string, prevCursor string, err error) {
+	sort, err = repository.NormalizeSort(sort)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
-	if len(res) == int(num) {
-		nextCursor = repository.EncodeCursor(res[len(res)-1].CreatedAt)
+	query, args, direction, err := repository.ListQuery(dialect{}, cursor, num, filter, sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+	res, err = m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, "", "", err
 	}
 
+	res, nextCursor, prevCursor = repository.Page(res, num, cursor, direction, filter, sort)
+	if err = m.fetchTags(ctx, res); err != nil {
+		return nil, "", "", err
+	}
 	return
 }
+
 func (m *mysqlTodoRepository) GetByID(ctx context.Context, id int64) (res domain.Todo, err error) {
-	query := `SELECT id, activity_group_id, title, is_active, priority
-  						FROM todo WHERE ID = ?`
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE ID = ? AND deleted_at IS NULL`
 
 	list, err := m.fetch(ctx, query, id)
 	if err != nil {
 		return domain.Todo{}, err
 	}
 
-	if len(list) > 0 {
-		res = list[0]
-	} else {
+	if len(list) == 0 {
 		return res, domain.ErrNotFound
 	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
 
 	return
 }
 
 func (m *mysqlTodoRepository) GetByTitle(ctx context.Context, title string) (res domain.Todo, err error) {
-	query := `SELECT id, activity_group_id, title, is_active, priority
-  						FROM todo WHERE title = ?`
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE title = ? AND deleted_at IS NULL`
 
 	list, err := m.fetch(ctx, query, title)
 	if err != nil {
 		return
 	}
 
-	if len(list) > 0 {
-		res = list[0]
-	} else {
+	if len(list) == 0 {
 		return res, domain.ErrNotFound
 	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
 	return
 }
 
 func (m *mysqlTodoRepository) Store(ctx context.Context, a *domain.Todo) (err error) {
-	query := `INSERT todo SET activity_group_id=?, title=?, is_active=?, priority=?, updated_at=?, created_at=?`
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	query := `INSERT todo SET activity_group_id=?, title=?, notes=?, status=?, priority=?, due_at=?, recurrence=?, time_zone=?, completed_at=?, position=?, updated_at=?, created_at=?`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, a.ActivityGroupID, a.Title, a.IsActive, a.Priority, a.UpdatedAt, a.CreatedAt)
+	res, err := stmt.ExecContext(ctx, nullableID(a.ActivityGroupID.ID), a.Title, a.Notes, string(a.Status), int(a.Priority), a.DueAt, a.Recurrence, a.TimeZone, a.CompletedAt, a.Position, a.UpdatedAt, a.CreatedAt)
 	if err != nil {
 		return
 	}
@@ -130,18 +237,18 @@ func (m *mysqlTodoRepository) Store(ctx context.Context, a *domain.Todo) (err er
 		return
 	}
 	a.ID = lastID
-	return
+	return m.setTags(ctx, a, false)
 }
 
-func (m *mysqlTodoRepository) Delete(ctx context.Context, id int64) (err error) {
-	query := "DELETE FROM todo WHERE id = ?"
+func (m *mysqlTodoRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE todo set deleted_at=? WHERE ID = ? AND deleted_at IS NULL"
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	res, err := stmt.ExecContext(ctx, deletedAt, id)
 	if err != nil {
 		return
 	}
@@ -159,14 +266,140 @@ func (m *mysqlTodoRepository) Delete(ctx context.Context, id int64) (err error)
 	return
 }
 func (m *mysqlTodoRepository) Update(ctx context.Context, ar *domain.Todo) (err error) {
-	query := `UPDATE activity set email=?, title=?, updated_at=? WHERE ID = ?`
+	query := `UPDATE todo set activity_group_id=?, title=?, notes=?, status=?, priority=?, due_at=?, recurrence=?, time_zone=?, completed_at=?, position=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(ar.ActivityGroupID.ID), ar.Title, ar.Notes, string(ar.Status), int(ar.Priority), ar.DueAt, ar.Recurrence, ar.TimeZone, ar.CompletedAt, ar.Position, ar.UpdatedAt, ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return m.setTags(ctx, ar, true)
+}
+
+func (m *mysqlTodoRepository) DeleteByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	query := "UPDATE todo set deleted_at=? WHERE activity_group_id = ? AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, deletedAt, activityGroupID)
+	return
+}
+
+func (m *mysqlTodoRepository) DetachActivityGroup(ctx context.Context, activityGroupID int64) (err error) {
+	query := `UPDATE todo set activity_group_id=NULL WHERE activity_group_id = ? AND deleted_at IS NULL`
 
-	stmt, err := m.Conn.PrepareContext(ctx, query)
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
 	if err != nil {
 		return
 	}
 
-	res, err := stmt.ExecContext(ctx, ar.ActivityGroupID, ar.Title, ar.IsActive, ar.Priority, ar.UpdatedAt, ar.ID)
+	_, err = stmt.ExecContext(ctx, activityGroupID)
+	return
+}
+
+func (m *mysqlTodoRepository) LastPosition(ctx context.Context, activityGroupID int64) (position string, err error) {
+	query := `SELECT COALESCE(MAX(position), '') FROM todo WHERE activity_group_id = ? AND deleted_at IS NULL`
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, activityGroupID).Scan(&position)
+	return
+}
+
+func (m *mysqlTodoRepository) NeighborPosition(ctx context.Context, activityGroupID int64, position string, next bool) (neighbor string, err error) {
+	query := `SELECT position FROM todo WHERE activity_group_id = ? AND position < ? AND deleted_at IS NULL ORDER BY position DESC LIMIT 1`
+	if next {
+		query = `SELECT position FROM todo WHERE activity_group_id = ? AND position > ? AND deleted_at IS NULL ORDER BY position ASC LIMIT 1`
+	}
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, activityGroupID, position).Scan(&neighbor)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return
+}
+
+func (m *mysqlTodoRepository) UpdatePosition(ctx context.Context, ar *domain.Todo) (err error) {
+	query := `UPDATE todo set activity_group_id=?, position=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(ar.ActivityGroupID.ID), ar.Position, ar.UpdatedAt, ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *mysqlTodoRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+
+	return
+}
+
+func (m *mysqlTodoRepository) FetchDeleted(ctx context.Context) (res []domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	res, err = m.fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.fetchTags(ctx, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (m *mysqlTodoRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := `UPDATE todo set deleted_at=NULL WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
 		return
 	}
@@ -181,3 +414,30 @@ func (m *mysqlTodoRepository) Update(ctx context.Context, ar *domain.Todo) (err
 
 	return
 }
+
+func (m *mysqlTodoRepository) RestoreByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	query := `UPDATE todo set deleted_at=NULL WHERE activity_group_id = ? AND deleted_at = ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, activityGroupID, deletedAt)
+	return
+}
+
+func (m *mysqlTodoRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	query := `DELETE FROM todo WHERE deleted_at < ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,450 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/postgresdb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+	"github.com/bxcodec/go-clean-arch/todo/repository"
+)
+
+type postgresTodoRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresTodoRepository will create an object that represent the domain.TodoRepository interface
+func NewPostgresTodoRepository(Conn *sql.DB) domain.TodoRepository {
+	return &postgresTodoRepository{Conn}
+}
+
+func (m *postgresTodoRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Todo, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Todo, 0)
+	for rows.Next() {
+		t := domain.Todo{}
+		activityID := sql.NullInt64{}
+		err = rows.Scan(
+			&t.ID,
+			&activityID,
+			&t.Title,
+			&t.Notes,
+			&t.Status,
+			&t.Priority,
+			&t.DueAt,
+			&t.Recurrence,
+			&t.TimeZone,
+			&t.CompletedAt,
+			&t.Position,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.DeletedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		t.ActivityGroupID = domain.Activity{
+			ID: activityID.Int64,
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// fetchTags will load the tags of the given todos in a single query
+func (m *postgresTodoRepository) fetchTags(ctx context.Context, todos []domain.Todo) (err error) {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(todos))
+	for i, t := range todos {
+		index[t.ID] = i
+	}
+
+	query, args := repository.TagsQuery(dialect{}, todos)
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		var todoID int64
+		var name string
+		err = rows.Scan(&todoID, &name)
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+		if i, ok := index[todoID]; ok {
+			todos[i].Tags = append(todos[i].Tags, name)
+		}
+	}
+	return rows.Err()
+}
+
+// setTags will replace the tags of the todo, the tags not known yet are created
+func (m *postgresTodoRepository) setTags(ctx context.Context, t *domain.Todo, replace bool) (err error) {
+	conn := transaction.Conn(ctx, m.Conn)
+	if replace {
+		_, err = conn.ExecContext(ctx, "DELETE FROM todo_tag WHERE todo_id = $1", t.ID)
+		if err != nil {
+			return
+		}
+	}
+	if len(t.Tags) == 0 {
+		return
+	}
+
+	args := repository.NewArgs(dialect{})
+	values := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		values = append(values, "("+args.AddAll(tag, t.UpdatedAt, t.UpdatedAt)+")")
+	}
+	_, err = conn.ExecContext(ctx, "INSERT INTO tag (name, updated_at, created_at) VALUES "+strings.Join(values, ", ")+" ON CONFLICT (name) DO NOTHING", args.Values...)
+	if err != nil {
+		return
+	}
+
+	names := repository.NewArgs(dialect{})
+	query := "INSERT INTO todo_tag (todo_id, tag_id) SELECT " + names.Add(t.ID) + "::BIGINT, id FROM tag WHERE name IN (" + names.AddStrings(t.Tags) + ")"
+	_, err = conn.ExecContext(ctx, query, names.Values...)
+	return
+}
+
+// nullableID will store the todo without activity group as NULL
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// dialect writes the arguments of the queries with the $n placeholders of PostgreSQL
+type dialect struct{}
+
+func (dialect) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (dialect) Value(value interface{}) interface{} {
+	return value
+}
+
+func (m *postgresTodoRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) (res []domain.Todo, nextCursor string, prevCursor string, err error) {
+	sort, err = repository.NormalizeSort(sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	query, args, direction, err := repository.ListQuery(dialect{}, cursor, num, filter, sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+	res, err = m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	res, nextCursor, prevCursor = repository.Page(res, num, cursor, direction, filter, sort)
+	if err = m.fetchTags(ctx, res); err != nil {
+		return nil, "", "", err
+	}
+	return
+}
+
+func (m *postgresTodoRepository) GetByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE ID = $1 AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+
+	return
+}
+
+func (m *postgresTodoRepository) GetByTitle(ctx context.Context, title string) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE title = $1 AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, title)
+	if err != nil {
+		return
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+	return
+}
+
+// Store will refuse an activity group that is not stored with domain.ErrUnknownActivity
+func (m *postgresTodoRepository) Store(ctx context.Context, a *domain.Todo) (err error) {
+	query := `INSERT INTO todo (activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at)
+  						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	err = stmt.QueryRowContext(ctx, nullableID(a.ActivityGroupID.ID), a.Title, a.Notes, string(a.Status), int(a.Priority), a.DueAt, a.Recurrence, a.TimeZone, a.CompletedAt, a.Position, a.UpdatedAt, a.CreatedAt).Scan(&a.ID)
+	if postgresdb.IsForeignKeyViolation(err) {
+		return domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return
+	}
+	return m.setTags(ctx, a, false)
+}
+
+func (m *postgresTodoRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE todo set deleted_at=$1 WHERE ID = $2 AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, deletedAt, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+func (m *postgresTodoRepository) Update(ctx context.Context, ar *domain.Todo) (err error) {
+	query := `UPDATE todo set activity_group_id=$1, title=$2, notes=$3, status=$4, priority=$5, due_at=$6, recurrence=$7, time_zone=$8, completed_at=$9, position=$10, updated_at=$11 WHERE ID = $12 AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(ar.ActivityGroupID.ID), ar.Title, ar.Notes, string(ar.Status), int(ar.Priority), ar.DueAt, ar.Recurrence, ar.TimeZone, ar.CompletedAt, ar.Position, ar.UpdatedAt, ar.ID)
+	if postgresdb.IsForeignKeyViolation(err) {
+		return domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return m.setTags(ctx, ar, true)
+}
+
+func (m *postgresTodoRepository) DeleteByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	query := "UPDATE todo set deleted_at=$1 WHERE activity_group_id = $2 AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, deletedAt, activityGroupID)
+	return
+}
+
+func (m *postgresTodoRepository) DetachActivityGroup(ctx context.Context, activityGroupID int64) (err error) {
+	query := `UPDATE todo set activity_group_id=NULL WHERE activity_group_id = $1 AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, activityGroupID)
+	return
+}
+
+func (m *postgresTodoRepository) LastPosition(ctx context.Context, activityGroupID int64) (position string, err error) {
+	query := `SELECT COALESCE(MAX(position), '') FROM todo WHERE activity_group_id = $1 AND deleted_at IS NULL`
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, activityGroupID).Scan(&position)
+	return
+}
+
+func (m *postgresTodoRepository) NeighborPosition(ctx context.Context, activityGroupID int64, position string, next bool) (neighbor string, err error) {
+	query := `SELECT position FROM todo WHERE activity_group_id = $1 AND position < $2 AND deleted_at IS NULL ORDER BY position DESC LIMIT 1`
+	if next {
+		query = `SELECT position FROM todo WHERE activity_group_id = $1 AND position > $2 AND deleted_at IS NULL ORDER BY position ASC LIMIT 1`
+	}
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, activityGroupID, position).Scan(&neighbor)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return
+}
+
+func (m *postgresTodoRepository) UpdatePosition(ctx context.Context, ar *domain.Todo) (err error) {
+	query := `UPDATE todo set activity_group_id=$1, position=$2, updated_at=$3 WHERE ID = $4 AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(ar.ActivityGroupID.ID), ar.Position, ar.UpdatedAt, ar.ID)
+	if postgresdb.IsForeignKeyViolation(err) {
+		return domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresTodoRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE ID = $1 AND deleted_at IS NOT NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+
+	return
+}
+
+func (m *postgresTodoRepository) FetchDeleted(ctx context.Context) (res []domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	res, err = m.fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.fetchTags(ctx, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (m *postgresTodoRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := `UPDATE todo set deleted_at=NULL WHERE ID = $1 AND deleted_at IS NOT NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresTodoRepository) RestoreByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	query := `UPDATE todo set deleted_at=NULL WHERE activity_group_id = $1 AND deleted_at = $2`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, activityGroupID, deletedAt)
+	return
+}
+
+func (m *postgresTodoRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	query := `DELETE FROM todo WHERE deleted_at < $1`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return
+	}
+	return res.RowsAffected()
+}
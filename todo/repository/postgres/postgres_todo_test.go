@@ -0,0 +1,700 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+	todoPostgresRepo "github.com/bxcodec/go-clean-arch/todo/repository/postgres"
+)
+
+const tagQuery = "SELECT tt.todo_id, t.name FROM todo_tag tt JOIN tag t ON t.id = tt.tag_id WHERE tt.todo_id IN"
+
+var tagColumns = []string{"todo_id", "name"}
+
+func TestFetch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockTodos := []domain.Todo{
+		{
+			ID: 1, ActivityGroupID: domain.Activity{ID: 2}, Title: "title 1", Status: domain.TodoStatusTodo, Priority: 3,
+			UpdatedAt: createdAt, CreatedAt: createdAt,
+		},
+		{
+			ID: 2, ActivityGroupID: domain.Activity{ID: 2}, Title: "title 2", Status: domain.TodoStatusTodo, Priority: 3,
+			UpdatedAt: createdAt, CreatedAt: createdAt,
+		},
+	}
+	columns := []string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}
+
+	t.Run("first-page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(mockTodos[0].ID, mockTodos[0].ActivityGroupID.ID, mockTodos[0].Title, "", mockTodos[0].Status, mockTodos[0].Priority, nil, "", "", nil, "", mockTodos[0].UpdatedAt, mockTodos[0].CreatedAt, nil).
+			AddRow(mockTodos[1].ID, mockTodos[1].ActivityGroupID.ID, mockTodos[1].Title, "", mockTodos[1].Status, mockTodos[1].Priority, nil, "", "", nil, "", mockTodos[1].UpdatedAt, mockTodos[1].CreatedAt, nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo WHERE deleted_at IS NULL ORDER BY created_at ASC, id ASC LIMIT \\$1"
+
+		mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), "", int64(1), domain.TodoFilter{}, nil)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 1)
+		assert.Equal(t, createdAt, list[0].CreatedAt)
+
+		decoded, err := pagination.DecodeCursor(nextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, mockTodos[0].ID, decoded.ID)
+		assert.Equal(t, []string{pagination.EncodeTime(createdAt)}, decoded.Values)
+	})
+
+	t.Run("same-timestamp-next-page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(mockTodos[1].ID, mockTodos[1].ActivityGroupID.ID, mockTodos[1].Title, "", mockTodos[1].Status, mockTodos[1].Priority, nil, "", "", nil, "", mockTodos[1].UpdatedAt, mockTodos[1].CreatedAt, nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL AND \\(created_at > \\$1 OR \\(created_at = \\$2 AND id > \\$3\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\$4"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockTodos[0].ID,
+			Sort:      "created_at,id",
+		})
+		mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), mockTodos[0].ID, int64(2)).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.TodoFilter{}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+		assert.Len(t, list, 1)
+		assert.Equal(t, mockTodos[1].ID, list[0].ID)
+	})
+
+	t.Run("prev-page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(mockTodos[0].ID, mockTodos[0].ActivityGroupID.ID, mockTodos[0].Title, "", mockTodos[0].Status, mockTodos[0].Priority, nil, "", "", nil, "", mockTodos[0].UpdatedAt, mockTodos[0].CreatedAt, nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL AND \\(created_at < \\$1 OR \\(created_at = \\$2 AND id < \\$3\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\$4"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionPrev,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockTodos[1].ID,
+			Sort:      "created_at,id",
+		})
+		mock.ExpectQuery(query).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.TodoFilter{}, nil)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("mismatched-filter", func(t *testing.T) {
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        mockTodos[0].ID,
+			Filter:    "activity_group_id=2",
+			Sort:      "created_at,id",
+		})
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		_, _, _, err := a.Fetch(context.TODO(), cursor, int64(1), domain.TodoFilter{ActivityGroupID: 3}, nil)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	t.Run("tampered-cursor", func(t *testing.T) {
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		_, _, _, err := a.Fetch(context.TODO(), "eyJ2IjoxfQ.AAAAAAAAAAA", int64(1), domain.TodoFilter{}, nil)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchWithFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, 3, "title 1", "", "todo", 5, nil, "", "", nil, "", time.Now(), time.Now(), nil)
+
+	query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+		"WHERE deleted_at IS NULL AND activity_group_id = \\$1 AND status IN \\(\\$2, \\$3\\) AND priority = \\$4 ORDER BY created_at ASC, id ASC LIMIT \\$5"
+
+	priority := domain.PriorityVeryHigh
+	filter := domain.TodoFilter{
+		ActivityGroupID: 3,
+		Status:          domain.ActiveTodoStatuses,
+		Priority:        &priority,
+	}
+	mock.ExpectQuery(query).WithArgs(int64(3), "todo", "in_progress", 5, int64(11)).WillReturnRows(rows)
+	mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+	list, nextCursor, _, err := a.Fetch(context.TODO(), "", int64(10), filter, nil)
+	assert.Empty(t, nextCursor)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchWithDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	dueAfter := time.Date(2021, 3, 1, 0, 0, 0, 0, jakarta)
+	dueBefore := dueAfter.AddDate(0, 0, 1)
+	dueAt := time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, 3, "title 1", "", "todo", 5, dueAt, "", "", nil, "", time.Now(), time.Now(), nil)
+
+	query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+		"WHERE deleted_at IS NULL AND due_at >= \\$1 AND due_at < \\$2 AND due_at < \\$3 AND status IN \\(\\$4, \\$5\\) ORDER BY created_at ASC, id ASC LIMIT \\$6"
+
+	filter := domain.TodoFilter{
+		DueAfter:  &dueAfter,
+		DueBefore: &dueBefore,
+		Overdue:   true,
+		Now:       time.Date(2021, 3, 1, 5, 0, 0, 0, jakarta),
+	}
+	mock.ExpectQuery(query).
+		WithArgs(dueAfter.UTC(), dueBefore.UTC(), filter.Now.UTC(), "todo", "in_progress", int64(11)).
+		WillReturnRows(rows)
+	mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+	list, _, _, err := a.Fetch(context.TODO(), "", int64(10), filter, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) && assert.NotNil(t, list[0].DueAt) {
+		assert.Equal(t, dueAt, *list[0].DueAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchWithTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	columns := []string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}
+	tags := []string{"backend", "urgent"}
+
+	t.Run("any", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 3, "title 1", "", "todo", 5, nil, "", "", nil, "", time.Now(), time.Now(), nil).
+			AddRow(2, 3, "title 2", "", "todo", 5, nil, "", "", nil, "", time.Now(), time.Now(), nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL AND id IN \\(SELECT tt.todo_id FROM todo_tag tt JOIN tag t ON t.id = tt.tag_id WHERE t.name IN \\(\\$1, \\$2\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\$3"
+
+		mock.ExpectQuery(query).WithArgs("backend", "urgent", int64(11)).WillReturnRows(rows)
+		// the tags of the whole page are loaded at once
+		mock.ExpectQuery(tagQuery+" \\(\\$1, \\$2\\) ORDER BY t.name ASC").WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows(tagColumns).AddRow(1, "backend").AddRow(2, "backend").AddRow(1, "urgent"))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, _, _, err := a.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{Tags: tags, TagMatch: domain.TagMatchAny}, nil)
+		assert.NoError(t, err)
+		if assert.Len(t, list, 2) {
+			assert.Equal(t, []string{"backend", "urgent"}, list[0].Tags)
+			assert.Equal(t, []string{"backend"}, list[1].Tags)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 3, "title 1", "", "todo", 5, nil, "", "", nil, "", time.Now(), time.Now(), nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL AND id IN \\(SELECT tt.todo_id FROM todo_tag tt JOIN tag t ON t.id = tt.tag_id WHERE t.name IN \\(\\$1, \\$2\\) " +
+			"GROUP BY tt.todo_id HAVING COUNT\\(DISTINCT tt.tag_id\\) = \\$3\\) ORDER BY created_at ASC, id ASC LIMIT \\$4"
+
+		mock.ExpectQuery(query).WithArgs("backend", "urgent", 2, int64(11)).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(tagColumns).AddRow(1, "backend").AddRow(1, "urgent"))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, _, _, err := a.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{Tags: tags, TagMatch: domain.TagMatchAll}, nil)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchWithSort(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}
+	sort := domain.TodoSort{{Field: "priority", Descending: true}, {Field: "created_at"}}
+
+	t.Run("first-page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(4, 2, "title 4", "", "todo", 5, nil, "", "", nil, "", createdAt, createdAt, nil).
+			AddRow(3, 2, "title 3", "", "todo", 3, nil, "", "", nil, "", createdAt, createdAt, nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL ORDER BY priority DESC, created_at ASC, id ASC LIMIT \\$1"
+
+		mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, nextCursor, _, err := a.Fetch(context.TODO(), "", int64(1), domain.TodoFilter{}, sort)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		decoded, err := pagination.DecodeCursor(nextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, "-priority,created_at,id", decoded.Sort)
+		assert.Equal(t, []string{"5", pagination.EncodeTime(createdAt)}, decoded.Values)
+	})
+
+	t.Run("next-page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(3, 2, "title 3", "", "todo", 3, nil, "", "", nil, "", createdAt, createdAt, nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL AND \\(priority < \\$1 OR \\(priority = \\$2 AND created_at > \\$3\\) OR \\(priority = \\$4 AND created_at = \\$5 AND id > \\$6\\)\\) " +
+			"ORDER BY priority DESC, created_at ASC, id ASC LIMIT \\$7"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{"5", pagination.EncodeTime(createdAt)},
+			ID:        4,
+			Sort:      "-priority,created_at,id",
+		})
+		mock.ExpectQuery(query).WithArgs(5, 5, sqlmock.AnyArg(), 5, sqlmock.AnyArg(), int64(4), int64(2)).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.TodoFilter{}, sort)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Empty(t, nextCursor)
+		assert.NotEmpty(t, prevCursor)
+	})
+
+	t.Run("prev-page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(4, 2, "title 4", "", "todo", 5, nil, "", "", nil, "", createdAt, createdAt, nil)
+
+		query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+			"WHERE deleted_at IS NULL AND \\(priority > \\$1 OR \\(priority = \\$2 AND created_at < \\$3\\) OR \\(priority = \\$4 AND created_at = \\$5 AND id < \\$6\\)\\) " +
+			"ORDER BY priority ASC, created_at DESC, id DESC LIMIT \\$7"
+
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionPrev,
+			Values:    []string{"3", pagination.EncodeTime(createdAt)},
+			ID:        3,
+			Sort:      "-priority,created_at,id",
+		})
+		mock.ExpectQuery(query).WillReturnRows(rows)
+		mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		list, nextCursor, prevCursor, err := a.Fetch(context.TODO(), cursor, int64(1), domain.TodoFilter{}, sort)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.NotEmpty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+	})
+
+	t.Run("cursor-of-another-sort", func(t *testing.T) {
+		cursor := pagination.EncodeCursor(pagination.Cursor{
+			Direction: pagination.DirectionNext,
+			Values:    []string{pagination.EncodeTime(createdAt)},
+			ID:        4,
+			Sort:      "created_at,id",
+		})
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		_, _, _, err := a.Fetch(context.TODO(), cursor, int64(1), domain.TodoFilter{}, sort)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	t.Run("not-whitelisted", func(t *testing.T) {
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+		_, _, _, err := a.Fetch(context.TODO(), "", int64(1), domain.TodoFilter{}, domain.TodoSort{{Field: "id; DROP TABLE todo"}})
+		assert.Equal(t, domain.ErrBadParamInput, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	completedAt := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, 2, "title 1", "Ask for **the** invoice", "done", 3, nil, "FREQ=WEEKLY", "Europe/Berlin", completedAt, "", time.Now(), time.Now(), nil)
+
+	query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo WHERE ID = \\$1 AND deleted_at IS NULL"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	num := int64(5)
+	aTodo, err := a.GetByID(context.TODO(), num)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), aTodo.ActivityGroupID.ID)
+	assert.Equal(t, domain.TodoStatusDone, aTodo.Status)
+	assert.Equal(t, "Ask for **the** invoice", aTodo.Notes)
+	assert.Equal(t, "FREQ=WEEKLY", aTodo.Recurrence)
+	assert.Equal(t, "Europe/Berlin", aTodo.TimeZone)
+	if assert.NotNil(t, aTodo.CompletedAt) {
+		assert.Equal(t, completedAt, *aTodo.CompletedAt)
+	}
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Todo{
+		ActivityGroupID: domain.Activity{ID: 2},
+		Title:           "Judul",
+		Notes:           "Ask for the invoice",
+		Status:          domain.TodoStatusTodo,
+		Priority:        3,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT INTO todo \\(activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11, \\$12\\) RETURNING id"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(ar.ActivityGroupID.ID, ar.Title, ar.Notes, string(ar.Status), ar.Priority, nil, "", "", nil, ar.Position, ar.UpdatedAt, ar.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.Store(context.TODO(), ar)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), ar.ID)
+
+	// the activity group is not stored
+	prep = mock.ExpectPrepare("INSERT INTO todo")
+	prep.ExpectQuery().WillReturnError(&pq.Error{Code: "23503"})
+	err = a.Store(context.TODO(), &domain.Todo{ActivityGroupID: domain.Activity{ID: 42}, Title: "Judul", Priority: domain.PriorityNormal})
+	assert.Equal(t, domain.ErrUnknownActivity, err)
+}
+
+func TestGetByTitle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, 2, "title 1", "", "todo", 3, nil, "", "", nil, "", time.Now(), time.Now(), nil)
+
+	query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo WHERE title = \\$1 AND deleted_at IS NULL"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns))
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	title := "title 1"
+	aTodo, err := a.GetByTitle(context.TODO(), title)
+	assert.NoError(t, err)
+	assert.Equal(t, title, aTodo.Title)
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	query := "UPDATE todo set deleted_at=\\$1 WHERE ID = \\$2 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(now, 12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	num := int64(12)
+	err = a.Delete(context.TODO(), num, now)
+	assert.NoError(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Todo{
+		ID:              12,
+		ActivityGroupID: domain.Activity{ID: 2},
+		Title:           "Judul",
+		Status:          domain.TodoStatusTodo,
+		Priority:        3,
+		Tags:            []string{"backend", "urgent"},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE todo set activity_group_id=\\$1, title=\\$2, notes=\\$3, status=\\$4, priority=\\$5, due_at=\\$6, recurrence=\\$7, time_zone=\\$8, completed_at=\\$9, position=\\$10, updated_at=\\$11 WHERE ID = \\$12 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(ar.ActivityGroupID.ID, ar.Title, ar.Notes, string(ar.Status), ar.Priority, nil, "", "", nil, ar.Position, ar.UpdatedAt, ar.ID).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec("DELETE FROM todo_tag WHERE todo_id = \\$1").WithArgs(ar.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO tag \\(name, updated_at, created_at\\) VALUES \\(\\$1, \\$2, \\$3\\), \\(\\$4, \\$5, \\$6\\) ON CONFLICT \\(name\\) DO NOTHING").
+		WithArgs("backend", now, now, "urgent", now, now).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec("INSERT INTO todo_tag \\(todo_id, tag_id\\) SELECT \\$1::BIGINT, id FROM tag WHERE name IN \\(\\$2, \\$3\\)").
+		WithArgs(ar.ID, "backend", "urgent").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.Update(context.TODO(), ar)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteByActivityGroupID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	query := "UPDATE todo set deleted_at=\\$1 WHERE activity_group_id = \\$2 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(now, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.DeleteByActivityGroupID(context.TODO(), int64(2), now)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDetachActivityGroup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE todo set activity_group_id=NULL WHERE activity_group_id = \\$1 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.DetachActivityGroup(context.TODO(), int64(2))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLastPosition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT COALESCE\\(MAX\\(position\\), ''\\) FROM todo WHERE activity_group_id = \\$1 AND deleted_at IS NULL"
+	mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("k"))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	position, err := a.LastPosition(context.TODO(), int64(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "k", position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNeighborPosition(t *testing.T) {
+	t.Run("next", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		query := "SELECT position FROM todo WHERE activity_group_id = \\$1 AND position > \\$2 AND deleted_at IS NULL ORDER BY position ASC LIMIT 1"
+		mock.ExpectQuery(query).WithArgs(int64(2), "i").WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("r"))
+
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+		position, err := a.NeighborPosition(context.TODO(), int64(2), "i", true)
+		assert.NoError(t, err)
+		assert.Equal(t, "r", position)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("previous-none", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		query := "SELECT position FROM todo WHERE activity_group_id = \\$1 AND position < \\$2 AND deleted_at IS NULL ORDER BY position DESC LIMIT 1"
+		mock.ExpectQuery(query).WithArgs(int64(2), "i").WillReturnRows(sqlmock.NewRows([]string{"position"}))
+
+		a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+		position, err := a.NeighborPosition(context.TODO(), int64(2), "i", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "", position)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePosition(t *testing.T) {
+	now := time.Now()
+	ar := &domain.Todo{
+		ID:              12,
+		ActivityGroupID: domain.Activity{ID: 3},
+		Position:        "m",
+		UpdatedAt:       now,
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE todo set activity_group_id=\\$1, position=\\$2, updated_at=\\$3 WHERE ID = \\$4 AND deleted_at IS NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(ar.ActivityGroupID.ID, ar.Position, ar.UpdatedAt, ar.ID).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.UpdatePosition(context.TODO(), ar)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	deletedAt := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}).
+		AddRow(1, 2, "title 1", "", "todo", 3, nil, "", "", nil, "", time.Now(), time.Now(), deletedAt)
+
+	query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+		"WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC"
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(tagQuery).WillReturnRows(sqlmock.NewRows(tagColumns).AddRow(1, "backend"))
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	list, err := a.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) && assert.NotNil(t, list[0].DeletedAt) {
+		assert.Equal(t, deletedAt, *list[0].DeletedAt)
+		assert.Equal(t, []string{"backend"}, list[0].Tags)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeletedByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at FROM todo " +
+		"WHERE ID = \\$1 AND deleted_at IS NOT NULL"
+
+	mock.ExpectQuery(query).WithArgs(int64(5)).WillReturnRows(sqlmock.NewRows([]string{"id", "activity_group_id", "title", "notes", "status", "priority", "due_at", "recurrence", "time_zone", "completed_at", "position", "updated_at", "created_at", "deleted_at"}))
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	_, err = a.GetDeletedByID(context.TODO(), int64(5))
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE todo set deleted_at=NULL WHERE ID = \\$1 AND deleted_at IS NOT NULL"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.Restore(context.TODO(), int64(12))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreByActivityGroupID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	deletedAt := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+	query := "UPDATE todo set deleted_at=NULL WHERE activity_group_id = \\$1 AND deleted_at = \\$2"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(2, deletedAt).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	err = a.RestoreByActivityGroupID(context.TODO(), int64(2), deletedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	before := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	query := "DELETE FROM todo WHERE deleted_at < \\$1"
+
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 4))
+
+	a := todoPostgresRepo.NewPostgresTodoRepository(db)
+
+	purged, err := a.Purge(context.TODO(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
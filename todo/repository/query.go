@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/pagination"
+)
+
+// Dialect is what tells the SQL drivers apart when building the queries of the todos
+type Dialect interface {
+	// Placeholder will give the placeholder of the n-th argument of the query, counting from 1
+	Placeholder(n int) string
+	// Value will bring the argument to what the driver stores
+	Value(value interface{}) interface{}
+}
+
+// Args collects the arguments of a query built piece by piece, handing out the placeholder of each one in the dialect
+type Args struct {
+	dialect Dialect
+	Values  []interface{}
+}
+
+// NewArgs will create the arguments of a query written in the given dialect
+func NewArgs(d Dialect) *Args {
+	return &Args{dialect: d}
+}
+
+// Add will append the value and return its placeholder
+func (a *Args) Add(value interface{}) string {
+	a.Values = append(a.Values, a.dialect.Value(value))
+	return a.dialect.Placeholder(len(a.Values))
+}
+
+// AddAll will append every value and return their placeholders separated by commas, as an IN list expects
+func (a *Args) AddAll(values ...interface{}) string {
+	placeholders := make([]string, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, a.Add(value))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// AddStrings will append every string as AddAll does
+func (a *Args) AddStrings(values []string) string {
+	list := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+	return a.AddAll(list...)
+}
+
+// todoColumns are the columns of a todo in the order the drivers scan them
+const todoColumns = "id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at"
+
+// ListQuery will build the query of a page of the listing with the given filter and normalized sort, starting after the
+// cursor when there is one. It fetches one more row than requested to know whether there is another page in the
+// same direction, see Page
+func ListQuery(d Dialect, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) (query string, args []interface{}, direction string, err error) {
+	a := NewArgs(d)
+	conditions := filterConditions(filter, a)
+	keys := orderKeys(sort)
+
+	direction = pagination.DirectionNext
+	if cursor != "" {
+		c, err := decodeCursor(cursor, filter, sort, keys)
+		if err != nil {
+			return "", nil, "", err
+		}
+
+		direction = c.Direction
+		conditions = append(conditions, keysetCondition(keys, direction, a))
+	}
+
+	query = "SELECT " + todoColumns + " FROM todo" + whereClause(conditions) + orderClause(keys, direction) + " LIMIT " + a.Add(num+1)
+	return query, a.Values, direction, nil
+}
+
+// Page will bring the rows fetched by ListQuery back to the requested page in the order of the listing, along with
+// the cursors of the pages around it
+func Page(res []domain.Todo, num int64, cursor string, direction string, filter domain.TodoFilter, sort domain.TodoSort) (page []domain.Todo, nextCursor string, prevCursor string) {
+	hasMore := len(res) > int(num)
+	if hasMore {
+		res = res[:num]
+	}
+	if direction == pagination.DirectionPrev {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	if len(res) == 0 {
+		return res, "", ""
+	}
+
+	if hasMore || direction == pagination.DirectionPrev {
+		nextCursor = CursorOf(res[len(res)-1], pagination.DirectionNext, filter, sort)
+	}
+	if (hasMore && direction == pagination.DirectionPrev) || (cursor != "" && direction == pagination.DirectionNext) {
+		prevCursor = CursorOf(res[0], pagination.DirectionPrev, filter, sort)
+	}
+	return res, nextCursor, prevCursor
+}
+
+// TagsQuery will build the query loading the tags of the given todos, as todo_id and name rows
+func TagsQuery(d Dialect, todos []domain.Todo) (query string, args []interface{}) {
+	a := NewArgs(d)
+	ids := make([]interface{}, 0, len(todos))
+	for _, t := range todos {
+		ids = append(ids, t.ID)
+	}
+
+	query = `SELECT tt.todo_id, t.name FROM todo_tag tt JOIN tag t ON t.id = tt.tag_id
+  						WHERE tt.todo_id IN (` + a.AddAll(ids...) + `) ORDER BY t.name ASC`
+	return query, a.Values
+}
+
+// filterConditions will turn the given filter into parameterized conditions of a WHERE clause, their arguments are
+// appended to args. The overdue todos are the ones still open past filter.Now and the todos in the trash are never
+// listed
+func filterConditions(filter domain.TodoFilter, args *Args) (conditions []string) {
+	conditions = []string{"deleted_at IS NULL"}
+	if filter.ActivityGroupID != 0 {
+		conditions = append(conditions, "activity_group_id = "+args.Add(filter.ActivityGroupID))
+	}
+	if len(filter.Status) > 0 {
+		statuses := make([]string, 0, len(filter.Status))
+		for _, status := range filter.Status {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, "status IN ("+args.AddStrings(statuses)+")")
+	}
+	if filter.Priority != nil {
+		conditions = append(conditions, "priority = "+args.Add(int(*filter.Priority)))
+	}
+	if filter.DueAfter != nil {
+		conditions = append(conditions, "due_at >= "+args.Add(filter.DueAfter.UTC()))
+	}
+	if filter.DueBefore != nil {
+		conditions = append(conditions, "due_at < "+args.Add(filter.DueBefore.UTC()))
+	}
+	if filter.Overdue {
+		conditions = append(conditions, "due_at < "+args.Add(filter.Now.UTC())+" AND status IN ("+
+			args.AddAll(string(domain.TodoStatusTodo), string(domain.TodoStatusInProgress))+")")
+	}
+	if len(filter.Tags) > 0 {
+		condition := "id IN (SELECT tt.todo_id FROM todo_tag tt JOIN tag t ON t.id = tt.tag_id WHERE t.name IN (" + args.AddStrings(filter.Tags) + ")"
+		if filter.TagMatch == domain.TagMatchAll {
+			condition += " GROUP BY tt.todo_id HAVING COUNT(DISTINCT tt.tag_id) = " + args.Add(len(filter.Tags))
+		}
+		conditions = append(conditions, condition+")")
+	}
+	return
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// orderKey is a column of the ORDER BY clause along with its value on the cursor row
+type orderKey struct {
+	column     string
+	descending bool
+	value      interface{}
+}
+
+// keysetCondition will build the condition matching the rows positioned after the cursor row in the given order,
+// its arguments are appended to args
+func keysetCondition(keys []orderKey, direction string, args *Args) (condition string) {
+	terms := make([]string, 0, len(keys))
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for _, prevKey := range keys[:i] {
+			parts = append(parts, prevKey.column+" = "+args.Add(prevKey.value))
+		}
+
+		operator := ">"
+		if key.descending != (direction == pagination.DirectionPrev) {
+			operator = "<"
+		}
+		parts = append(parts, key.column+" "+operator+" "+args.Add(key.value))
+
+		term := strings.Join(parts, " AND ")
+		if i > 0 {
+			term = "(" + term + ")"
+		}
+		terms = append(terms, term)
+	}
+
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+func orderClause(keys []orderKey, direction string) string {
+	columns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		order := "ASC"
+		if key.descending != (direction == pagination.DirectionPrev) {
+			order = "DESC"
+		}
+		columns = append(columns, key.column+" "+order)
+	}
+	return " ORDER BY " + strings.Join(columns, ", ")
+}
+
+func orderKeys(sort domain.TodoSort) []orderKey {
+	keys := make([]orderKey, 0, len(sort)+1)
+	for _, field := range sort {
+		keys = append(keys, orderKey{column: SortKeys[field.Field].Column, descending: field.Descending})
+	}
+	return append(keys, orderKey{column: "id", descending: sort[len(sort)-1].Descending})
+}
+
+// decodeCursor will decode the cursor and fill the order keys with the values of the cursor row
+func decodeCursor(cursor string, filter domain.TodoFilter, sort domain.TodoSort, keys []orderKey) (c pagination.Cursor, err error) {
+	c, values, err := ParseCursor(cursor, filter, sort)
+	if err != nil {
+		return pagination.Cursor{}, err
+	}
+
+	for i, value := range values {
+		keys[i].value = value
+	}
+	keys[len(keys)-1].value = c.ID
+	return
+}
@@ -0,0 +1,457 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+	"github.com/bxcodec/go-clean-arch/todo/repository"
+)
+
+type sqliteTodoRepository struct {
+	Conn *sql.DB
+}
+
+// NewSqliteTodoRepository will create an object that represent the domain.TodoRepository interface
+func NewSqliteTodoRepository(Conn *sql.DB) domain.TodoRepository {
+	return &sqliteTodoRepository{Conn}
+}
+
+func (m *sqliteTodoRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.Todo, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.Todo, 0)
+	for rows.Next() {
+		t := domain.Todo{}
+		activityID := sql.NullInt64{}
+		err = rows.Scan(
+			&t.ID,
+			&activityID,
+			&t.Title,
+			&t.Notes,
+			&t.Status,
+			&t.Priority,
+			&t.DueAt,
+			&t.Recurrence,
+			&t.TimeZone,
+			&t.CompletedAt,
+			&t.Position,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+			&t.DeletedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		t.ActivityGroupID = domain.Activity{
+			ID: activityID.Int64,
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+// fetchTags will load the tags of the given todos in a single query
+func (m *sqliteTodoRepository) fetchTags(ctx context.Context, todos []domain.Todo) (err error) {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(todos))
+	for i, t := range todos {
+		index[t.ID] = i
+	}
+
+	query, args := repository.TagsQuery(dialect{}, todos)
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	for rows.Next() {
+		var todoID int64
+		var name string
+		err = rows.Scan(&todoID, &name)
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+		if i, ok := index[todoID]; ok {
+			todos[i].Tags = append(todos[i].Tags, name)
+		}
+	}
+	return rows.Err()
+}
+
+// setTags will replace the tags of the todo, the tags not known yet are created
+func (m *sqliteTodoRepository) setTags(ctx context.Context, t *domain.Todo, replace bool) (err error) {
+	conn := transaction.Conn(ctx, m.Conn)
+	if replace {
+		_, err = conn.ExecContext(ctx, "DELETE FROM todo_tag WHERE todo_id = ?", t.ID)
+		if err != nil {
+			return
+		}
+	}
+	if len(t.Tags) == 0 {
+		return
+	}
+
+	args := repository.NewArgs(dialect{})
+	values := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		values = append(values, "("+args.AddAll(tag, t.UpdatedAt, t.UpdatedAt)+")")
+	}
+	_, err = conn.ExecContext(ctx, "INSERT OR IGNORE INTO tag (name, updated_at, created_at) VALUES "+strings.Join(values, ", "), args.Values...)
+	if err != nil {
+		return
+	}
+
+	names := repository.NewArgs(dialect{})
+	query := "INSERT INTO todo_tag (todo_id, tag_id) SELECT " + names.Add(t.ID) + ", id FROM tag WHERE name IN (" + names.AddStrings(t.Tags) + ")"
+	_, err = conn.ExecContext(ctx, query, names.Values...)
+	return
+}
+
+// nullableID will store the todo without activity group as NULL
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// dialect writes the arguments of the queries with the ? placeholder, the times as the DATETIME columns store them
+type dialect struct{}
+
+func (dialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (dialect) Value(value interface{}) interface{} {
+	if t, ok := value.(time.Time); ok {
+		return sqlitedb.Time(t)
+	}
+	return value
+}
+
+func (m *sqliteTodoRepository) Fetch(ctx context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) (res []domain.Todo, nextCursor string, prevCursor string, err error) {
+	sort, err = repository.NormalizeSort(sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	query, args, direction, err := repository.ListQuery(dialect{}, cursor, num, filter, sort)
+	if err != nil {
+		return nil, "", "", err
+	}
+	res, err = m.fetch(ctx, query, args...)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	res, nextCursor, prevCursor = repository.Page(res, num, cursor, direction, filter, sort)
+	if err = m.fetchTags(ctx, res); err != nil {
+		return nil, "", "", err
+	}
+	return
+}
+
+func (m *sqliteTodoRepository) GetByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE ID = ? AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+
+	return
+}
+
+func (m *sqliteTodoRepository) GetByTitle(ctx context.Context, title string) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE title = ? AND deleted_at IS NULL`
+
+	list, err := m.fetch(ctx, query, title)
+	if err != nil {
+		return
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+	return
+}
+
+// Store will refuse an activity group that is not stored with domain.ErrUnknownActivity
+func (m *sqliteTodoRepository) Store(ctx context.Context, a *domain.Todo) (err error) {
+	query := `INSERT INTO todo (activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at)
+  						VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(a.ActivityGroupID.ID), a.Title, a.Notes, string(a.Status), int(a.Priority), sqlitedb.TimePtr(a.DueAt), a.Recurrence, a.TimeZone, sqlitedb.TimePtr(a.CompletedAt), a.Position, sqlitedb.Time(a.UpdatedAt), sqlitedb.Time(a.CreatedAt))
+	if sqlitedb.IsForeignKeyViolation(err) {
+		return domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return m.setTags(ctx, a, false)
+}
+
+func (m *sqliteTodoRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	query := "UPDATE todo set deleted_at=? WHERE ID = ? AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, sqlitedb.Time(deletedAt), id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+func (m *sqliteTodoRepository) Update(ctx context.Context, ar *domain.Todo) (err error) {
+	query := `UPDATE todo set activity_group_id=?, title=?, notes=?, status=?, priority=?, due_at=?, recurrence=?, time_zone=?, completed_at=?, position=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(ar.ActivityGroupID.ID), ar.Title, ar.Notes, string(ar.Status), int(ar.Priority), sqlitedb.TimePtr(ar.DueAt), ar.Recurrence, ar.TimeZone, sqlitedb.TimePtr(ar.CompletedAt), ar.Position, sqlitedb.Time(ar.UpdatedAt), ar.ID)
+	if sqlitedb.IsForeignKeyViolation(err) {
+		return domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return m.setTags(ctx, ar, true)
+}
+
+func (m *sqliteTodoRepository) DeleteByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	query := "UPDATE todo set deleted_at=? WHERE activity_group_id = ? AND deleted_at IS NULL"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, sqlitedb.Time(deletedAt), activityGroupID)
+	return
+}
+
+func (m *sqliteTodoRepository) DetachActivityGroup(ctx context.Context, activityGroupID int64) (err error) {
+	query := `UPDATE todo set activity_group_id=NULL WHERE activity_group_id = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, activityGroupID)
+	return
+}
+
+func (m *sqliteTodoRepository) LastPosition(ctx context.Context, activityGroupID int64) (position string, err error) {
+	query := `SELECT COALESCE(MAX(position), '') FROM todo WHERE activity_group_id = ? AND deleted_at IS NULL`
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, activityGroupID).Scan(&position)
+	return
+}
+
+func (m *sqliteTodoRepository) NeighborPosition(ctx context.Context, activityGroupID int64, position string, next bool) (neighbor string, err error) {
+	query := `SELECT position FROM todo WHERE activity_group_id = ? AND position < ? AND deleted_at IS NULL ORDER BY position DESC LIMIT 1`
+	if next {
+		query = `SELECT position FROM todo WHERE activity_group_id = ? AND position > ? AND deleted_at IS NULL ORDER BY position ASC LIMIT 1`
+	}
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, activityGroupID, position).Scan(&neighbor)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return
+}
+
+func (m *sqliteTodoRepository) UpdatePosition(ctx context.Context, ar *domain.Todo) (err error) {
+	query := `UPDATE todo set activity_group_id=?, position=?, updated_at=? WHERE ID = ? AND deleted_at IS NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, nullableID(ar.ActivityGroupID.ID), ar.Position, sqlitedb.Time(ar.UpdatedAt), ar.ID)
+	if sqlitedb.IsForeignKeyViolation(err) {
+		return domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteTodoRepository) GetDeletedByID(ctx context.Context, id int64) (res domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if len(list) == 0 {
+		return res, domain.ErrNotFound
+	}
+	if err = m.fetchTags(ctx, list); err != nil {
+		return domain.Todo{}, err
+	}
+	res = list[0]
+
+	return
+}
+
+func (m *sqliteTodoRepository) FetchDeleted(ctx context.Context) (res []domain.Todo, err error) {
+	query := `SELECT id, activity_group_id, title, notes, status, priority, due_at, recurrence, time_zone, completed_at, position, updated_at, created_at, deleted_at
+  						FROM todo WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC`
+
+	res, err = m.fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.fetchTags(ctx, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (m *sqliteTodoRepository) Restore(ctx context.Context, id int64) (err error) {
+	query := `UPDATE todo set deleted_at=NULL WHERE ID = ? AND deleted_at IS NOT NULL`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteTodoRepository) RestoreByActivityGroupID(ctx context.Context, activityGroupID int64, deletedAt time.Time) (err error) {
+	query := `UPDATE todo set deleted_at=NULL WHERE activity_group_id = ? AND deleted_at = ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, activityGroupID, sqlitedb.Time(deletedAt))
+	return
+}
+
+func (m *sqliteTodoRepository) Purge(ctx context.Context, before time.Time) (purged int64, err error) {
+	query := `DELETE FROM todo WHERE deleted_at < ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, sqlitedb.Time(before))
+	if err != nil {
+		return
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,455 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	activitySqliteRepo "github.com/bxcodec/go-clean-arch/activity/repository/sqlite"
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	todoSqliteRepo "github.com/bxcodec/go-clean-arch/todo/repository/sqlite"
+	todoItemSqliteRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/sqlite"
+)
+
+// newDB will open a database in a file of its own, removed once the test is over
+func newDB(t *testing.T) *sql.DB {
+	dir, err := ioutil.TempDir("", "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sqlitedb.Open(filepath.Join(dir, "todolist.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+	return db
+}
+
+var createdAt = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// newRepository will give a todo repository along with a stored activity to put the todos in
+func newRepository(t *testing.T) (*sql.DB, domain.TodoRepository, domain.Activity) {
+	db := newDB(t)
+	activity := domain.Activity{Email: "Bagus@gmail.com", Title: "Judul", UpdatedAt: createdAt, CreatedAt: createdAt}
+	assert.NoError(t, activitySqliteRepo.NewSqliteActivityRepository(db).Store(context.TODO(), &activity))
+	return db, todoSqliteRepo.NewSqliteTodoRepository(db), activity
+}
+
+func storeTodos(t *testing.T, repo domain.TodoRepository, todos ...domain.Todo) []domain.Todo {
+	res := make([]domain.Todo, 0, len(todos))
+	for _, todo := range todos {
+		if todo.Priority == 0 {
+			todo.Priority = domain.PriorityNormal
+		}
+		if todo.Status == "" {
+			todo.Status = domain.TodoStatusTodo
+		}
+		if todo.CreatedAt.IsZero() {
+			todo.CreatedAt = createdAt
+			todo.UpdatedAt = createdAt
+		}
+		assert.NoError(t, repo.Store(context.TODO(), &todo))
+		res = append(res, todo)
+	}
+	return res
+}
+
+func titles(list []domain.Todo) []string {
+	res := make([]string, 0, len(list))
+	for _, todo := range list {
+		res = append(res, todo.Title)
+	}
+	return res
+}
+
+func TestFetch(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	storeTodos(t, repo,
+		domain.Todo{ActivityGroupID: activity, Title: "title 1"},
+		domain.Todo{ActivityGroupID: activity, Title: "title 2"},
+		domain.Todo{ActivityGroupID: activity, Title: "title 3", CreatedAt: createdAt.Add(time.Hour)},
+	)
+
+	list, nextCursor, prevCursor, err := repo.Fetch(context.TODO(), "", int64(2), domain.TodoFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 1", "title 2"}, titles(list))
+	assert.NotEmpty(t, nextCursor)
+	assert.Empty(t, prevCursor)
+
+	list, nextCursor, prevCursor, err = repo.Fetch(context.TODO(), nextCursor, int64(2), domain.TodoFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 3"}, titles(list))
+	assert.Empty(t, nextCursor)
+	assert.NotEmpty(t, prevCursor)
+
+	list, nextCursor, prevCursor, err = repo.Fetch(context.TODO(), prevCursor, int64(1), domain.TodoFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 2"}, titles(list))
+	assert.NotEmpty(t, nextCursor)
+	assert.NotEmpty(t, prevCursor)
+
+	_, _, _, err = repo.Fetch(context.TODO(), nextCursor, int64(1), domain.TodoFilter{ActivityGroupID: activity.ID}, nil)
+	assert.Equal(t, domain.ErrBadParamInput, err)
+	_, _, _, err = repo.Fetch(context.TODO(), "", int64(1), domain.TodoFilter{}, domain.TodoSort{{Field: "unknown"}})
+	assert.Equal(t, domain.ErrBadParamInput, err)
+}
+
+func TestFetchWithFilter(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	storeTodos(t, repo,
+		domain.Todo{ActivityGroupID: activity, Title: "open", Priority: domain.PriorityHigh},
+		domain.Todo{ActivityGroupID: activity, Title: "done", Status: domain.TodoStatusDone},
+		domain.Todo{Title: "without activity", Priority: domain.PriorityHigh},
+	)
+
+	list, _, _, err := repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{ActivityGroupID: activity.ID}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"open", "done"}, titles(list))
+
+	list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{Status: []domain.TodoStatus{domain.TodoStatusDone}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"done"}, titles(list))
+
+	priority := domain.PriorityHigh
+	list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{Priority: &priority}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"open", "without activity"}, titles(list))
+}
+
+func TestFetchWithDue(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	storeTodos(t, repo,
+		domain.Todo{Title: "overdue", DueAt: &past},
+		domain.Todo{Title: "done late", DueAt: &past, Status: domain.TodoStatusDone},
+		domain.Todo{Title: "upcoming", DueAt: &future},
+		domain.Todo{Title: "no due"},
+	)
+
+	list, _, _, err := repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{Overdue: true, Now: time.Now()}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"overdue"}, titles(list))
+
+	// overdue is relative to the time given along with the filter, not to the time of the query
+	list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{Overdue: true, Now: past.Add(-time.Hour)}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	now := time.Now()
+	list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{DueAfter: &now}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"upcoming"}, titles(list))
+
+	list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{DueBefore: &now}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"overdue", "done late"}, titles(list))
+}
+
+func TestFetchWithTags(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	storeTodos(t, repo,
+		domain.Todo{Title: "both", Tags: []string{"home", "urgent"}},
+		domain.Todo{Title: "home", Tags: []string{"home"}},
+		domain.Todo{Title: "none"},
+	)
+
+	filter := domain.TodoFilter{Tags: []string{"home", "urgent"}}
+	list, _, _, err := repo.Fetch(context.TODO(), "", int64(10), filter, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"both", "home"}, titles(list))
+	assert.Equal(t, []string{"home", "urgent"}, list[0].Tags)
+
+	filter.TagMatch = domain.TagMatchAll
+	list, _, _, err = repo.Fetch(context.TODO(), "", int64(10), filter, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"both"}, titles(list))
+}
+
+func TestFetchWithSort(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	storeTodos(t, repo,
+		domain.Todo{Title: "b", Priority: domain.PriorityLow},
+		domain.Todo{Title: "A", Priority: domain.PriorityHigh},
+		domain.Todo{Title: "c", Priority: domain.PriorityHigh},
+		domain.Todo{Title: "a", Priority: domain.PriorityLow},
+	)
+
+	// the titles compare regardless of case and the id breaks the tie
+	sort := domain.TodoSort{{Field: "title"}}
+	list, _, _, err := repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{}, sort)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "a", "b", "c"}, titles(list))
+
+	sort = domain.TodoSort{{Field: "priority", Descending: true}, {Field: "title"}}
+	var pages [][]string
+	cursor := ""
+	for {
+		list, nextCursor, _, err := repo.Fetch(context.TODO(), cursor, int64(3), domain.TodoFilter{}, sort)
+		assert.NoError(t, err)
+		pages = append(pages, titles(list))
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+	assert.Equal(t, [][]string{{"A", "c", "a"}, {"b"}}, pages)
+
+	list, _, prevCursor, err := repo.Fetch(context.TODO(), cursor, int64(3), domain.TodoFilter{}, sort)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, titles(list))
+	assert.NotEmpty(t, prevCursor)
+
+	list, _, _, err = repo.Fetch(context.TODO(), prevCursor, int64(2), domain.TodoFilter{}, sort)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "a"}, titles(list))
+}
+
+func TestGetByID(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	due := time.Date(2021, 3, 2, 17, 0, 0, 400000000, time.FixedZone("WIB", 7*60*60))
+	stored := storeTodos(t, repo, domain.Todo{ActivityGroupID: activity, Title: "Judul", Notes: "*notes*", DueAt: &due, Tags: []string{"work", "home"}})
+
+	res, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Judul", res.Title)
+	assert.Equal(t, "*notes*", res.Notes)
+	assert.Equal(t, domain.Activity{ID: activity.ID}, res.ActivityGroupID)
+	assert.Equal(t, []string{"home", "work"}, res.Tags)
+	// stored the way a DATETIME column does
+	assert.Equal(t, time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC), *res.DueAt)
+
+	_, err = repo.GetByID(context.TODO(), stored[0].ID+1)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestStore(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	todo := &domain.Todo{ActivityGroupID: activity, Title: "Judul", Priority: domain.PriorityNormal}
+
+	assert.NoError(t, repo.Store(context.TODO(), todo))
+	assert.Equal(t, int64(1), todo.ID)
+
+	unknown := &domain.Todo{ActivityGroupID: domain.Activity{ID: activity.ID + 1}, Title: "Judul", Priority: domain.PriorityNormal}
+	assert.Equal(t, domain.ErrUnknownActivity, repo.Store(context.TODO(), unknown))
+}
+
+func TestGetByTitle(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "Judul"})
+
+	res, err := repo.GetByTitle(context.TODO(), "judul")
+	assert.NoError(t, err)
+	assert.Equal(t, stored[0].ID, res.ID)
+
+	_, err = repo.GetByTitle(context.TODO(), "unknown")
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestDelete(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "Judul"})
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, time.Now()))
+	_, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	list, _, _, err := repo.Fetch(context.TODO(), "", int64(10), domain.TodoFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	assert.Error(t, repo.Delete(context.TODO(), stored[0].ID, time.Now()))
+}
+
+func TestUpdate(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{ActivityGroupID: activity, Title: "Judul", Tags: []string{"home"}})
+
+	todo := stored[0]
+	todo.Title = "Judul baru"
+	todo.Status = domain.TodoStatusInProgress
+	todo.Tags = []string{"work"}
+	todo.UpdatedAt = createdAt.Add(time.Hour)
+	todo.CreatedAt = time.Now()
+	assert.NoError(t, repo.Update(context.TODO(), &todo))
+
+	res, err := repo.GetByID(context.TODO(), todo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Judul baru", res.Title)
+	assert.Equal(t, domain.TodoStatusInProgress, res.Status)
+	assert.Equal(t, []string{"work"}, res.Tags)
+	assert.Equal(t, createdAt.Add(time.Hour), res.UpdatedAt)
+	assert.Equal(t, createdAt, res.CreatedAt)
+
+	todo.ActivityGroupID = domain.Activity{ID: activity.ID + 1}
+	assert.Equal(t, domain.ErrUnknownActivity, repo.Update(context.TODO(), &todo))
+	assert.Error(t, repo.Update(context.TODO(), &domain.Todo{ID: todo.ID + 1, Title: "unknown"}))
+}
+
+func TestDeleteByActivityGroupID(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{ActivityGroupID: activity, Title: "in group"}, domain.Todo{Title: "alone"})
+
+	assert.NoError(t, repo.DeleteByActivityGroupID(context.TODO(), activity.ID, time.Now()))
+	_, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	_, err = repo.GetByID(context.TODO(), stored[1].ID)
+	assert.NoError(t, err)
+}
+
+func TestDetachActivityGroup(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{ActivityGroupID: activity, Title: "in group"})
+
+	assert.NoError(t, repo.DetachActivityGroup(context.TODO(), activity.ID))
+	res, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), res.ActivityGroupID.ID)
+}
+
+func TestLastPosition(t *testing.T) {
+	_, repo, activity := newRepository(t)
+
+	position, err := repo.LastPosition(context.TODO(), activity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", position)
+
+	storeTodos(t, repo,
+		domain.Todo{ActivityGroupID: activity, Title: "first", Position: "a"},
+		domain.Todo{ActivityGroupID: activity, Title: "last", Position: "c"},
+		domain.Todo{Title: "alone", Position: "z"},
+	)
+	position, err = repo.LastPosition(context.TODO(), activity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", position)
+
+	// the todos without activity group are not part of any group
+	position, err = repo.LastPosition(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "", position)
+}
+
+func TestNeighborPosition(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	storeTodos(t, repo,
+		domain.Todo{ActivityGroupID: activity, Title: "first", Position: "a"},
+		domain.Todo{ActivityGroupID: activity, Title: "second", Position: "c"},
+		domain.Todo{ActivityGroupID: activity, Title: "third", Position: "e"},
+	)
+
+	neighbor, err := repo.NeighborPosition(context.TODO(), activity.ID, "c", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "e", neighbor)
+
+	neighbor, err = repo.NeighborPosition(context.TODO(), activity.ID, "c", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", neighbor)
+
+	neighbor, err = repo.NeighborPosition(context.TODO(), activity.ID, "e", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "", neighbor)
+}
+
+func TestUpdatePosition(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "alone", Position: "a"})
+
+	todo := stored[0]
+	todo.ActivityGroupID = activity
+	todo.Position = "n"
+	todo.Title = "ignored"
+	assert.NoError(t, repo.UpdatePosition(context.TODO(), &todo))
+
+	res, err := repo.GetByID(context.TODO(), todo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, activity.ID, res.ActivityGroupID.ID)
+	assert.Equal(t, "n", res.Position)
+	assert.Equal(t, "alone", res.Title)
+}
+
+func TestFetchDeleted(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "title 1", Tags: []string{"home"}}, domain.Todo{Title: "title 2"}, domain.Todo{Title: "title 3"})
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, createdAt.Add(time.Hour)))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[1].ID, createdAt))
+
+	list, err := repo.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 1", "title 2"}, titles(list))
+	assert.Equal(t, []string{"home"}, list[0].Tags)
+}
+
+func TestGetDeletedByID(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "Judul"})
+
+	_, err := repo.GetDeletedByID(context.TODO(), stored[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, createdAt))
+	res, err := repo.GetDeletedByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, *res.DeletedAt)
+}
+
+func TestRestore(t *testing.T) {
+	_, repo, _ := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "Judul"})
+
+	assert.Error(t, repo.Restore(context.TODO(), stored[0].ID))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, createdAt))
+	assert.NoError(t, repo.Restore(context.TODO(), stored[0].ID))
+
+	_, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+}
+
+func TestRestoreByActivityGroupID(t *testing.T) {
+	_, repo, activity := newRepository(t)
+	stored := storeTodos(t, repo,
+		domain.Todo{ActivityGroupID: activity, Title: "deleted with the activity"},
+		domain.Todo{ActivityGroupID: activity, Title: "deleted before"},
+	)
+	deletedAt := createdAt.Add(time.Hour)
+	assert.NoError(t, repo.Delete(context.TODO(), stored[1].ID, createdAt))
+	assert.NoError(t, repo.DeleteByActivityGroupID(context.TODO(), activity.ID, deletedAt))
+
+	assert.NoError(t, repo.RestoreByActivityGroupID(context.TODO(), activity.ID, deletedAt))
+	_, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	_, err = repo.GetByID(context.TODO(), stored[1].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestPurge(t *testing.T) {
+	db, repo, _ := newRepository(t)
+	stored := storeTodos(t, repo, domain.Todo{Title: "title 1", Tags: []string{"home"}}, domain.Todo{Title: "title 2"}, domain.Todo{Title: "title 3"})
+	items := todoItemSqliteRepo.NewSqliteTodoItemRepository(db)
+	assert.NoError(t, items.Store(context.TODO(), &domain.TodoItem{TodoID: stored[0].ID, Title: "item"}))
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID, createdAt))
+	assert.NoError(t, repo.Delete(context.TODO(), stored[1].ID, createdAt.Add(time.Hour)))
+
+	purged, err := repo.Purge(context.TODO(), createdAt.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	list, err := repo.FetchDeleted(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title 2"}, titles(list))
+
+	// the items of the purged todo go along with it
+	total, _, err := items.CountByTodoID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
@@ -3,148 +3,1033 @@ package usecase_test
 import (
 	"context"
 	"errors"
-	"log"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
-	ucase "github.com/bxcodec/go-clean-arch/activity/usecase"
 	"github.com/bxcodec/go-clean-arch/domain"
 	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	"github.com/bxcodec/go-clean-arch/pkg/clock"
+	ucase "github.com/bxcodec/go-clean-arch/todo/usecase"
 )
 
 func TestFetch(t *testing.T) {
-	mockArticleRepo := new(mocks.ActivityRepositoryMock)
-	mockArticle := domain.Activity{
-		Title: "Hello",
-		Email: "Content",
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockActivity := domain.Activity{
+		ID:    2,
+		Title: "Work",
+		Email: "bagus@gmail.com",
+	}
+	mockTodo := domain.Todo{
+		Title:           "Hello",
+		ActivityGroupID: domain.Activity{ID: mockActivity.ID},
 	}
 
-	mockListArtilce := make([]domain.Activity, 0)
-	mockListArtilce = append(mockListArtilce, mockArticle)
-	log.Println("asu", mockListArtilce)
+	mockListTodo := make([]domain.Todo, 0)
+	mockListTodo = append(mockListTodo, mockTodo)
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
-			mock.AnythingOfType("int64")).Return(mockListArtilce, "next-cursor", nil).Once()
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		mockTodoRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
+			mock.AnythingOfType("int64"), mock.AnythingOfType("domain.TodoFilter"), mock.AnythingOfType("domain.TodoSort")).Return(mockListTodo, "next-cursor", "prev-cursor", nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, mockActivity.ID).Return(mockActivity, nil).Once()
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
 		num := int64(1)
 		cursor := "12"
-		list, nextCursor, err := u.Fetch(context.TODO(), cursor, num)
+		list, nextCursor, prevCursor, err := u.Fetch(context.TODO(), cursor, num, domain.TodoFilter{}, nil)
 		cursorExpected := "next-cursor"
 		assert.Equal(t, cursorExpected, nextCursor)
-		assert.NotEmpty(t, nextCursor)
+		assert.Equal(t, "prev-cursor", prevCursor)
 		assert.NoError(t, err)
-		assert.Len(t, list, len(mockListArtilce))
+		assert.Len(t, list, len(mockListTodo))
+		assert.Equal(t, mockActivity, list[0].ActivityGroupID)
+
+		mockTodoRepo.AssertExpectations(t)
+		mockActivityRepo.AssertExpectations(t)
+	})
+	t.Run("overdue-at-clock-time", func(t *testing.T) {
+		now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+		mockTodoRepo.On("Fetch", mock.Anything, "", int64(10), domain.TodoFilter{Overdue: true, Now: now}, domain.TodoSort(nil)).Return([]domain.Todo{}, "", "", nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+		_, _, _, err := u.Fetch(context.TODO(), "", 0, domain.TodoFilter{Overdue: true}, nil)
 
-		mockArticleRepo.AssertExpectations(t)
+		assert.NoError(t, err)
+		mockTodoRepo.AssertExpectations(t)
 	})
+	t.Run("error-failed", func(t *testing.T) {
+		mockTodoRepo.On("Fetch", mock.Anything, mock.AnythingOfType("string"),
+			mock.AnythingOfType("int64"), mock.AnythingOfType("domain.TodoFilter"), mock.AnythingOfType("domain.TodoSort")).Return(nil, "", "", errors.New("Unexpected Error")).Once()
 
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+		num := int64(1)
+		cursor := "12"
+		list, nextCursor, prevCursor, err := u.Fetch(context.TODO(), cursor, num, domain.TodoFilter{}, nil)
+
+		assert.Empty(t, nextCursor)
+		assert.Empty(t, prevCursor)
+		assert.Error(t, err)
+		assert.Len(t, list, 0)
+		mockTodoRepo.AssertExpectations(t)
+	})
 }
 
 func TestGetByID(t *testing.T) {
-	mockArticleRepo := new(mocks.ActivityRepositoryMock)
-	mockArticle := domain.Activity{
-		Title: "Hello",
-		Email: "Content",
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockActivity := domain.Activity{
+		ID:    2,
+		Title: "Work",
+		Email: "bagus@gmail.com",
+	}
+	mockTodo := domain.Todo{
+		ID:              7,
+		Title:           "Hello",
+		ActivityGroupID: domain.Activity{ID: mockActivity.ID},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockItemRepo.On("CountByTodoID", mock.Anything, mockTodo.ID).Return(int64(3), int64(1), nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, mockActivity.ID).Return(mockActivity, nil).Once()
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
 
-		a, err := u.GetByID(context.TODO(), mockArticle.ID)
+		a, err := u.GetByID(context.TODO(), mockTodo.ID)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, a)
+		assert.Equal(t, mockActivity, a.ActivityGroupID)
+		if assert.NotNil(t, a.Completion) {
+			assert.Equal(t, 33, *a.Completion)
+		}
+
+		mockTodoRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+		mockActivityRepo.AssertExpectations(t)
+	})
+	t.Run("success-without-items", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockItemRepo.On("CountByTodoID", mock.Anything, mockTodo.ID).Return(int64(0), int64(0), nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, mockActivity.ID).Return(mockActivity, nil).Once()
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
 
-		mockArticleRepo.AssertExpectations(t)
+		a, err := u.GetByID(context.TODO(), mockTodo.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, a.Completion)
+		mockItemRepo.AssertExpectations(t)
 	})
+	t.Run("error-failed", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.GetByID(context.TODO(), mockTodo.ID)
 
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
 }
 
 func TestStore(t *testing.T) {
-	mockArticleRepo := new(mocks.ActivityRepositoryMock)
-	mockArticle := domain.Activity{
-		Title: "Hello",
-		Email: "Content",
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockTodo := domain.Todo{
+		Title:           "Hello",
+		ActivityGroupID: domain.Activity{ID: 2},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		tempMockArticle := mockArticle
-		tempMockArticle.ID = 0
-		mockArticleRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Activity{}, domain.ErrNotFound).Once()
-		mockArticleRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Activity")).Return(nil).Once()
+		tempMockTodo := mockTodo
+		tempMockTodo.ID = 0
+		tempMockTodo.Tags = []string{" Urgent", "backend", "urgent", ""}
+		mockTodoRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Work"}, nil).Once()
+		mockTodoRepo.On("LastPosition", mock.Anything, int64(2)).Return("i", nil).Once()
+		mockTodoRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Todo")).Return(nil).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
 
-		err := u.Store(context.TODO(), &tempMockArticle)
+		err := u.Store(context.TODO(), &tempMockTodo)
 
 		assert.NoError(t, err)
-		assert.Equal(t, mockArticle.Title, tempMockArticle.Title)
-		mockArticleRepo.AssertExpectations(t)
+		assert.Equal(t, mockTodo.Title, tempMockTodo.Title)
+		assert.Equal(t, "Work", tempMockTodo.ActivityGroupID.Title)
+		assert.Equal(t, domain.TodoStatusTodo, tempMockTodo.Status)
+		assert.Equal(t, []string{"backend", "urgent"}, tempMockTodo.Tags)
+		assert.Equal(t, "r", tempMockTodo.Position)
+		assert.False(t, tempMockTodo.CreatedAt.IsZero())
+		mockTodoRepo.AssertExpectations(t)
+		mockActivityRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-activity", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		mockTodoRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Store(context.TODO(), &tempMockTodo)
+
+		assert.Equal(t, domain.ErrUnknownActivity, err)
+		mockTodoRepo.AssertExpectations(t)
+		mockActivityRepo.AssertExpectations(t)
 	})
+	t.Run("archived-activity", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		mockTodoRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
 
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Store(context.TODO(), &tempMockTodo)
+
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockActivityRepo.AssertExpectations(t)
+	})
+	t.Run("missing-activity", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.ActivityGroupID = domain.Activity{}
+		mockTodoRepo.On("GetByTitle", mock.Anything, mock.AnythingOfType("string")).Return(domain.Todo{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Store(context.TODO(), &tempMockTodo)
+
+		assert.Equal(t, domain.ErrUnknownActivity, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
 }
 
 func TestDelete(t *testing.T) {
-	mockArticleRepo := new(mocks.ActivityRepositoryMock)
-	mockArticle := domain.Activity{
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockTodo := domain.Todo{
+		ID:    7,
 		Title: "Hello",
-		Email: "Content",
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockArticle, nil).Once()
-
-		mockArticleRepo.On("Delete", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Once()
+		now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+		mockTodoRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("Delete", mock.Anything, mockTodo.ID, now).Return(nil).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
 
-		err := u.Delete(context.TODO(), mockArticle.ID)
+		err := u.Delete(context.TODO(), mockTodo.ID)
 
 		assert.NoError(t, err)
-		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+		mockItemRepo.AssertNotCalled(t, "DeleteByTodoID", mock.Anything, mock.Anything)
+	})
+	t.Run("archived-activity", func(t *testing.T) {
+		archivedTodo := mockTodo
+		archivedTodo.ActivityGroupID = domain.Activity{ID: 2}
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(archivedTodo, nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Delete(context.TODO(), mockTodo.ID)
+
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockTodoRepo.AssertExpectations(t)
 	})
-	t.Run("article-is-not-exist", func(t *testing.T) {
-		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Activity{}, nil).Once()
+	t.Run("todo-is-not-exist", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Todo{}, nil).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
 
-		err := u.Delete(context.TODO(), mockArticle.ID)
+		err := u.Delete(context.TODO(), mockTodo.ID)
 
 		assert.Error(t, err)
-		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
 	})
 	t.Run("error-happens-in-db", func(t *testing.T) {
-		mockArticleRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Activity{}, errors.New("Unexpected Error")).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, mock.AnythingOfType("int64")).Return(domain.Todo{}, errors.New("Unexpected Error")).Once()
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
 
-		err := u.Delete(context.TODO(), mockArticle.ID)
+		err := u.Delete(context.TODO(), mockTodo.ID)
 
 		assert.Error(t, err)
-		mockArticleRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
 	})
+}
+
+func TestRestore(t *testing.T) {
+	deletedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockActivity := domain.Activity{ID: 2, Title: "Work"}
+	deleted := domain.Todo{
+		ID:              7,
+		Title:           "Hello",
+		ActivityGroupID: domain.Activity{ID: mockActivity.ID},
+		DeletedAt:       &deletedAt,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetDeletedByID", mock.Anything, deleted.ID).Return(deleted, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, deleted.Title).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, mockActivity.ID).Return(mockActivity, nil).Twice()
+		mockTodoRepo.On("Restore", mock.Anything, deleted.ID).Return(nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, deleted.ID).Return(domain.Todo{ID: 7, Title: "Hello", ActivityGroupID: domain.Activity{ID: mockActivity.ID}}, nil).Once()
+		mockItemRepo.On("CountByTodoID", mock.Anything, deleted.ID).Return(int64(0), int64(0), nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		res, err := u.Restore(context.TODO(), deleted.ID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, res.DeletedAt)
+		assert.Equal(t, mockActivity, res.ActivityGroupID)
+		mockTodoRepo.AssertExpectations(t)
+		mockActivityRepo.AssertExpectations(t)
+	})
+	t.Run("title-taken", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("GetDeletedByID", mock.Anything, deleted.ID).Return(deleted, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, deleted.Title).Return(domain.Todo{ID: 8, Title: "Hello"}, nil).Once()
+
+		u := ucase.NewTodoUsecase(new(mocks.ActivityRepositoryMock), mockTodoRepo, new(mocks.TodoItemRepositoryMock), new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Restore(context.TODO(), deleted.ID)
 
+		assert.Equal(t, domain.ErrConflict, err)
+		mockTodoRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+	t.Run("activity-deleted", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo.On("GetDeletedByID", mock.Anything, deleted.ID).Return(deleted, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, deleted.Title).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, mockActivity.ID).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, new(mocks.TodoItemRepositoryMock), new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Restore(context.TODO(), deleted.ID)
+
+		assert.Equal(t, domain.ErrUnknownActivity, err)
+		mockTodoRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+	t.Run("not-in-trash", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("GetDeletedByID", mock.Anything, int64(9)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(new(mocks.ActivityRepositoryMock), mockTodoRepo, new(mocks.TodoItemRepositoryMock), new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Restore(context.TODO(), 9)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
 }
 
 func TestUpdate(t *testing.T) {
-	mockArticleRepo := new(mocks.ActivityRepositoryMock)
-	mockArticle := domain.Activity{
-		Title: "Hello",
-		Email: "Content",
-		ID:    23,
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockTodo := domain.Todo{
+		ID:              23,
+		Title:           "Hello",
+		ActivityGroupID: domain.Activity{ID: 2},
+		Status:          domain.TodoStatusTodo,
+		Priority:        3,
+	}
+	mockActivityRepo.On("GetByID", mock.Anything, mockTodo.ActivityGroupID.ID).Return(domain.Activity{ID: 2}, nil)
+
+	t.Run("success", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.Status = domain.TodoStatusInProgress
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("Update", mock.Anything, &tempMockTodo).Once().Return(nil)
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TodoStatusInProgress, tempMockTodo.Status)
+		assert.Nil(t, tempMockTodo.CompletedAt)
+		assert.False(t, tempMockTodo.UpdatedAt.IsZero())
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("keep-status-when-omitted", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.Status = ""
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("Update", mock.Anything, &tempMockTodo).Once().Return(nil)
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TodoStatusTodo, tempMockTodo.Status)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("done-records-completed-at", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.Status = domain.TodoStatusDone
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("Update", mock.Anything, &tempMockTodo).Once().Return(nil)
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.NoError(t, err)
+		if assert.NotNil(t, tempMockTodo.CompletedAt) {
+			assert.Equal(t, tempMockTodo.UpdatedAt, *tempMockTodo.CompletedAt)
+		}
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("invalid-transition", func(t *testing.T) {
+		completedAt := time.Now()
+		doneTodo := mockTodo
+		doneTodo.Status = domain.TodoStatusDone
+		doneTodo.CompletedAt = &completedAt
+		tempMockTodo := mockTodo
+		tempMockTodo.Status = domain.TodoStatusInProgress
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(doneTodo, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.Equal(t, domain.ErrInvalidTransition, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-status", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.Status = "paused"
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.Equal(t, domain.ErrBadParamInput, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("todo-is-not-exist", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(domain.Todo{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &mockTodo)
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("title-conflict", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.Title = "World"
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, "World").Return(domain.Todo{ID: 24, Title: "World"}, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.Equal(t, domain.ErrConflict, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("archived-activity", func(t *testing.T) {
+		tempMockTodo := mockTodo
+		tempMockTodo.Title = "World"
+		archivedActivityRepo := new(mocks.ActivityRepositoryMock)
+		archivedActivityRepo.On("GetByID", mock.Anything, mockTodo.ActivityGroupID.ID).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+
+		u := ucase.NewTodoUsecase(archivedActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		err := u.Update(context.TODO(), &tempMockTodo)
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+}
+
+func TestComplete(t *testing.T) {
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItemRepo.On("CountByTodoID", mock.Anything, mock.AnythingOfType("int64")).Return(int64(0), int64(0), nil)
+	mockTodo := domain.Todo{
+		ID:       23,
+		Title:    "Hello",
+		Status:   domain.TodoStatusInProgress,
+		Priority: 3,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("Update", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Status == domain.TodoStatusDone && td.CompletedAt != nil
+		})).Return(nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		res, err := u.Complete(context.TODO(), mockTodo.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TodoStatusDone, res.Status)
+		assert.NotNil(t, res.CompletedAt)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("already-done", func(t *testing.T) {
+		doneTodo := mockTodo
+		doneTodo.Status = domain.TodoStatusDone
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(doneTodo, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Complete(context.TODO(), mockTodo.ID)
+		assert.Equal(t, domain.ErrInvalidTransition, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("archived-activity", func(t *testing.T) {
+		archivedTodo := mockTodo
+		archivedTodo.ActivityGroupID = domain.Activity{ID: 2}
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(archivedTodo, nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Complete(context.TODO(), mockTodo.ID)
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		cancelledTodo := mockTodo
+		cancelledTodo.Status = domain.TodoStatusCancelled
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(cancelledTodo, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Complete(context.TODO(), mockTodo.ID)
+		assert.Equal(t, domain.ErrInvalidTransition, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+}
+
+func TestReopen(t *testing.T) {
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItemRepo.On("CountByTodoID", mock.Anything, mock.AnythingOfType("int64")).Return(int64(0), int64(0), nil)
+	completedAt := time.Now()
+	mockTodo := domain.Todo{
+		ID:          23,
+		Title:       "Hello",
+		Status:      domain.TodoStatusDone,
+		Priority:    3,
+		CompletedAt: &completedAt,
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepo.On("Update", mock.Anything, &mockArticle).Once().Return(nil)
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(mockTodo, nil).Once()
+		mockTodoRepo.On("Update", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Status == domain.TodoStatusTodo && td.CompletedAt == nil
+		})).Return(nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		res, err := u.Reopen(context.TODO(), mockTodo.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TodoStatusTodo, res.Status)
+		assert.Nil(t, res.CompletedAt)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("still-open", func(t *testing.T) {
+		openTodo := mockTodo
+		openTodo.Status = domain.TodoStatusTodo
+		openTodo.CompletedAt = nil
+		mockTodoRepo.On("GetByID", mock.Anything, mockTodo.ID).Return(openTodo, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Reopen(context.TODO(), mockTodo.ID)
+		assert.Equal(t, domain.ErrInvalidTransition, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+}
+
+func TestCompleteRecurring(t *testing.T) {
+	now := time.Date(2021, 3, 26, 17, 0, 0, 0, time.UTC)
+	dueAt := func(t time.Time) *time.Time {
+		return &t
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		todo domain.Todo
+		// taken is whether another todo already holds the dated title of the next one
+		taken bool
+		// next is nil when the series is over
+		next *domain.Todo
+	}{
+		{
+			name: "weekly-chore-keeps-local-time-across-dst",
+			todo: domain.Todo{
+				DueAt:      dueAt(time.Date(2021, 3, 22, 9, 0, 0, 0, berlin)),
+				Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+				TimeZone:   "Europe/Berlin",
+			},
+			next: &domain.Todo{
+				Title:      "Chore (2021-03-29)",
+				DueAt:      dueAt(time.Date(2021, 3, 29, 9, 0, 0, 0, berlin)),
+				Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+				TimeZone:   "Europe/Berlin",
+			},
+		},
+		{
+			name: "monthly-report-on-the-last-day",
+			todo: domain.Todo{
+				DueAt:      dueAt(time.Date(2021, 1, 31, 17, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+				TimeZone:   "UTC",
+			},
+			next: &domain.Todo{
+				Title:      "Chore (2021-02-28)",
+				DueAt:      dueAt(time.Date(2021, 2, 28, 17, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+				TimeZone:   "UTC",
+			},
+		},
+		{
+			name: "without-due-date-follows-the-clock",
+			todo: domain.Todo{
+				Recurrence: "FREQ=DAILY",
+				TimeZone:   "UTC",
+			},
+			next: &domain.Todo{
+				Title:      "Chore (2021-03-27)",
+				DueAt:      dueAt(now.AddDate(0, 0, 1)),
+				Recurrence: "FREQ=DAILY",
+				TimeZone:   "UTC",
+			},
+		},
+		{
+			name: "count-is-consumed",
+			todo: domain.Todo{
+				DueAt:      dueAt(time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=DAILY;COUNT=3",
+				TimeZone:   "UTC",
+			},
+			next: &domain.Todo{
+				Title:      "Chore (2021-03-02)",
+				DueAt:      dueAt(time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=DAILY;COUNT=2",
+				TimeZone:   "UTC",
+			},
+		},
+		{
+			name: "date-of-the-previous-occurrence-is-replaced",
+			todo: domain.Todo{
+				Title:      "Chore (2021-03-22)",
+				DueAt:      dueAt(time.Date(2021, 3, 22, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=WEEKLY",
+				TimeZone:   "UTC",
+			},
+			next: &domain.Todo{
+				Title:      "Chore (2021-03-29)",
+				DueAt:      dueAt(time.Date(2021, 3, 29, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=WEEKLY",
+				TimeZone:   "UTC",
+			},
+		},
+		{
+			name: "taken-title-gets-a-copy-title",
+			todo: domain.Todo{
+				DueAt:      dueAt(time.Date(2021, 3, 22, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=WEEKLY",
+				TimeZone:   "UTC",
+			},
+			taken: true,
+			next: &domain.Todo{
+				Title:      "Chore (2021-03-29) (copy)",
+				DueAt:      dueAt(time.Date(2021, 3, 29, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=WEEKLY",
+				TimeZone:   "UTC",
+			},
+		},
+		{
+			name: "last-of-count",
+			todo: domain.Todo{
+				DueAt:      dueAt(time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=DAILY;COUNT=1",
+				TimeZone:   "UTC",
+			},
+		},
+		{
+			name: "past-until",
+			todo: domain.Todo{
+				DueAt:      dueAt(time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)),
+				Recurrence: "FREQ=DAILY;UNTIL=20210301T235959Z",
+				TimeZone:   "UTC",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTodoRepo := new(mocks.TodoRepositoryMock)
+			mockActivityRepo := new(mocks.ActivityRepositoryMock)
+			mockItemRepo := new(mocks.TodoItemRepositoryMock)
+			mockItemRepo.On("CountByTodoID", mock.Anything, mock.AnythingOfType("int64")).Return(int64(0), int64(0), nil)
+			fakeClock := &mocks.ClockMock{Time: now}
+
+			td := tt.todo
+			td.ID = 23
+			if td.Title == "" {
+				td.Title = "Chore"
+			}
+			td.Status = domain.TodoStatusInProgress
+			td.Priority = domain.PriorityHigh
+			td.ActivityGroupID = domain.Activity{ID: 2}
+			mockTodoRepo.On("GetByID", mock.Anything, td.ID).Return(td, nil).Once()
+			mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Home"}, nil).Once()
+			mockTodoRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Todo")).Return(nil).Once()
+			if tt.next != nil {
+				if tt.taken {
+					mockTodoRepo.On("GetByTitle", mock.Anything, "Chore (2021-03-29)").Return(domain.Todo{ID: 40}, nil).Once()
+				}
+				mockTodoRepo.On("GetByTitle", mock.Anything, tt.next.Title).Return(domain.Todo{}, domain.ErrNotFound).Once()
+				mockTodoRepo.On("LastPosition", mock.Anything, int64(2)).Return("i", nil).Once()
+				mockTodoRepo.On("Store", mock.Anything, mock.MatchedBy(func(next *domain.Todo) bool {
+					return next.ID == 0 && next.Title == tt.next.Title && next.Status == domain.TodoStatusTodo &&
+						next.Priority == domain.PriorityHigh && next.ActivityGroupID.ID == 2 &&
+						next.DueAt != nil && next.DueAt.Equal(*tt.next.DueAt) &&
+						next.Recurrence == tt.next.Recurrence && next.TimeZone == tt.next.TimeZone &&
+						next.CreatedAt.Equal(now) && next.CompletedAt == nil
+				})).Return(nil).Once()
+			}
+
+			u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), fakeClock, time.Second*2)
+
+			res, err := u.Complete(context.TODO(), td.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, domain.TodoStatusDone, res.Status)
+			if assert.NotNil(t, res.CompletedAt) {
+				assert.True(t, res.CompletedAt.Equal(now))
+			}
+			mockTodoRepo.AssertExpectations(t)
+			if tt.next == nil {
+				mockTodoRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestStoreRecurrence(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence string
+		timeZone   string
+		err        error
+		canonical  string
+		zone       string
+	}{
+		{name: "canonical", recurrence: "RRULE:FREQ=WEEKLY;BYDAY=WE,MO", timeZone: "Asia/Jakarta", canonical: "FREQ=WEEKLY;BYDAY=MO,WE", zone: "Asia/Jakarta"},
+		{name: "default-zone", recurrence: "FREQ=DAILY", canonical: "FREQ=DAILY", zone: "UTC"},
+		{name: "no-recurrence"},
+		{name: "invalid-rule", recurrence: "FREQ=HOURLY", err: domain.ErrBadParamInput},
+		{name: "invalid-zone", recurrence: "FREQ=DAILY", timeZone: "Mars/Olympus", err: domain.ErrBadParamInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTodoRepo := new(mocks.TodoRepositoryMock)
+			mockActivityRepo := new(mocks.ActivityRepositoryMock)
+			mockItemRepo := new(mocks.TodoItemRepositoryMock)
+			mockTodoRepo.On("GetByTitle", mock.Anything, "Chore").Return(domain.Todo{}, domain.ErrNotFound).Once()
+			mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2}, nil).Once()
+			mockTodoRepo.On("LastPosition", mock.Anything, int64(2)).Return("", nil).Once()
+			if tt.err == nil {
+				mockTodoRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Todo")).Return(nil).Once()
+			}
+
+			u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+			td := domain.Todo{
+				Title:           "Chore",
+				Priority:        domain.PriorityNormal,
+				ActivityGroupID: domain.Activity{ID: 2},
+				Recurrence:      tt.recurrence,
+				TimeZone:        tt.timeZone,
+			}
+			err := u.Store(context.TODO(), &td)
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.canonical, td.Recurrence)
+				assert.Equal(t, tt.zone, td.TimeZone)
+			}
+			mockTodoRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	todos := []domain.Todo{
+		{ID: 23, Title: "Moved", ActivityGroupID: domain.Activity{ID: 2}, Position: "m"},
+		{ID: 30, Title: "First", ActivityGroupID: domain.Activity{ID: 2}, Position: "c"},
+		{ID: 31, Title: "Last", ActivityGroupID: domain.Activity{ID: 2}, Position: "t"},
+		{ID: 40, Title: "Elsewhere", ActivityGroupID: domain.Activity{ID: 5}, Position: "i"},
+		{ID: 50, Title: "Loose"},
+	}
+
+	tests := []struct {
+		name     string
+		move     domain.TodoMove
+		lower    string
+		upper    string
+		activity int64
+		err      error
+	}{
+		{name: "after", move: domain.TodoMove{After: 30}, lower: "c", upper: "m", activity: 2},
+		{name: "before", move: domain.TodoMove{Before: 31}, lower: "m", upper: "t", activity: 2},
+		{name: "between", move: domain.TodoMove{After: 30, Before: 31}, lower: "c", upper: "t", activity: 2},
+		{name: "end-of-activity", move: domain.TodoMove{ActivityGroupID: 5}, lower: "i", activity: 5},
+		{name: "next-to-itself", move: domain.TodoMove{After: 23}, err: domain.ErrBadParamInput},
+		{name: "neighbors-apart", move: domain.TodoMove{After: 30, Before: 40}, err: domain.ErrBadParamInput},
+		{name: "neighbor-without-activity", move: domain.TodoMove{Before: 50}, err: domain.ErrBadParamInput},
+		{name: "activity-not-of-neighbor", move: domain.TodoMove{After: 30, ActivityGroupID: 5}, err: domain.ErrBadParamInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTodoRepo := new(mocks.TodoRepositoryMock)
+			mockActivityRepo := new(mocks.ActivityRepositoryMock)
+			mockItemRepo := new(mocks.TodoItemRepositoryMock)
+			mockItemRepo.On("CountByTodoID", mock.Anything, mock.AnythingOfType("int64")).Return(int64(0), int64(0), nil)
+			for _, td := range todos {
+				mockTodoRepo.On("GetByID", mock.Anything, td.ID).Return(td, nil)
+			}
+			mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Work"}, nil)
+			mockActivityRepo.On("GetByID", mock.Anything, int64(5)).Return(domain.Activity{ID: 5, Title: "Home"}, nil)
+			mockTodoRepo.On("LastPosition", mock.Anything, int64(5)).Return("i", nil)
+			mockTodoRepo.On("NeighborPosition", mock.Anything, int64(2), "c", true).Return("m", nil)
+			mockTodoRepo.On("NeighborPosition", mock.Anything, int64(2), "t", false).Return("m", nil)
+			mockTodoRepo.On("UpdatePosition", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+				return td.ID == 23 && td.ActivityGroupID.ID == tt.activity &&
+					td.Position > tt.lower && (tt.upper == "" || td.Position < tt.upper)
+			})).Return(nil).Once()
+
+			u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+			res, err := u.Move(context.TODO(), 23, tt.move)
+			assert.Equal(t, tt.err, err)
+			if tt.err != nil {
+				mockTodoRepo.AssertNotCalled(t, "UpdatePosition", mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, int64(23), res.ID)
+			mockTodoRepo.AssertCalled(t, "UpdatePosition", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMoveMany(t *testing.T) {
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItemRepo.On("CountByTodoID", mock.Anything, mock.AnythingOfType("int64")).Return(int64(0), int64(0), nil)
+
+	t.Run("success", func(t *testing.T) {
+		first := domain.Todo{ID: 23, Title: "First", ActivityGroupID: domain.Activity{ID: 2}, Position: "c"}
+		second := domain.Todo{ID: 24, Title: "Second", ActivityGroupID: domain.Activity{ID: 2}, Position: "m"}
+		moved := func(td domain.Todo) domain.Todo {
+			td.ActivityGroupID = domain.Activity{ID: 5}
+			return td
+		}
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Title: "Work"}, nil)
+		mockActivityRepo.On("GetByID", mock.Anything, int64(5)).Return(domain.Activity{ID: 5, Title: "Home"}, nil)
+		mockTodoRepo.On("GetByID", mock.Anything, first.ID).Return(first, nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, second.ID).Return(second, nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, first.ID).Return(moved(first), nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, second.ID).Return(moved(second), nil).Once()
+		mockTodoRepo.On("LastPosition", mock.Anything, int64(5)).Return("i", nil).Once()
+		mockTodoRepo.On("UpdatePosition", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.ID == first.ID && td.ActivityGroupID.ID == 5 && td.Position > "i"
+		})).Return(nil).Once()
+		mockTodoRepo.On("LastPosition", mock.Anything, int64(5)).Return("r", nil).Once()
+		mockTodoRepo.On("UpdatePosition", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.ID == second.ID && td.ActivityGroupID.ID == 5 && td.Position > "r"
+		})).Return(nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		res, err := u.MoveMany(context.TODO(), []int64{first.ID, second.ID}, 5)
+		assert.NoError(t, err)
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, "Home", res[0].ActivityGroupID.Title)
+			assert.Equal(t, second.ID, res[1].ID)
+		}
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("unknown-activity", func(t *testing.T) {
+		mockActivityRepo.On("GetByID", mock.Anything, int64(6)).Return(domain.Activity{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.MoveMany(context.TODO(), []int64{23}, 6)
+		assert.Equal(t, domain.ErrUnknownActivity, err)
+	})
+	t.Run("archived-activity", func(t *testing.T) {
+		mockActivityRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Activity{ID: 7, Archived: true}, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.MoveMany(context.TODO(), []int64{23}, 7)
+		assert.Equal(t, domain.ErrActivityArchived, err)
+	})
+	t.Run("todo-is-not-exist", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(99)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.MoveMany(context.TODO(), []int64{99}, 5)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestCopy(t *testing.T) {
+	now := time.Now()
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItemRepo.On("CountByTodoID", mock.Anything, mock.AnythingOfType("int64")).Return(int64(1), int64(1), nil)
+	source := domain.Todo{
+		ID:              23,
+		Title:           "Hello",
+		Status:          domain.TodoStatusInProgress,
+		Priority:        domain.PriorityHigh,
+		ActivityGroupID: domain.Activity{ID: 2},
+		Position:        "m",
+		Tags:            []string{"urgent"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockActivityRepo.On("GetByID", mock.Anything, int64(5)).Return(domain.Activity{ID: 5, Title: "Home"}, nil)
+		mockTodoRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+		mockItemRepo.On("FetchByTodoID", mock.Anything, source.ID).Return([]domain.TodoItem{{ID: 7, TodoID: source.ID, Title: "Step", Checked: true}}, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, "Hello (copy)").Return(domain.Todo{ID: 30, Title: "Hello (copy)"}, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, "Hello (copy 2)").Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockTodoRepo.On("LastPosition", mock.Anything, int64(5)).Return("i", nil).Once()
+		mockTodoRepo.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.ID == 0 && td.Title == "Hello (copy 2)" && td.ActivityGroupID.ID == 5 &&
+				td.Status == source.Status && td.Priority == source.Priority && td.Position > "i" &&
+				len(td.Tags) == 1 && td.CreatedAt.Equal(now)
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Todo).ID = 31
+		}).Return(nil).Once()
+		mockItemRepo.On("Store", mock.Anything, mock.MatchedBy(func(item *domain.TodoItem) bool {
+			return item.ID == 0 && item.TodoID == 31 && item.Title == "Step" && item.Checked
+		})).Return(nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, int64(31)).Return(domain.Todo{ID: 31, Title: "Hello (copy 2)", ActivityGroupID: domain.Activity{ID: 5}}, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		res, err := u.Copy(context.TODO(), source.ID, domain.TodoCopy{ActivityGroupID: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(31), res.ID)
+		assert.Equal(t, "Home", res.ActivityGroupID.Title)
+		mockTodoRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("reset-status", func(t *testing.T) {
+		completedAt := now.Add(-time.Hour)
+		doneTodo := source
+		doneTodo.Status = domain.TodoStatusDone
+		doneTodo.CompletedAt = &completedAt
+		mockTodoRepo.On("GetByID", mock.Anything, source.ID).Return(doneTodo, nil).Once()
+		mockItemRepo.On("FetchByTodoID", mock.Anything, source.ID).Return([]domain.TodoItem{{ID: 7, TodoID: source.ID, Title: "Step", Checked: true}}, nil).Once()
+		mockTodoRepo.On("GetByTitle", mock.Anything, "Hello (copy)").Return(domain.Todo{}, domain.ErrNotFound).Once()
+		mockTodoRepo.On("LastPosition", mock.Anything, int64(5)).Return("", nil).Once()
+		mockTodoRepo.On("Store", mock.Anything, mock.MatchedBy(func(td *domain.Todo) bool {
+			return td.Title == "Hello (copy)" && td.Status == domain.TodoStatusTodo && td.CompletedAt == nil
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Todo).ID = 32
+		}).Return(nil).Once()
+		mockItemRepo.On("Store", mock.Anything, mock.MatchedBy(func(item *domain.TodoItem) bool {
+			return item.TodoID == 32 && !item.Checked
+		})).Return(nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, int64(32)).Return(domain.Todo{ID: 32, Title: "Hello (copy)", Status: domain.TodoStatusTodo}, nil).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		res, err := u.Copy(context.TODO(), source.ID, domain.TodoCopy{ActivityGroupID: 5, ResetStatus: true})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TodoStatusTodo, res.Status)
+		mockTodoRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("todo-is-not-exist", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(99)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+
+		u := ucase.NewTodoUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, new(mocks.TransactorMock), clock.New(), time.Second*2)
+
+		_, err := u.Copy(context.TODO(), 99, domain.TodoCopy{ActivityGroupID: 5})
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestBulk(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	ops := []domain.TodoOperation{
+		{Op: domain.TodoOperationDelete, ID: 7},
+		{Op: domain.TodoOperationDelete, ID: 8},
+		{Op: domain.TodoOperationCreate},
+	}
+
+	t.Run("best-effort", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7, Title: "Hello"}, nil).Once()
+		mockTodoRepo.On("Delete", mock.Anything, int64(7), now).Return(nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, int64(8)).Return(domain.Todo{}, nil).Once()
+
+		u := ucase.NewTodoUsecase(new(mocks.ActivityRepositoryMock), mockTodoRepo, new(mocks.TodoItemRepositoryMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		res, err := u.Bulk(context.TODO(), ops, domain.BulkBestEffort)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.TodoOperationResult{
+			{},
+			{Err: domain.ErrNotFound},
+			{Err: domain.ErrBadParamInput},
+		}, res)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("all-or-nothing", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7, Title: "Hello"}, nil).Once()
+		mockTodoRepo.On("Delete", mock.Anything, int64(7), now).Return(nil).Once()
+		mockTodoRepo.On("GetByID", mock.Anything, int64(8)).Return(domain.Todo{}, nil).Once()
+
+		u := ucase.NewTodoUsecase(new(mocks.ActivityRepositoryMock), mockTodoRepo, new(mocks.TodoItemRepositoryMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
+
+		res, err := u.Bulk(context.TODO(), ops, domain.BulkAllOrNothing)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.TodoOperationResult{
+			{Err: domain.ErrBulkAborted},
+			{Err: domain.ErrNotFound},
+			{Err: domain.ErrBulkAborted},
+		}, res)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("complete", func(t *testing.T) {
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{}, errors.New("Unexpected Error")).Once()
+
+		u := ucase.NewTodoUsecase(new(mocks.ActivityRepositoryMock), mockTodoRepo, new(mocks.TodoItemRepositoryMock), new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, time.Second*2)
 
-		u := ucase.NewArticleUsecase(mockArticleRepo, time.Second*2)
+		res, err := u.Bulk(context.TODO(), []domain.TodoOperation{{Op: domain.TodoOperationComplete, ID: 7}}, domain.BulkAllOrNothing)
 
-		err := u.Update(context.TODO(), &mockArticle)
 		assert.NoError(t, err)
-		mockArticleRepo.AssertExpectations(t)
+		assert.Len(t, res, 1)
+		assert.EqualError(t, res[0].Err, "Unexpected Error")
+		mockTodoRepo.AssertExpectations(t)
 	})
 }
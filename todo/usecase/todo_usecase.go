@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/rank"
+	"github.com/bxcodec/go-clean-arch/pkg/recurrence"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
@@ -12,13 +14,19 @@ import (
 type todoUsecase struct {
 	activity       domain.ActivityRepository
 	todo           domain.TodoRepository
+	item           domain.TodoItemRepository
+	transactor     domain.Transactor
+	clock          domain.Clock
 	contextTimeout time.Duration
 }
 
-func NewTodoUsecase(a domain.ActivityRepository, td domain.TodoRepository, timeout time.Duration) domain.TodoUsecase {
+func NewTodoUsecase(a domain.ActivityRepository, td domain.TodoRepository, it domain.TodoItemRepository, tx domain.Transactor, clk domain.Clock, timeout time.Duration) domain.TodoUsecase {
 	return &todoUsecase{
 		activity:       a,
 		todo:           td,
+		item:           it,
+		transactor:     tx,
+		clock:          clk,
 		contextTimeout: timeout,
 	}
 }
@@ -29,7 +37,10 @@ func (a *todoUsecase) fillAuthorDetails(c context.Context, data []domain.Todo) (
 	mapActivities := map[int64]domain.Activity{}
 
 	for _, todo := range data {
-		mapActivities[todo.ActivityGroupID.ID] = domain.Activity{}
+		// todo detached from its activity group has nothing to fill
+		if todo.ActivityGroupID.ID != 0 {
+			mapActivities[todo.ActivityGroupID.ID] = domain.Activity{}
+		}
 	}
 	// Using goroutine to fetch the author's detail
 	chanActivity := make(chan domain.Activity)
@@ -49,7 +60,6 @@ func (a *todoUsecase) fillAuthorDetails(c context.Context, data []domain.Todo) (
 		err := g.Wait()
 		if err != nil {
 			logrus.Error(err)
-			return
 		}
 		close(chanActivity)
 	}()
@@ -73,7 +83,7 @@ func (a *todoUsecase) fillAuthorDetails(c context.Context, data []domain.Todo) (
 	return data, nil
 }
 
-func (a *todoUsecase) Fetch(c context.Context, cursor string, num int64) (res []domain.Todo, nextCursor string, err error) {
+func (a *todoUsecase) Fetch(c context.Context, cursor string, num int64, filter domain.TodoFilter, sort domain.TodoSort) (res []domain.Todo, nextCursor string, prevCursor string, err error) {
 	if num == 0 {
 		num = 10
 	}
@@ -81,14 +91,16 @@ func (a *todoUsecase) Fetch(c context.Context, cursor string, num int64) (res []
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
 
-	res, nextCursor, err = a.todo.Fetch(ctx, cursor, num)
+	filter.Now = a.clock.Now()
+	res, nextCursor, prevCursor, err = a.todo.Fetch(ctx, cursor, num, filter, sort)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
 	res, err = a.fillAuthorDetails(ctx, res)
 	if err != nil {
 		nextCursor = ""
+		prevCursor = ""
 	}
 	return
 }
@@ -102,6 +114,15 @@ func (a *todoUsecase) GetByID(c context.Context, id int64) (res domain.Todo, err
 		return
 	}
 
+	res.Completion, err = a.completion(ctx, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+
+	if res.ActivityGroupID.ID == 0 {
+		return
+	}
+
 	resActivity, err := a.activity.GetByID(ctx, res.ActivityGroupID.ID)
 	if err != nil {
 		return domain.Todo{}, err
@@ -110,12 +131,228 @@ func (a *todoUsecase) GetByID(c context.Context, id int64) (res domain.Todo, err
 	return
 }
 
+// completion will give the percentage of the checked items of the todo, nil when the todo has no checklist
+func (a *todoUsecase) completion(ctx context.Context, id int64) (*int, error) {
+	total, checked, err := a.item.CountByTodoID(ctx, id)
+	if err != nil || total == 0 {
+		return nil, err
+	}
+
+	percentage := int(checked * 100 / total)
+	return &percentage, nil
+}
+
 func (a *todoUsecase) Update(c context.Context, ar *domain.Todo) (err error) {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
 
-	ar.UpdatedAt = time.Now()
-	return a.todo.Update(ctx, ar)
+	existedTodo, err := a.todo.GetByID(ctx, ar.ID)
+	if err != nil {
+		return
+	}
+	if err = a.writable(ctx, existedTodo); err != nil {
+		return
+	}
+	if existedTodo.Title != ar.Title {
+		sameTitle, _ := a.todo.GetByTitle(ctx, ar.Title)
+		if sameTitle.ID != 0 {
+			return domain.ErrConflict
+		}
+	}
+	ar.Position = existedTodo.Position
+	if existedTodo.ActivityGroupID.ID != ar.ActivityGroupID.ID {
+		ar.ActivityGroupID, err = a.activityGroup(ctx, ar.ActivityGroupID.ID)
+		if err != nil {
+			return
+		}
+		// a todo changing of activity group goes at the end of the new one
+		ar.Position, err = a.endPosition(ctx, ar.ActivityGroupID.ID)
+		if err != nil {
+			return
+		}
+	}
+
+	if ar.TimeZone == "" {
+		ar.TimeZone = existedTodo.TimeZone
+	}
+	ar.Tags = domain.NormalizeTags(ar.Tags)
+	if err = normalizeRecurrence(ar); err != nil {
+		return
+	}
+
+	now := a.clock.Now()
+	next := ar.Status
+	if next == "" {
+		next = existedTodo.Status
+	}
+	ar.Status = existedTodo.Status
+	ar.CompletedAt = existedTodo.CompletedAt
+	if err = transition(ar, next, now); err != nil {
+		return
+	}
+
+	ar.CreatedAt = existedTodo.CreatedAt
+	ar.UpdatedAt = now
+	return a.save(ctx, ar, existedTodo.Status)
+}
+
+// save will update the todo along with its tags, and store its next occurrence in the same transaction when a recurring todo gets done
+func (a *todoUsecase) save(ctx context.Context, td *domain.Todo, previous domain.TodoStatus) error {
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := a.todo.Update(ctx, td)
+		if err != nil || td.Recurrence == "" || td.Status != domain.TodoStatusDone || previous == domain.TodoStatusDone {
+			return err
+		}
+
+		next, ok, err := nextOccurrence(*td, a.clock.Now())
+		if err != nil || !ok {
+			return err
+		}
+		// the titles are unique among the live todos like Store enforces
+		next.Title, err = a.freeTitle(ctx, next.Title)
+		if err != nil {
+			return err
+		}
+		next.Position, err = a.endPosition(ctx, next.ActivityGroupID.ID)
+		if err != nil {
+			return err
+		}
+		return a.todo.Store(ctx, &next)
+	})
+}
+
+// nextOccurrence will build the todo following the given one in its recurrence, false when the series is over.
+// The series follows the due_at of the todo, or the given time when it has none,
+// and the COUNT of the next todo is the number of occurrences left including itself.
+// The next todo is titled after its local due date, see domain.OccurrenceTitle.
+func nextOccurrence(td domain.Todo, now time.Time) (domain.Todo, bool, error) {
+	rule, err := recurrence.Parse(td.Recurrence)
+	if err != nil {
+		return domain.Todo{}, false, domain.ErrBadParamInput
+	}
+	if rule.Count == 1 {
+		return domain.Todo{}, false, nil
+	}
+	loc, err := time.LoadLocation(td.TimeZone)
+	if err != nil {
+		return domain.Todo{}, false, domain.ErrBadParamInput
+	}
+
+	start := now
+	if td.DueAt != nil {
+		start = *td.DueAt
+	}
+	start = start.In(loc)
+	dueAt, ok := rule.Next(start, start)
+	if !ok {
+		return domain.Todo{}, false, nil
+	}
+	if rule.Count > 0 {
+		rule.Count--
+	}
+
+	title := domain.OccurrenceTitle(td.Title, dueAt)
+	dueAt = dueAt.UTC()
+	return domain.Todo{
+		ActivityGroupID: td.ActivityGroupID,
+		Title:           title,
+		Status:          domain.TodoStatusTodo,
+		Priority:        td.Priority,
+		DueAt:           &dueAt,
+		Recurrence:      rule.String(),
+		TimeZone:        td.TimeZone,
+		Tags:            td.Tags,
+		UpdatedAt:       now,
+		CreatedAt:       now,
+	}, true, nil
+}
+
+// normalizeRecurrence will check the recurrence rule and time zone of the todo and put the rule in its canonical form
+func normalizeRecurrence(td *domain.Todo) error {
+	if td.Recurrence == "" {
+		return nil
+	}
+
+	rule, err := recurrence.Parse(td.Recurrence)
+	if err != nil {
+		return domain.ErrBadParamInput
+	}
+	if td.TimeZone == "" {
+		td.TimeZone = "UTC"
+	}
+	if _, err = time.LoadLocation(td.TimeZone); err != nil {
+		return domain.ErrBadParamInput
+	}
+
+	td.Recurrence = rule.String()
+	return nil
+}
+
+// transition will move the todo to the next status following the workflow and keep completed_at in sync
+func transition(td *domain.Todo, next domain.TodoStatus, now time.Time) error {
+	if !next.IsValid() {
+		return domain.ErrBadParamInput
+	}
+	if td.Status == next {
+		return nil
+	}
+	if !td.Status.CanTransitionTo(next) {
+		return domain.ErrInvalidTransition
+	}
+
+	td.Status = next
+	td.CompletedAt = nil
+	if next == domain.TodoStatusDone {
+		td.CompletedAt = &now
+	}
+	return nil
+}
+
+// changeStatus will move the stored todo to the given status, staying at the same status is not a transition
+func (a *todoUsecase) changeStatus(c context.Context, id int64, from []domain.TodoStatus, next domain.TodoStatus) (res domain.Todo, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	res, err = a.GetByID(ctx, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	if res.ActivityGroupID.Archived {
+		return domain.Todo{}, domain.ErrActivityArchived
+	}
+	if !hasStatus(from, res.Status) {
+		return domain.Todo{}, domain.ErrInvalidTransition
+	}
+
+	previous := res.Status
+	now := a.clock.Now()
+	if err = transition(&res, next, now); err != nil {
+		return domain.Todo{}, err
+	}
+	res.UpdatedAt = now
+
+	err = a.save(ctx, &res, previous)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	return
+}
+
+func hasStatus(statuses []domain.TodoStatus, status domain.TodoStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *todoUsecase) Complete(c context.Context, id int64) (domain.Todo, error) {
+	return a.changeStatus(c, id, domain.ActiveTodoStatuses, domain.TodoStatusDone)
+}
+
+func (a *todoUsecase) Reopen(c context.Context, id int64) (domain.Todo, error) {
+	return a.changeStatus(c, id, domain.InactiveTodoStatuses, domain.TodoStatusTodo)
 }
 
 func (a *todoUsecase) GetByTitle(c context.Context, title string) (res domain.Todo, err error) {
@@ -126,6 +363,10 @@ func (a *todoUsecase) GetByTitle(c context.Context, title string) (res domain.To
 		return
 	}
 
+	if res.ActivityGroupID.ID == 0 {
+		return
+	}
+
 	resActivity, err := a.activity.GetByID(ctx, res.ActivityGroupID.ID)
 	if err != nil {
 		return domain.Todo{}, err
@@ -138,13 +379,324 @@ func (a *todoUsecase) GetByTitle(c context.Context, title string) (res domain.To
 func (a *todoUsecase) Store(c context.Context, m *domain.Todo) (err error) {
 	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
 	defer cancel()
-	existedArticle, _ := a.GetByTitle(ctx, m.Title)
-	if existedArticle != (domain.Todo{}) {
+	existedArticle, _ := a.todo.GetByTitle(ctx, m.Title)
+	if existedArticle.ID != 0 {
 		return domain.ErrConflict
 	}
 
-	err = a.todo.Store(ctx, m)
-	return
+	m.ActivityGroupID, err = a.activityGroup(ctx, m.ActivityGroupID.ID)
+	if err != nil {
+		return
+	}
+	m.Position, err = a.endPosition(ctx, m.ActivityGroupID.ID)
+	if err != nil {
+		return
+	}
+
+	if m.Status == "" {
+		m.Status = domain.TodoStatusTodo
+	}
+	if !m.Status.IsValid() {
+		return domain.ErrBadParamInput
+	}
+	if err = normalizeRecurrence(m); err != nil {
+		return
+	}
+	m.Tags = domain.NormalizeTags(m.Tags)
+
+	m.CreatedAt = a.clock.Now()
+	m.UpdatedAt = m.CreatedAt
+	m.CompletedAt = nil
+	if m.Status == domain.TodoStatusDone {
+		completedAt := m.CreatedAt
+		m.CompletedAt = &completedAt
+	}
+
+	// the todo and its tags are written together
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return a.todo.Store(ctx, m)
+	})
+}
+
+// endPosition will give the position following every todo of the activity group
+func (a *todoUsecase) endPosition(ctx context.Context, activityGroupID int64) (string, error) {
+	last, err := a.todo.LastPosition(ctx, activityGroupID)
+	if err != nil {
+		return "", err
+	}
+	return between(last, "")
+}
+
+// between will give the position between the two, the positions not leaving room are a conflict to reload and retry
+func between(lower, upper string) (string, error) {
+	position, err := rank.Between(lower, upper)
+	if err != nil {
+		return "", domain.ErrConflict
+	}
+	return position, nil
+}
+
+// neighbor will get the todo the moved one is dropped next to, it has to be in an activity group
+func (a *todoUsecase) neighbor(ctx context.Context, id int64, movedID int64) (domain.Todo, error) {
+	if id == movedID {
+		return domain.Todo{}, domain.ErrBadParamInput
+	}
+
+	res, err := a.todo.GetByID(ctx, id)
+	if err == domain.ErrNotFound || (err == nil && res.ActivityGroupID.ID == 0) {
+		return domain.Todo{}, domain.ErrBadParamInput
+	}
+	return res, err
+}
+
+// Move will rank the todo between its new neighbors, only the moved todo is written
+func (a *todoUsecase) Move(c context.Context, id int64, move domain.TodoMove) (res domain.Todo, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return a.move(ctx, id, move)
+	})
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	return a.GetByID(ctx, id)
+}
+
+// MoveMany will move the todos one after the other to the end of the activity group, all of them or none
+func (a *todoUsecase) MoveMany(c context.Context, ids []int64, activityGroupID int64) (res []domain.Todo, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := a.activityGroup(ctx, activityGroupID); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := a.move(ctx, id, domain.TodoMove{ActivityGroupID: activityGroupID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.getMany(ctx, ids)
+}
+
+func (a *todoUsecase) move(ctx context.Context, id int64, move domain.TodoMove) (err error) {
+	res, err := a.todo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = a.writable(ctx, res); err != nil {
+		return err
+	}
+
+	activityGroupID := move.ActivityGroupID
+	var lower, upper string
+	if move.After != 0 {
+		after, err := a.neighbor(ctx, move.After, id)
+		if err != nil {
+			return err
+		}
+		lower = after.Position
+		activityGroupID = after.ActivityGroupID.ID
+	}
+	if move.Before != 0 {
+		before, err := a.neighbor(ctx, move.Before, id)
+		if err != nil {
+			return err
+		}
+		if move.After != 0 && before.ActivityGroupID.ID != activityGroupID {
+			return domain.ErrBadParamInput
+		}
+		upper = before.Position
+		activityGroupID = before.ActivityGroupID.ID
+	}
+	if activityGroupID == 0 || (move.ActivityGroupID != 0 && move.ActivityGroupID != activityGroupID) {
+		return domain.ErrBadParamInput
+	}
+
+	switch {
+	case move.After == 0 && move.Before == 0:
+		lower, err = a.todo.LastPosition(ctx, activityGroupID)
+	case move.Before == 0:
+		upper, err = a.todo.NeighborPosition(ctx, activityGroupID, lower, true)
+	case move.After == 0:
+		lower, err = a.todo.NeighborPosition(ctx, activityGroupID, upper, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	res.Position, err = between(lower, upper)
+	if err != nil {
+		return err
+	}
+	if res.ActivityGroupID.ID != activityGroupID {
+		res.ActivityGroupID, err = a.activityGroup(ctx, activityGroupID)
+		if err != nil {
+			return err
+		}
+	}
+
+	res.UpdatedAt = a.clock.Now()
+	return a.todo.UpdatePosition(ctx, &res)
+}
+
+// Copy will store a copy of the todo with its checklist at the end of the activity group,
+// the copy is titled `Title (copy)`, `Title (copy 2)` and so on to keep the titles unique
+func (a *todoUsecase) Copy(c context.Context, id int64, cp domain.TodoCopy) (domain.Todo, error) {
+	res, err := a.CopyMany(c, []int64{id}, cp)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	return res[0], nil
+}
+
+// CopyMany will copy the todos one after the other to the end of the activity group, all of them or none
+func (a *todoUsecase) CopyMany(c context.Context, ids []int64, cp domain.TodoCopy) (res []domain.Todo, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	copyIDs := make([]int64, 0, len(ids))
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		activity, err := a.activityGroup(ctx, cp.ActivityGroupID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			copyID, err := a.copy(ctx, id, activity, cp.ResetStatus)
+			if err != nil {
+				return err
+			}
+			copyIDs = append(copyIDs, copyID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.getMany(ctx, copyIDs)
+}
+
+func (a *todoUsecase) copy(ctx context.Context, id int64, activity domain.Activity, resetStatus bool) (int64, error) {
+	source, err := a.todo.GetByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	items, err := a.item.FetchByTodoID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	copied := source
+	copied.ID = 0
+	copied.ActivityGroupID = activity
+	copied.Title, err = a.copyTitle(ctx, source.Title)
+	if err != nil {
+		return 0, err
+	}
+	copied.Position, err = a.endPosition(ctx, activity.ID)
+	if err != nil {
+		return 0, err
+	}
+	if resetStatus {
+		copied.Status = domain.TodoStatusTodo
+		copied.CompletedAt = nil
+	}
+	copied.CreatedAt = a.clock.Now()
+	copied.UpdatedAt = copied.CreatedAt
+	if err = a.todo.Store(ctx, &copied); err != nil {
+		return 0, err
+	}
+
+	for _, item := range items {
+		item.ID = 0
+		item.TodoID = copied.ID
+		item.Checked = item.Checked && !resetStatus
+		item.CreatedAt = copied.CreatedAt
+		item.UpdatedAt = copied.CreatedAt
+		if err = a.item.Store(ctx, &item); err != nil {
+			return 0, err
+		}
+	}
+	return copied.ID, nil
+}
+
+// copyTitle will give the first copy title no todo is using yet
+func (a *todoUsecase) copyTitle(ctx context.Context, title string) (string, error) {
+	for n := 1; ; n++ {
+		candidate := domain.CopyTitle(title, n)
+		existed, err := a.todo.GetByTitle(ctx, candidate)
+		if err == domain.ErrNotFound || (err == nil && existed.ID == 0) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// freeTitle will give the title when no other todo holds it, its first free copy title otherwise
+func (a *todoUsecase) freeTitle(ctx context.Context, title string) (string, error) {
+	existed, err := a.todo.GetByTitle(ctx, title)
+	if err == domain.ErrNotFound || (err == nil && existed.ID == 0) {
+		return title, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return a.copyTitle(ctx, title)
+}
+
+func (a *todoUsecase) getMany(ctx context.Context, ids []int64) ([]domain.Todo, error) {
+	res := make([]domain.Todo, 0, len(ids))
+	for _, id := range ids {
+		todo, err := a.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, todo)
+	}
+	return res, nil
+}
+
+// activityGroup will get the activity group a todo is written under, it must exist and not be archived
+func (a *todoUsecase) activityGroup(ctx context.Context, id int64) (domain.Activity, error) {
+	if id == 0 {
+		return domain.Activity{}, domain.ErrUnknownActivity
+	}
+
+	res, err := a.activity.GetByID(ctx, id)
+	if err == domain.ErrNotFound {
+		return domain.Activity{}, domain.ErrUnknownActivity
+	}
+	if err != nil {
+		return domain.Activity{}, err
+	}
+	if res.Archived {
+		return domain.Activity{}, domain.ErrActivityArchived
+	}
+	return res, nil
+}
+
+// writable will make sure the stored todo is not read-only, the todos of an archived activity group are
+func (a *todoUsecase) writable(ctx context.Context, td domain.Todo) error {
+	if td.ActivityGroupID.ID == 0 {
+		return nil
+	}
+
+	activity, err := a.activity.GetByID(ctx, td.ActivityGroupID.ID)
+	if err != nil {
+		return err
+	}
+	if activity.Archived {
+		return domain.ErrActivityArchived
+	}
+	return nil
 }
 
 func (a *todoUsecase) Delete(c context.Context, id int64) (err error) {
@@ -154,8 +706,126 @@ func (a *todoUsecase) Delete(c context.Context, id int64) (err error) {
 	if err != nil {
 		return
 	}
-	if existedArticle == (domain.Todo{}) {
+	if existedArticle.ID == 0 {
 		return domain.ErrNotFound
 	}
-	return a.todo.Delete(ctx, id)
+	if err = a.writable(ctx, existedArticle); err != nil {
+		return
+	}
+
+	// the checklist items are kept so a restore brings them back, the purge removes them with the todo
+	return a.todo.Delete(ctx, id, a.clock.Now())
+}
+
+// Restore will bring the todo back from the trash, as long as its title is still free and its activity is not deleted
+func (a *todoUsecase) Restore(c context.Context, id int64) (res domain.Todo, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	deleted, err := a.todo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	existedArticle, _ := a.todo.GetByTitle(ctx, deleted.Title)
+	if existedArticle.ID != 0 {
+		return domain.Todo{}, domain.ErrConflict
+	}
+	if deleted.ActivityGroupID.ID != 0 {
+		if _, err = a.activityGroup(ctx, deleted.ActivityGroupID.ID); err != nil {
+			return domain.Todo{}, err
+		}
+	}
+
+	err = a.todo.Restore(ctx, id)
+	if err != nil {
+		return
+	}
+	return a.GetByID(ctx, id)
+}
+
+// Bulk will run the operations one after the other inside a single transaction. With all_or_nothing the first failed
+// operation rolls the whole bulk back and every other operation gets ErrBulkAborted, with best_effort each operation
+// runs inside its own savepoint so a failure only undoes the writes of that operation
+func (a *todoUsecase) Bulk(c context.Context, ops []domain.TodoOperation, mode domain.BulkMode) (res []domain.TodoOperationResult, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	run := a.transactor.WithinTransaction
+	if mode == domain.BulkBestEffort {
+		run = a.transactor.WithinSavepoint
+	}
+
+	res = make([]domain.TodoOperationResult, len(ops))
+	failed := -1
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, op := range ops {
+			var td *domain.Todo
+			opErr := run(ctx, func(ctx context.Context) (err error) {
+				td, err = a.apply(ctx, op)
+				return
+			})
+			res[i] = domain.TodoOperationResult{Todo: td, Err: opErr}
+			if opErr != nil && mode != domain.BulkBestEffort {
+				failed = i
+				return opErr
+			}
+		}
+		return nil
+	})
+	if failed >= 0 {
+		for i := range res {
+			if i != failed {
+				res[i] = domain.TodoOperationResult{Err: domain.ErrBulkAborted}
+			}
+		}
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// apply will run a single operation of a bulk, giving the todo once written or nil when it is gone
+func (a *todoUsecase) apply(ctx context.Context, op domain.TodoOperation) (*domain.Todo, error) {
+	switch op.Op {
+	case domain.TodoOperationCreate:
+		if op.Todo == nil {
+			return nil, domain.ErrBadParamInput
+		}
+		td := *op.Todo
+		td.ID = 0
+		if err := a.Store(ctx, &td); err != nil {
+			return nil, err
+		}
+		return &td, nil
+	case domain.TodoOperationUpdate:
+		if op.Todo == nil {
+			return nil, domain.ErrBadParamInput
+		}
+		td := *op.Todo
+		td.ID = op.ID
+		if err := a.Update(ctx, &td); err != nil {
+			return nil, err
+		}
+		return &td, nil
+	case domain.TodoOperationComplete:
+		td, err := a.Complete(ctx, op.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &td, nil
+	case domain.TodoOperationDelete:
+		return nil, a.Delete(ctx, op.ID)
+	case domain.TodoOperationMove:
+		if op.Move == nil || *op.Move == (domain.TodoMove{}) {
+			return nil, domain.ErrBadParamInput
+		}
+		td, err := a.Move(ctx, op.ID, *op.Move)
+		if err != nil {
+			return nil, err
+		}
+		return &td, nil
+	}
+	return nil, domain.ErrBadParamInput
 }
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/mergepatch"
+	"github.com/bxcodec/go-clean-arch/pkg/validation"
+)
+
+// ResponseError represent the reseponse error struct
+type ResponseError struct {
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// TodoItemHandler  represent the httphandler for the checklist items of a todo
+type TodoItemHandler struct {
+	IUsecase domain.TodoItemUsecase
+}
+
+// NewTodoItemHandler will initialize the todo/:id/items resources endpoint
+func NewTodoItemHandler(e *echo.Echo, us domain.TodoItemUsecase) {
+	handler := &TodoItemHandler{
+		IUsecase: us,
+	}
+	e.GET("/todo/:id/items", handler.FetchItem)
+	e.POST("/todo/:id/items", handler.Store)
+	e.GET("/todo/:id/items/:item_id", handler.GetByID)
+	e.PATCH("/todo/:id/items/:item_id", handler.Patch)
+	e.DELETE("/todo/:id/items/:item_id", handler.Delete)
+}
+
+// pathIDs will get the id of the todo and, when asked, the id of its item from the path
+func pathIDs(c echo.Context, withItem bool) (todoID int64, itemID int64, err error) {
+	todoID, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || !withItem {
+		return
+	}
+	itemID, err = strconv.ParseInt(c.Param("item_id"), 10, 64)
+	return
+}
+
+// FetchItem will fetch the checklist of the todo
+func (a *TodoItemHandler) FetchItem(c echo.Context) error {
+	todoID, _, err := pathIDs(c, false)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	listItem, err := a.IUsecase.Fetch(ctx, todoID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, listItem)
+}
+
+// GetByID will get the item of the todo by given params
+func (a *TodoItemHandler) GetByID(c echo.Context) error {
+	todoID, itemID, err := pathIDs(c, true)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	item, err := a.IUsecase.GetByID(ctx, todoID, itemID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+func isRequestValid(m *domain.TodoItem) (bool, ResponseError) {
+	fields, err := validation.Struct(m)
+	if err != nil {
+		return false, ResponseError{Message: err.Error()}
+	}
+	if len(fields) > 0 {
+		return false, ResponseError{Message: domain.ErrBadParamInput.Error(), Errors: fields}
+	}
+	return true, ResponseError{}
+}
+
+// Store will add the item to the checklist of the todo by given param and request body
+func (a *TodoItemHandler) Store(c echo.Context) (err error) {
+	todoID, _, err := pathIDs(c, false)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	var item domain.TodoItem
+	err = c.Bind(&item)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	item.ID = 0
+	item.TodoID = todoID
+
+	if ok, resErr := isRequestValid(&item); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	ctx := c.Request().Context()
+	err = a.IUsecase.Store(ctx, &item)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, item)
+}
+
+// Patch will partially update the item, e.g. check it off, by given params and JSON merge-patch body
+func (a *TodoItemHandler) Patch(c echo.Context) (err error) {
+	todoID, itemID, err := pathIDs(c, true)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	if !mergepatch.IsMediaType(c.Request().Header.Get(echo.HeaderContentType)) {
+		return c.JSON(http.StatusUnsupportedMediaType, ResponseError{Message: mergepatch.ErrMediaType.Error()})
+	}
+	patch, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	existed, err := a.IUsecase.GetByID(ctx, todoID, itemID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	original, err := json.Marshal(existed)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ResponseError{Message: err.Error()})
+	}
+
+	patched, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var item domain.TodoItem
+	err = json.Unmarshal(patched, &item)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+	}
+	item.ID = itemID
+	item.TodoID = todoID
+
+	if ok, resErr := isRequestValid(&item); !ok {
+		return c.JSON(http.StatusBadRequest, resErr)
+	}
+
+	err = a.IUsecase.Update(ctx, &item)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// Delete will remove the item from the checklist of the todo by given params
+func (a *TodoItemHandler) Delete(c echo.Context) error {
+	todoID, itemID, err := pathIDs(c, true)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, domain.ErrNotFound.Error())
+	}
+
+	ctx := c.Request().Context()
+	err = a.IUsecase.Delete(ctx, todoID, itemID)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	logrus.Error(err)
+	switch err {
+	case domain.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case domain.ErrNotFound:
+		return http.StatusNotFound
+	case domain.ErrConflict:
+		return http.StatusConflict
+	case domain.ErrBadParamInput:
+		return http.StatusBadRequest
+	case domain.ErrActivityArchived:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
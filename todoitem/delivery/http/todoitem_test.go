@@ -0,0 +1,214 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	todoItemHTTP "github.com/bxcodec/go-clean-arch/todoitem/delivery/http"
+)
+
+func TestFetch(t *testing.T) {
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+	mockItems := []domain.TodoItem{
+		{ID: 1, TodoID: 7, Title: "Milk", Position: 1},
+		{ID: 2, TodoID: 7, Title: "Eggs", Checked: true, Position: 2},
+	}
+	mockUCase.On("Fetch", mock.Anything, int64(7)).Return(mockItems, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo/7/items", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/items")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+	err = handler.FetchItem(c)
+	require.NoError(t, err)
+
+	var items []domain.TodoItem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &items))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, items, 2)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestFetchUnknownTodo(t *testing.T) {
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+	mockUCase.On("Fetch", mock.Anything, int64(7)).Return(nil, domain.ErrNotFound)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/todo/7/items", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/items")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+	err = handler.FetchItem(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStore(t *testing.T) {
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+	mockUCase.On("Store", mock.Anything, mock.MatchedBy(func(item *domain.TodoItem) bool {
+		return item.TodoID == 7 && item.Title == "Milk" && item.ID == 0
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/todo/7/items", strings.NewReader(`{"id":3,"todo_id":9,"title":"Milk"}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/items")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+	err = handler.Store(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestStoreInvalid(t *testing.T) {
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+
+	e := echo.New()
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+
+	for _, tt := range []struct {
+		body   string
+		errors map[string]string
+	}{
+		{body: `{"checked":true}`, errors: map[string]string{"title": "is required"}},
+		{body: `{"title":"` + strings.Repeat("a", 256) + `"}`, errors: map[string]string{"title": "must be at most 255 characters long"}},
+	} {
+		req, err := http.NewRequest(echo.POST, "/todo/7/items", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("todo/:id/items")
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+
+		err = handler.Store(c)
+		require.NoError(t, err)
+
+		var resErr todoItemHTTP.ResponseError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resErr))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, tt.errors, resErr.Errors)
+	}
+	mockUCase.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestPatch(t *testing.T) {
+	mockItem := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Position: 2}
+
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+	mockUCase.On("GetByID", mock.Anything, int64(7), int64(3)).Return(mockItem, nil)
+	mockUCase.On("Update", mock.Anything, mock.MatchedBy(func(item *domain.TodoItem) bool {
+		return item.ID == 3 && item.TodoID == 7 && item.Checked && item.Title == "Milk" && item.Position == 2
+	})).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/todo/7/items/3", strings.NewReader(`{"checked":true}`))
+	assert.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/items/:item_id")
+	c.SetParamNames("id", "item_id")
+	c.SetParamValues("7", "3")
+
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+	err = handler.Patch(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+	mockUCase.On("Delete", mock.Anything, int64(7), int64(3)).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/todo/7/items/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/items/:item_id")
+	c.SetParamNames("id", "item_id")
+	c.SetParamValues("7", "3")
+
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
+
+func TestDeleteArchived(t *testing.T) {
+	mockUCase := new(mocks.TodoItemUsecaseMock)
+	mockUCase.On("Delete", mock.Anything, int64(7), int64(3)).Return(domain.ErrActivityArchived)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/todo/7/items/3", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("todo/:id/items/:item_id")
+	c.SetParamNames("id", "item_id")
+	c.SetParamValues("7", "3")
+
+	handler := todoItemHTTP.TodoItemHandler{
+		IUsecase: mockUCase,
+	}
+	err = handler.Delete(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockUCase.AssertExpectations(t)
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+)
+
+type memoryTodoItemRepository struct {
+	DB *memdb.DB
+}
+
+// NewMemoryTodoItemRepository will create an object that represent the domain.TodoItemRepository interface,
+// the items are kept in the given in-memory database
+func NewMemoryTodoItemRepository(db *memdb.DB) domain.TodoItemRepository {
+	return &memoryTodoItemRepository{db}
+}
+
+func (m *memoryTodoItemRepository) FetchByTodoID(ctx context.Context, todoID int64) (res []domain.TodoItem, err error) {
+	res = make([]domain.TodoItem, 0)
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, item := range t.TodoItems {
+			if item.TodoID == todoID {
+				res = append(res, item)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Position != res[j].Position {
+			return res[i].Position < res[j].Position
+		}
+		return res[i].ID < res[j].ID
+	})
+	return
+}
+
+func (m *memoryTodoItemRepository) GetByID(ctx context.Context, id int64) (res domain.TodoItem, err error) {
+	found := false
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		res, found = t.TodoItems[id]
+		return nil
+	})
+	if err != nil {
+		return domain.TodoItem{}, err
+	}
+
+	if !found {
+		return domain.TodoItem{}, domain.ErrNotFound
+	}
+	return
+}
+
+func (m *memoryTodoItemRepository) CountByTodoID(ctx context.Context, todoID int64) (total int64, checked int64, err error) {
+	err = m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for _, item := range t.TodoItems {
+			if item.TodoID != todoID {
+				continue
+			}
+			total++
+			if item.Checked {
+				checked++
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// Store will refuse an item of a todo that is not stored, like the foreign key of the todo_item table does
+func (m *memoryTodoItemRepository) Store(ctx context.Context, a *domain.TodoItem) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		if _, ok := t.Todos[a.TodoID]; !ok {
+			return domain.ErrNotFound
+		}
+
+		row := *a
+		row.ID = t.NextID("todo_item")
+		row.UpdatedAt = memdb.Time(a.UpdatedAt)
+		row.CreatedAt = memdb.Time(a.CreatedAt)
+		t.TodoItems[row.ID] = row
+		a.ID = row.ID
+		return nil
+	})
+}
+
+func (m *memoryTodoItemRepository) Update(ctx context.Context, ar *domain.TodoItem) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		item, ok := t.TodoItems[ar.ID]
+		if !ok {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		item.Title = ar.Title
+		item.Checked = ar.Checked
+		item.Position = ar.Position
+		item.UpdatedAt = memdb.Time(ar.UpdatedAt)
+		t.TodoItems[ar.ID] = item
+		return nil
+	})
+}
+
+func (m *memoryTodoItemRepository) Delete(ctx context.Context, id int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		if _, ok := t.TodoItems[id]; !ok {
+			return fmt.Errorf("weird  Behavior. Total Affected: %d", 0)
+		}
+
+		delete(t.TodoItems, id)
+		return nil
+	})
+}
+
+func (m *memoryTodoItemRepository) DeleteByTodoID(ctx context.Context, todoID int64) (err error) {
+	return m.DB.Exec(ctx, func(t *memdb.Tables) error {
+		for id, item := range t.TodoItems {
+			if item.TodoID == todoID {
+				delete(t.TodoItems, id)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,127 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/memdb"
+	todoMemoryRepo "github.com/bxcodec/go-clean-arch/todo/repository/memory"
+	todoItemMemoryRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/memory"
+)
+
+// newRepository will give a todo item repository along with a stored todo to put the items in
+func newRepository(t *testing.T) (domain.TodoItemRepository, domain.Todo) {
+	db := memdb.New()
+	todo := domain.Todo{Title: "Judul", Priority: domain.PriorityNormal}
+	assert.NoError(t, todoMemoryRepo.NewMemoryTodoRepository(db).Store(context.TODO(), &todo))
+	return todoItemMemoryRepo.NewMemoryTodoItemRepository(db), todo
+}
+
+func storeItems(t *testing.T, repo domain.TodoItemRepository, items ...domain.TodoItem) []domain.TodoItem {
+	res := make([]domain.TodoItem, 0, len(items))
+	for _, item := range items {
+		assert.NoError(t, repo.Store(context.TODO(), &item))
+		res = append(res, item)
+	}
+	return res
+}
+
+func TestFetchByTodoID(t *testing.T) {
+	repo, todo := newRepository(t)
+	storeItems(t, repo,
+		domain.TodoItem{TodoID: todo.ID, Title: "second", Position: 1},
+		domain.TodoItem{TodoID: todo.ID, Title: "first", Position: 0},
+		domain.TodoItem{TodoID: todo.ID, Title: "third", Position: 1},
+	)
+
+	list, err := repo.FetchByTodoID(context.TODO(), todo.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 3) {
+		assert.Equal(t, "first", list[0].Title)
+		assert.Equal(t, "second", list[1].Title)
+		assert.Equal(t, "third", list[2].Title)
+	}
+
+	list, err = repo.FetchByTodoID(context.TODO(), todo.ID+1)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestGetByID(t *testing.T) {
+	repo, todo := newRepository(t)
+	stored := storeItems(t, repo, domain.TodoItem{TodoID: todo.ID, Title: "item", CreatedAt: time.Date(2021, 3, 1, 10, 0, 0, 700000000, time.UTC)})
+
+	item, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "item", item.Title)
+	assert.Equal(t, time.Date(2021, 3, 1, 10, 0, 1, 0, time.UTC), item.CreatedAt)
+
+	_, err = repo.GetByID(context.TODO(), stored[0].ID+1)
+	assert.Equal(t, domain.ErrNotFound, err)
+}
+
+func TestCountByTodoID(t *testing.T) {
+	repo, todo := newRepository(t)
+	storeItems(t, repo,
+		domain.TodoItem{TodoID: todo.ID, Title: "checked", Checked: true},
+		domain.TodoItem{TodoID: todo.ID, Title: "unchecked"},
+	)
+
+	total, checked, err := repo.CountByTodoID(context.TODO(), todo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, int64(1), checked)
+}
+
+func TestStore(t *testing.T) {
+	repo, todo := newRepository(t)
+	item := &domain.TodoItem{TodoID: todo.ID, Title: "item"}
+
+	assert.NoError(t, repo.Store(context.TODO(), item))
+	assert.Equal(t, int64(1), item.ID)
+
+	assert.Equal(t, domain.ErrNotFound, repo.Store(context.TODO(), &domain.TodoItem{TodoID: todo.ID + 1, Title: "item"}))
+}
+
+func TestUpdate(t *testing.T) {
+	repo, todo := newRepository(t)
+	stored := storeItems(t, repo, domain.TodoItem{TodoID: todo.ID, Title: "item"})
+
+	item := stored[0]
+	item.Title = "changed"
+	item.Checked = true
+	item.Position = 3
+	assert.NoError(t, repo.Update(context.TODO(), &item))
+
+	res, err := repo.GetByID(context.TODO(), item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "changed", res.Title)
+	assert.True(t, res.Checked)
+	assert.Equal(t, 3, res.Position)
+
+	assert.Error(t, repo.Update(context.TODO(), &domain.TodoItem{ID: item.ID + 1}))
+}
+
+func TestDelete(t *testing.T) {
+	repo, todo := newRepository(t)
+	stored := storeItems(t, repo, domain.TodoItem{TodoID: todo.ID, Title: "item"})
+
+	assert.NoError(t, repo.Delete(context.TODO(), stored[0].ID))
+	_, err := repo.GetByID(context.TODO(), stored[0].ID)
+	assert.Equal(t, domain.ErrNotFound, err)
+	assert.Error(t, repo.Delete(context.TODO(), stored[0].ID))
+}
+
+func TestDeleteByTodoID(t *testing.T) {
+	repo, todo := newRepository(t)
+	storeItems(t, repo, domain.TodoItem{TodoID: todo.ID, Title: "first"}, domain.TodoItem{TodoID: todo.ID, Title: "second"})
+
+	assert.NoError(t, repo.DeleteByTodoID(context.TODO(), todo.ID))
+	total, _, err := repo.CountByTodoID(context.TODO(), todo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
@@ -0,0 +1,147 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	todoItemMysqlRepo "github.com/bxcodec/go-clean-arch/todoitem/repository/mysql"
+)
+
+var columns = []string{"id", "todo_id", "title", "checked", "position", "updated_at", "created_at"}
+
+func TestFetchByTodoID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	now := time.Now()
+	rows := sqlmock.NewRows(columns).
+		AddRow(1, 7, "Milk", true, 1, now, now).
+		AddRow(2, 7, "Eggs", false, 2, now, now)
+
+	query := "SELECT id, todo_id, title, checked, position, updated_at, created_at FROM todo_item WHERE todo_id = \\? ORDER BY position ASC, id ASC"
+	mock.ExpectQuery(query).WithArgs(int64(7)).WillReturnRows(rows)
+
+	a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+	list, err := a.FetchByTodoID(context.TODO(), 7)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.True(t, list[0].Checked)
+	assert.Equal(t, 2, list[1].Position)
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT id, todo_id, title, checked, position, updated_at, created_at FROM todo_item WHERE ID = \\?"
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows(columns).AddRow(3, 7, "Milk", false, 1, now, now)
+		mock.ExpectQuery(query).WithArgs(int64(3)).WillReturnRows(rows)
+
+		a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+		item, err := a.GetByID(context.TODO(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), item.TodoID)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(int64(4)).WillReturnRows(sqlmock.NewRows(columns))
+
+		a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+		_, err := a.GetByID(context.TODO(), 4)
+		assert.Equal(t, domain.ErrNotFound, err)
+	})
+}
+
+func TestCountByTodoID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "SELECT COUNT\\(\\*\\), COALESCE\\(SUM\\(checked\\), 0\\) FROM todo_item WHERE todo_id = \\?"
+	mock.ExpectQuery(query).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"total", "checked"}).AddRow(4, 1))
+
+	a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+	total, checked, err := a.CountByTodoID(context.TODO(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, int64(1), checked)
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now()
+	item := &domain.TodoItem{TodoID: 7, Title: "Milk", Position: 1, CreatedAt: now, UpdatedAt: now}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "INSERT todo_item SET todo_id=\\?, title=\\?, checked=\\?, position=\\?, updated_at=\\?, created_at=\\?"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(item.TodoID, item.Title, item.Checked, item.Position, item.UpdatedAt, item.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+	err = a.Store(context.TODO(), item)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), item.ID)
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Now()
+	item := &domain.TodoItem{ID: 12, TodoID: 7, Title: "Milk", Checked: true, Position: 1, CreatedAt: now, UpdatedAt: now}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	query := "UPDATE todo_item set title=\\?, checked=\\?, position=\\?, updated_at=\\? WHERE ID = \\?"
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(item.Title, item.Checked, item.Position, item.UpdatedAt, item.ID).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+	err = a.Update(context.TODO(), item)
+	assert.NoError(t, err)
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("DELETE FROM todo_item WHERE id = \\?")
+	prep.ExpectExec().WithArgs(12).WillReturnResult(sqlmock.NewResult(12, 1))
+
+	a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+	err = a.Delete(context.TODO(), 12)
+	assert.NoError(t, err)
+}
+
+func TestDeleteByTodoID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	prep := mock.ExpectPrepare("DELETE FROM todo_item WHERE todo_id = \\?")
+	prep.ExpectExec().WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	a := todoItemMysqlRepo.NewMysqlTodoItemRepository(db)
+	err = a.DeleteByTodoID(context.TODO(), 7)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/postgresdb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type postgresTodoItemRepository struct {
+	Conn *sql.DB
+}
+
+// NewPostgresTodoItemRepository will create an object that represent the domain.TodoItemRepository interface
+func NewPostgresTodoItemRepository(Conn *sql.DB) domain.TodoItemRepository {
+	return &postgresTodoItemRepository{Conn}
+}
+
+func (m *postgresTodoItemRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.TodoItem, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.TodoItem, 0)
+	for rows.Next() {
+		t := domain.TodoItem{}
+		err = rows.Scan(
+			&t.ID,
+			&t.TodoID,
+			&t.Title,
+			&t.Checked,
+			&t.Position,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *postgresTodoItemRepository) FetchByTodoID(ctx context.Context, todoID int64) (res []domain.TodoItem, err error) {
+	query := `SELECT id, todo_id, title, checked, position, updated_at, created_at
+  						FROM todo_item WHERE todo_id = $1 ORDER BY position ASC, id ASC`
+
+	return m.fetch(ctx, query, todoID)
+}
+
+func (m *postgresTodoItemRepository) GetByID(ctx context.Context, id int64) (res domain.TodoItem, err error) {
+	query := `SELECT id, todo_id, title, checked, position, updated_at, created_at
+  						FROM todo_item WHERE ID = $1`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.TodoItem{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *postgresTodoItemRepository) CountByTodoID(ctx context.Context, todoID int64) (total int64, checked int64, err error) {
+	query := `SELECT COUNT(*), COUNT(*) FILTER (WHERE checked) FROM todo_item WHERE todo_id = $1`
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, todoID).Scan(&total, &checked)
+	return
+}
+
+// Store will refuse a todo that is not stored with domain.ErrNotFound
+func (m *postgresTodoItemRepository) Store(ctx context.Context, a *domain.TodoItem) (err error) {
+	query := `INSERT INTO todo_item (todo_id, title, checked, position, updated_at, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	err = stmt.QueryRowContext(ctx, a.TodoID, a.Title, a.Checked, a.Position, a.UpdatedAt, a.CreatedAt).Scan(&a.ID)
+	if postgresdb.IsForeignKeyViolation(err) {
+		return domain.ErrNotFound
+	}
+	return
+}
+
+func (m *postgresTodoItemRepository) Update(ctx context.Context, ar *domain.TodoItem) (err error) {
+	query := `UPDATE todo_item set title=$1, checked=$2, position=$3, updated_at=$4 WHERE ID = $5`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Title, ar.Checked, ar.Position, ar.UpdatedAt, ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *postgresTodoItemRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := "DELETE FROM todo_item WHERE id = $1"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (m *postgresTodoItemRepository) DeleteByTodoID(ctx context.Context, todoID int64) (err error) {
+	query := "DELETE FROM todo_item WHERE todo_id = $1"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, todoID)
+	return
+}
@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/pkg/sqlitedb"
+	"github.com/bxcodec/go-clean-arch/pkg/transaction"
+)
+
+type sqliteTodoItemRepository struct {
+	Conn *sql.DB
+}
+
+// NewSqliteTodoItemRepository will create an object that represent the domain.TodoItemRepository interface
+func NewSqliteTodoItemRepository(Conn *sql.DB) domain.TodoItemRepository {
+	return &sqliteTodoItemRepository{Conn}
+}
+
+func (m *sqliteTodoItemRepository) fetch(ctx context.Context, query string, args ...interface{}) (result []domain.TodoItem, err error) {
+	rows, err := transaction.Conn(ctx, m.Conn).QueryContext(ctx, query, args...)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	defer func() {
+		errRow := rows.Close()
+		if errRow != nil {
+			logrus.Error(errRow)
+		}
+	}()
+
+	result = make([]domain.TodoItem, 0)
+	for rows.Next() {
+		t := domain.TodoItem{}
+		err = rows.Scan(
+			&t.ID,
+			&t.TodoID,
+			&t.Title,
+			&t.Checked,
+			&t.Position,
+			&t.UpdatedAt,
+			&t.CreatedAt,
+		)
+
+		if err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, nil
+}
+
+func (m *sqliteTodoItemRepository) FetchByTodoID(ctx context.Context, todoID int64) (res []domain.TodoItem, err error) {
+	query := `SELECT id, todo_id, title, checked, position, updated_at, created_at
+  						FROM todo_item WHERE todo_id = ? ORDER BY position ASC, id ASC`
+
+	return m.fetch(ctx, query, todoID)
+}
+
+func (m *sqliteTodoItemRepository) GetByID(ctx context.Context, id int64) (res domain.TodoItem, err error) {
+	query := `SELECT id, todo_id, title, checked, position, updated_at, created_at
+  						FROM todo_item WHERE ID = ?`
+
+	list, err := m.fetch(ctx, query, id)
+	if err != nil {
+		return domain.TodoItem{}, err
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return res, domain.ErrNotFound
+	}
+
+	return
+}
+
+func (m *sqliteTodoItemRepository) CountByTodoID(ctx context.Context, todoID int64) (total int64, checked int64, err error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(checked), 0) FROM todo_item WHERE todo_id = ?`
+
+	err = transaction.Conn(ctx, m.Conn).QueryRowContext(ctx, query, todoID).Scan(&total, &checked)
+	return
+}
+
+// Store will refuse a todo that is not stored with domain.ErrNotFound
+func (m *sqliteTodoItemRepository) Store(ctx context.Context, a *domain.TodoItem) (err error) {
+	query := `INSERT INTO todo_item (todo_id, title, checked, position, updated_at, created_at) VALUES (?, ?, ?, ?, ?, ?)`
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, a.TodoID, a.Title, a.Checked, a.Position, sqlitedb.Time(a.UpdatedAt), sqlitedb.Time(a.CreatedAt))
+	if sqlitedb.IsForeignKeyViolation(err) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	a.ID = lastID
+	return
+}
+
+func (m *sqliteTodoItemRepository) Update(ctx context.Context, ar *domain.TodoItem) (err error) {
+	query := `UPDATE todo_item set title=?, checked=?, position=?, updated_at=? WHERE ID = ?`
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, ar.Title, ar.Checked, ar.Position, sqlitedb.Time(ar.UpdatedAt), ar.ID)
+	if err != nil {
+		return
+	}
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affect != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", affect)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteTodoItemRepository) Delete(ctx context.Context, id int64) (err error) {
+	query := "DELETE FROM todo_item WHERE id = ?"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+
+	rowsAfected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if rowsAfected != 1 {
+		err = fmt.Errorf("weird  Behavior. Total Affected: %d", rowsAfected)
+		return
+	}
+
+	return
+}
+
+func (m *sqliteTodoItemRepository) DeleteByTodoID(ctx context.Context, todoID int64) (err error) {
+	query := "DELETE FROM todo_item WHERE todo_id = ?"
+
+	stmt, err := transaction.Conn(ctx, m.Conn).PrepareContext(ctx, query)
+	if err != nil {
+		return
+	}
+
+	_, err = stmt.ExecContext(ctx, todoID)
+	return
+}
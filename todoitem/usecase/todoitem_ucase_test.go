@@ -0,0 +1,268 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	ucase "github.com/bxcodec/go-clean-arch/todoitem/usecase"
+)
+
+func TestFetch(t *testing.T) {
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItems := []domain.TodoItem{{ID: 1, TodoID: 7, Title: "Milk", Position: 1}}
+
+	t.Run("success", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("FetchByTodoID", mock.Anything, int64(7)).Return(mockItems, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		list, err := u.Fetch(context.TODO(), 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockItems, list)
+		mockTodoRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("todo-is-not-exist", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(8)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		_, err := u.Fetch(context.TODO(), 8)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItem := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Position: 1}
+
+	t.Run("success", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("GetByID", mock.Anything, int64(3)).Return(mockItem, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		item, err := u.GetByID(context.TODO(), 7, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockItem, item)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("item-of-another-todo", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(8)).Return(domain.Todo{ID: 8}, nil).Once()
+		mockItemRepo.On("GetByID", mock.Anything, int64(3)).Return(mockItem, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		_, err := u.GetByID(context.TODO(), 8, 3)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("todo-in-the-trash", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		_, err := u.GetByID(context.TODO(), 7, 3)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockItemRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStore(t *testing.T) {
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+
+	t.Run("append-at-the-end", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("FetchByTodoID", mock.Anything, int64(7)).Return([]domain.TodoItem{
+			{ID: 1, TodoID: 7, Position: 1},
+			{ID: 2, TodoID: 7, Position: 4},
+		}, nil).Once()
+		mockItemRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.TodoItem")).Return(nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{TodoID: 7, Title: "Milk"}
+		err := u.Store(context.TODO(), &item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, item.Position)
+		assert.Equal(t, now, item.CreatedAt)
+		assert.Equal(t, now, item.UpdatedAt)
+		mockTodoRepo.AssertExpectations(t)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("given-position", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.TodoItem")).Return(nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{TodoID: 7, Title: "Milk", Position: 2}
+		err := u.Store(context.TODO(), &item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, item.Position)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("todo-is-not-exist", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(8)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{TodoID: 8, Title: "Milk"}
+		err := u.Store(context.TODO(), &item)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("activity-is-archived", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7, ActivityGroupID: domain.Activity{ID: 2}}, nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{TodoID: 7, Title: "Milk"}
+		err := u.Store(context.TODO(), &item)
+
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockActivityRepo.AssertExpectations(t)
+		mockItemRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := createdAt.Add(time.Hour)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItem := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Position: 2, CreatedAt: createdAt, UpdatedAt: createdAt}
+
+	t.Run("success", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("GetByID", mock.Anything, int64(3)).Return(mockItem, nil).Once()
+		mockItemRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.TodoItem")).Return(nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Checked: true}
+		err := u.Update(context.TODO(), &item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, item.Position)
+		assert.Equal(t, createdAt, item.CreatedAt)
+		assert.Equal(t, now, item.UpdatedAt)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("item-of-another-todo", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(8)).Return(domain.Todo{ID: 8}, nil).Once()
+		mockItemRepo.On("GetByID", mock.Anything, int64(3)).Return(mockItem, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{ID: 3, TodoID: 8, Title: "Milk"}
+		err := u.Update(context.TODO(), &item)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("todo-in-the-trash", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Checked: true}
+		err := u.Update(context.TODO(), &item)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+	t.Run("activity-is-archived", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7, ActivityGroupID: domain.Activity{ID: 2}}, nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{Time: now}, time.Second*2)
+
+		item := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Checked: true}
+		err := u.Update(context.TODO(), &item)
+
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockActivityRepo.AssertExpectations(t)
+		mockItemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockItemRepo := new(mocks.TodoItemRepositoryMock)
+	mockItem := domain.TodoItem{ID: 3, TodoID: 7, Title: "Milk", Position: 2}
+
+	t.Run("success", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("GetByID", mock.Anything, int64(3)).Return(mockItem, nil).Once()
+		mockItemRepo.On("Delete", mock.Anything, int64(3)).Return(nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		err := u.Delete(context.TODO(), 7, 3)
+
+		assert.NoError(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("error-happens-in-db", func(t *testing.T) {
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7}, nil).Once()
+		mockItemRepo.On("GetByID", mock.Anything, int64(3)).Return(domain.TodoItem{}, errors.New("Unexpected Error")).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		err := u.Delete(context.TODO(), 7, 3)
+
+		assert.Error(t, err)
+		mockItemRepo.AssertExpectations(t)
+	})
+	t.Run("todo-in-the-trash", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{}, domain.ErrNotFound).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		err := u.Delete(context.TODO(), 7, 3)
+
+		assert.Equal(t, domain.ErrNotFound, err)
+		mockItemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+	t.Run("activity-is-archived", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockItemRepo := new(mocks.TodoItemRepositoryMock)
+		mockTodoRepo.On("GetByID", mock.Anything, int64(7)).Return(domain.Todo{ID: 7, ActivityGroupID: domain.Activity{ID: 2}}, nil).Once()
+		mockActivityRepo.On("GetByID", mock.Anything, int64(2)).Return(domain.Activity{ID: 2, Archived: true}, nil).Once()
+		u := ucase.NewTodoItemUsecase(mockActivityRepo, mockTodoRepo, mockItemRepo, &mocks.ClockMock{}, time.Second*2)
+
+		err := u.Delete(context.TODO(), 7, 3)
+
+		assert.Equal(t, domain.ErrActivityArchived, err)
+		mockActivityRepo.AssertExpectations(t)
+		mockItemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
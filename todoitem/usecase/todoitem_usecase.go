@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type todoItemUsecase struct {
+	activity       domain.ActivityRepository
+	todo           domain.TodoRepository
+	item           domain.TodoItemRepository
+	clock          domain.Clock
+	contextTimeout time.Duration
+}
+
+// NewTodoItemUsecase will create new an todoItemUsecase object representation of domain.TodoItemUsecase interface
+func NewTodoItemUsecase(ar domain.ActivityRepository, td domain.TodoRepository, it domain.TodoItemRepository, clk domain.Clock, timeout time.Duration) domain.TodoItemUsecase {
+	return &todoItemUsecase{
+		activity:       ar,
+		todo:           td,
+		item:           it,
+		clock:          clk,
+		contextTimeout: timeout,
+	}
+}
+
+func (a *todoItemUsecase) Fetch(c context.Context, todoID int64) (res []domain.TodoItem, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	_, err = a.todo.GetByID(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.item.FetchByTodoID(ctx, todoID)
+}
+
+func (a *todoItemUsecase) GetByID(c context.Context, todoID int64, id int64) (res domain.TodoItem, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	return a.getByID(ctx, todoID, id)
+}
+
+// getByID will get the item only when it belongs to the given todo, the items of a todo in the trash are not found
+// either as they are kept until the todo is purged
+func (a *todoItemUsecase) getByID(ctx context.Context, todoID int64, id int64) (res domain.TodoItem, err error) {
+	_, err = a.todo.GetByID(ctx, todoID)
+	if err != nil {
+		return domain.TodoItem{}, err
+	}
+	return a.itemOf(ctx, todoID, id)
+}
+
+// itemOf will get the item only when it belongs to the given todo, the todo itself is checked by the caller
+func (a *todoItemUsecase) itemOf(ctx context.Context, todoID int64, id int64) (res domain.TodoItem, err error) {
+	res, err = a.item.GetByID(ctx, id)
+	if err != nil {
+		return domain.TodoItem{}, err
+	}
+	if res.TodoID != todoID {
+		return domain.TodoItem{}, domain.ErrNotFound
+	}
+	return
+}
+
+// writable will make sure the checklist of the given todo is not read-only, the checklists of the todos of an archived
+// activity group are
+func (a *todoItemUsecase) writable(ctx context.Context, todoID int64) error {
+	td, err := a.todo.GetByID(ctx, todoID)
+	if err != nil {
+		return err
+	}
+	if td.ActivityGroupID.ID == 0 {
+		return nil
+	}
+
+	activity, err := a.activity.GetByID(ctx, td.ActivityGroupID.ID)
+	if err != nil {
+		return err
+	}
+	if activity.Archived {
+		return domain.ErrActivityArchived
+	}
+	return nil
+}
+
+func (a *todoItemUsecase) Store(c context.Context, m *domain.TodoItem) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if err = a.writable(ctx, m.TodoID); err != nil {
+		return
+	}
+
+	// an item without position is put at the end of the checklist
+	if m.Position == 0 {
+		items, err := a.item.FetchByTodoID(ctx, m.TodoID)
+		if err != nil {
+			return err
+		}
+		m.Position = 1
+		for _, item := range items {
+			if item.Position >= m.Position {
+				m.Position = item.Position + 1
+			}
+		}
+	}
+
+	m.CreatedAt = a.clock.Now()
+	m.UpdatedAt = m.CreatedAt
+	return a.item.Store(ctx, m)
+}
+
+func (a *todoItemUsecase) Update(c context.Context, m *domain.TodoItem) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if err = a.writable(ctx, m.TodoID); err != nil {
+		return
+	}
+	existedItem, err := a.itemOf(ctx, m.TodoID, m.ID)
+	if err != nil {
+		return
+	}
+	if m.Position == 0 {
+		m.Position = existedItem.Position
+	}
+
+	m.CreatedAt = existedItem.CreatedAt
+	m.UpdatedAt = a.clock.Now()
+	return a.item.Update(ctx, m)
+}
+
+func (a *todoItemUsecase) Delete(c context.Context, todoID int64, id int64) (err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	if err = a.writable(ctx, todoID); err != nil {
+		return
+	}
+	_, err = a.itemOf(ctx, todoID, id)
+	if err != nil {
+		return
+	}
+	return a.item.Delete(ctx, id)
+}
@@ -0,0 +1,83 @@
+--
+-- PostgreSQL equivalent of todolist.sql, every timestamp is stored rounded to the second. The text columns are
+-- citext so they compare regardless of case like the utf8_unicode_ci collation does, but the position one that
+-- compares byte by byte. The full-text indexes have no equivalent, searching goes through search/repository/index
+--
+
+CREATE EXTENSION IF NOT EXISTS citext;
+
+CREATE TABLE activity (
+  id BIGSERIAL PRIMARY KEY,
+  email CITEXT NOT NULL CHECK (char_length(email) <= 45),
+  title CITEXT NOT NULL CHECK (char_length(title) <= 45),
+  archived BOOLEAN NOT NULL DEFAULT FALSE, -- the todos of an archived activity are read-only
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMPTZ(0) DEFAULT NULL -- set while the activity is in the trash
+);
+CREATE INDEX activity_created_at_id ON activity (created_at, id);
+CREATE INDEX activity_deleted_at ON activity (deleted_at);
+
+CREATE TABLE todo (
+  id BIGSERIAL PRIMARY KEY,
+  activity_group_id BIGINT DEFAULT NULL REFERENCES activity (id) ON DELETE RESTRICT,
+  title CITEXT NOT NULL CHECK (char_length(title) <= 45),
+  notes CITEXT NOT NULL DEFAULT '', -- markdown, 10000 characters at most
+  status CITEXT NOT NULL DEFAULT 'todo' CHECK (char_length(status) <= 16),
+  priority SMALLINT NOT NULL, -- 1 very-low, 2 low, 3 normal, 4 high, 5 very-high
+  due_at TIMESTAMPTZ(0) DEFAULT NULL,
+  recurrence CITEXT NOT NULL DEFAULT '' CHECK (char_length(recurrence) <= 255),
+  time_zone CITEXT NOT NULL DEFAULT '' CHECK (char_length(time_zone) <= 64),
+  completed_at TIMESTAMPTZ(0) DEFAULT NULL,
+  position VARCHAR(64) COLLATE "C" NOT NULL DEFAULT '', -- fractional rank within the activity
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMPTZ(0) DEFAULT NULL -- set while the todo is in the trash
+);
+CREATE INDEX todo_created_at_id ON todo (created_at, id);
+CREATE INDEX todo_activity_group_id ON todo (activity_group_id);
+CREATE INDEX todo_deleted_at ON todo (deleted_at);
+CREATE INDEX todo_status ON todo (status);
+CREATE INDEX todo_due_at ON todo (due_at);
+CREATE INDEX todo_activity_group_id_position ON todo (activity_group_id, position);
+
+CREATE TABLE todo_item (
+  id BIGSERIAL PRIMARY KEY,
+  todo_id BIGINT NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+  title CITEXT NOT NULL CHECK (char_length(title) <= 255),
+  checked BOOLEAN NOT NULL DEFAULT FALSE,
+  position INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX todo_item_todo_id_position ON todo_item (todo_id, position);
+
+CREATE TABLE tag (
+  id BIGSERIAL PRIMARY KEY,
+  name CITEXT NOT NULL UNIQUE CHECK (char_length(name) <= 45), -- trimmed and lower-cased
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE todo_tag (
+  todo_id BIGINT NOT NULL REFERENCES todo (id) ON DELETE CASCADE,
+  tag_id BIGINT NOT NULL REFERENCES tag (id) ON DELETE CASCADE,
+  PRIMARY KEY (todo_id, tag_id)
+);
+CREATE INDEX todo_tag_tag_id ON todo_tag (tag_id);
+
+CREATE TABLE template (
+  id BIGSERIAL PRIMARY KEY,
+  title CITEXT NOT NULL CHECK (char_length(title) <= 45), -- may hold {{date}}, {{week}}, {{month}} and {{year}}
+  email CITEXT NOT NULL CHECK (char_length(email) <= 45),
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE template_todo (
+  template_id BIGINT NOT NULL REFERENCES template (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  title CITEXT NOT NULL CHECK (char_length(title) <= 45),
+  priority SMALLINT NOT NULL,
+  PRIMARY KEY (template_id, position)
+);
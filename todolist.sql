@@ -2,7 +2,7 @@ CREATE DATABASE  IF NOT EXISTS `activity` /*!40100 DEFAULT CHARACTER SET utf8 CO
 USE `activity`;
 
 --
--- Table structure for table `article`
+-- Table structure for table `article`, every datetime is stored in UTC
 --
 
 DROP TABLE IF EXISTS `activity`;
@@ -10,18 +10,86 @@ CREATE TABLE `activity` (
   `id` int(11) NOT NULL AUTO_INCREMENT,
   `email` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
   `title` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
-  `updated_at` datetime DEFAULT NULL,
-  `created_at` datetime DEFAULT NULL,
-  PRIMARY KEY (`id`)
+  `archived` tinyint(1) NOT NULL DEFAULT 0 COMMENT 'the todos of an archived activity are read-only',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL COMMENT 'set while the activity is in the trash',
+  PRIMARY KEY (`id`),
+  KEY `created_at_id` (`created_at`,`id`),
+  KEY `deleted_at` (`deleted_at`),
+  FULLTEXT KEY `ft_title` (`title`) WITH PARSER ngram
 ) ENGINE=InnoDB AUTO_INCREMENT=7 DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
 
 CREATE TABLE `todo` (
   `id` int(11) NOT NULL AUTO_INCREMENT,
-  `activity_group_id` int(11) NOT NULL,
+  `activity_group_id` int(11) DEFAULT NULL,
   `title` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
-  `is_active` int(11) NOT NULL,
-  `priority` int(11) NOT NULL,
-  `updated_at` datetime DEFAULT NULL,
-  `created_at` datetime DEFAULT NULL,
+  `notes` text COLLATE utf8_unicode_ci NOT NULL COMMENT 'markdown, 10000 characters at most',
+  `status` varchar(16) COLLATE utf8_unicode_ci NOT NULL DEFAULT 'todo',
+  `priority` tinyint(1) NOT NULL COMMENT '1 very-low, 2 low, 3 normal, 4 high, 5 very-high',
+  `due_at` datetime DEFAULT NULL,
+  `recurrence` varchar(255) COLLATE utf8_unicode_ci NOT NULL DEFAULT '',
+  `time_zone` varchar(64) COLLATE utf8_unicode_ci NOT NULL DEFAULT '',
+  `completed_at` datetime DEFAULT NULL,
+  `position` varchar(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '' COMMENT 'fractional rank within the activity',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `deleted_at` datetime DEFAULT NULL COMMENT 'set while the todo is in the trash',
+  PRIMARY KEY (`id`),
+  KEY `created_at_id` (`created_at`,`id`),
+  KEY `activity_group_id` (`activity_group_id`),
+  KEY `deleted_at` (`deleted_at`),
+  KEY `status` (`status`),
+  KEY `due_at` (`due_at`),
+  KEY `activity_group_id_position` (`activity_group_id`,`position`),
+  FULLTEXT KEY `ft_title_notes` (`title`,`notes`) WITH PARSER ngram,
+  CONSTRAINT `fk_todo_activity` FOREIGN KEY (`activity_group_id`) REFERENCES `activity` (`id`) ON DELETE RESTRICT
+) ENGINE=InnoDB AUTO_INCREMENT=7 DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+CREATE TABLE `todo_item` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `todo_id` int(11) NOT NULL,
+  `title` varchar(255) COLLATE utf8_unicode_ci NOT NULL,
+  `checked` tinyint(1) NOT NULL DEFAULT 0,
+  `position` int(11) NOT NULL DEFAULT 0,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `todo_id_position` (`todo_id`,`position`),
+  CONSTRAINT `fk_todo_item_todo` FOREIGN KEY (`todo_id`) REFERENCES `todo` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `tag` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(45) COLLATE utf8_unicode_ci NOT NULL COMMENT 'trimmed and lower-cased',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `todo_tag` (
+  `todo_id` int(11) NOT NULL,
+  `tag_id` int(11) NOT NULL,
+  PRIMARY KEY (`todo_id`,`tag_id`),
+  KEY `tag_id` (`tag_id`),
+  CONSTRAINT `fk_todo_tag_todo` FOREIGN KEY (`todo_id`) REFERENCES `todo` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_todo_tag_tag` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `template` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `title` varchar(45) COLLATE utf8_unicode_ci NOT NULL COMMENT 'may hold {{date}}, {{week}}, {{month}} and {{year}}',
+  `email` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=7 DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
\ No newline at end of file
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `template_todo` (
+  `template_id` int(11) NOT NULL,
+  `position` int(11) NOT NULL,
+  `title` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
+  `priority` tinyint(1) NOT NULL,
+  PRIMARY KEY (`template_id`,`position`),
+  CONSTRAINT `fk_template_todo_template` FOREIGN KEY (`template_id`) REFERENCES `template` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
@@ -0,0 +1,138 @@
+--
+-- Upgrades a database created by the original todolist.sql to the current schema, run it once with the server
+-- stopped and a backup at hand: mysql -u root -p < todolist.upgrade.sql
+--
+-- The original connection read and wrote the datetimes in Asia/Jakarta, they are now read and written in UTC. Jakarta
+-- has kept UTC+7 without daylight saving since 1964, so every stored datetime is shifted by a fixed -7 hours, which
+-- does not depend on the time zone tables of the server.
+--
+USE `activity`;
+
+--
+-- activity
+--
+UPDATE `activity` SET
+  `updated_at` = CONVERT_TZ(`updated_at`, '+07:00', '+00:00'),
+  `created_at` = CONVERT_TZ(`created_at`, '+07:00', '+00:00');
+UPDATE `activity` SET
+  `created_at` = COALESCE(`created_at`, `updated_at`, UTC_TIMESTAMP()),
+  `updated_at` = COALESCE(`updated_at`, `created_at`);
+
+ALTER TABLE `activity`
+  ADD COLUMN `archived` tinyint(1) NOT NULL DEFAULT 0 COMMENT 'the todos of an archived activity are read-only' AFTER `title`,
+  MODIFY `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  MODIFY `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN `deleted_at` datetime DEFAULT NULL COMMENT 'set while the activity is in the trash' AFTER `created_at`,
+  ADD KEY `created_at_id` (`created_at`,`id`),
+  ADD KEY `deleted_at` (`deleted_at`);
+ALTER TABLE `activity` ADD FULLTEXT KEY `ft_title` (`title`) WITH PARSER ngram;
+
+--
+-- todo
+--
+UPDATE `todo` SET
+  `updated_at` = CONVERT_TZ(`updated_at`, '+07:00', '+00:00'),
+  `created_at` = CONVERT_TZ(`created_at`, '+07:00', '+00:00');
+UPDATE `todo` SET
+  `created_at` = COALESCE(`created_at`, `updated_at`, UTC_TIMESTAMP()),
+  `updated_at` = COALESCE(`updated_at`, `created_at`);
+
+ALTER TABLE `todo`
+  MODIFY `activity_group_id` int(11) DEFAULT NULL,
+  ADD COLUMN `notes` text COLLATE utf8_unicode_ci NOT NULL COMMENT 'markdown, 10000 characters at most' AFTER `title`,
+  ADD COLUMN `status` varchar(16) COLLATE utf8_unicode_ci NOT NULL DEFAULT 'todo' AFTER `notes`,
+  ADD COLUMN `due_at` datetime DEFAULT NULL AFTER `priority`,
+  ADD COLUMN `recurrence` varchar(255) COLLATE utf8_unicode_ci NOT NULL DEFAULT '' AFTER `due_at`,
+  ADD COLUMN `time_zone` varchar(64) COLLATE utf8_unicode_ci NOT NULL DEFAULT '' AFTER `recurrence`,
+  ADD COLUMN `completed_at` datetime DEFAULT NULL AFTER `time_zone`,
+  ADD COLUMN `position` varchar(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '' COMMENT 'fractional rank within the activity' AFTER `completed_at`,
+  MODIFY `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  MODIFY `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN `deleted_at` datetime DEFAULT NULL COMMENT 'set while the todo is in the trash' AFTER `created_at`;
+
+-- an active todo is yet to be done, an inactive one is done since its last update
+UPDATE `todo` SET `status` = 'todo' WHERE `is_active` <> 0;
+UPDATE `todo` SET `status` = 'done', `completed_at` = `updated_at` WHERE `is_active` = 0;
+
+-- the priority was a free integer, it is now one of the five levels
+UPDATE `todo` SET `priority` = LEAST(GREATEST(`priority`, 1), 5);
+
+-- the activity group was not enforced, a todo of a missing activity is kept without any
+UPDATE `todo` t LEFT JOIN `activity` a ON a.`id` = t.`activity_group_id`
+  SET t.`activity_group_id` = NULL
+  WHERE a.`id` IS NULL;
+
+-- the todos had no rank, each activity group is ranked in the order of creation as the listing used to be. The n-th
+-- todo of the group gets n in the rank digits (base 36, see pkg/rank) padded to 6 digits, which sort as text in the
+-- same order once the trailing zeros a rank can't end with are trimmed
+UPDATE `todo` t JOIN (
+    SELECT a.`id`, COUNT(*) AS n
+    FROM `todo` a JOIN `todo` b ON b.`activity_group_id` <=> a.`activity_group_id`
+      AND (b.`created_at` < a.`created_at` OR (b.`created_at` = a.`created_at` AND b.`id` <= a.`id`))
+    GROUP BY a.`id`
+  ) ranked ON ranked.`id` = t.`id`
+  SET t.`position` = TRIM(TRAILING '0' FROM LPAD(LOWER(CONV(ranked.n, 10, 36)), 6, '0'));
+
+ALTER TABLE `todo`
+  DROP COLUMN `is_active`,
+  MODIFY `priority` tinyint(1) NOT NULL COMMENT '1 very-low, 2 low, 3 normal, 4 high, 5 very-high',
+  ADD KEY `created_at_id` (`created_at`,`id`),
+  ADD KEY `activity_group_id` (`activity_group_id`),
+  ADD KEY `deleted_at` (`deleted_at`),
+  ADD KEY `status` (`status`),
+  ADD KEY `due_at` (`due_at`),
+  ADD KEY `activity_group_id_position` (`activity_group_id`,`position`),
+  ADD CONSTRAINT `fk_todo_activity` FOREIGN KEY (`activity_group_id`) REFERENCES `activity` (`id`) ON DELETE RESTRICT;
+ALTER TABLE `todo` ADD FULLTEXT KEY `ft_title_notes` (`title`,`notes`) WITH PARSER ngram;
+
+--
+-- tables added since, as in todolist.sql
+--
+CREATE TABLE IF NOT EXISTS `todo_item` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `todo_id` int(11) NOT NULL,
+  `title` varchar(255) COLLATE utf8_unicode_ci NOT NULL,
+  `checked` tinyint(1) NOT NULL DEFAULT 0,
+  `position` int(11) NOT NULL DEFAULT 0,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `todo_id_position` (`todo_id`,`position`),
+  CONSTRAINT `fk_todo_item_todo` FOREIGN KEY (`todo_id`) REFERENCES `todo` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `tag` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `name` varchar(45) COLLATE utf8_unicode_ci NOT NULL COMMENT 'trimmed and lower-cased',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `todo_tag` (
+  `todo_id` int(11) NOT NULL,
+  `tag_id` int(11) NOT NULL,
+  PRIMARY KEY (`todo_id`,`tag_id`),
+  KEY `tag_id` (`tag_id`),
+  CONSTRAINT `fk_todo_tag_todo` FOREIGN KEY (`todo_id`) REFERENCES `todo` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_todo_tag_tag` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `template` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `title` varchar(45) COLLATE utf8_unicode_ci NOT NULL COMMENT 'may hold {{date}}, {{week}}, {{month}} and {{year}}',
+  `email` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `template_todo` (
+  `template_id` int(11) NOT NULL,
+  `position` int(11) NOT NULL,
+  `title` varchar(45) COLLATE utf8_unicode_ci NOT NULL,
+  `priority` tinyint(1) NOT NULL,
+  PRIMARY KEY (`template_id`,`position`),
+  CONSTRAINT `fk_template_todo_template` FOREIGN KEY (`template_id`) REFERENCES `template` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+// ResponseError represent the reseponse error struct
+type ResponseError struct {
+	Message string `json:"message"`
+}
+
+// TrashHandler  represent the httphandler for the trash
+type TrashHandler struct {
+	TUsecase domain.TrashUsecase
+}
+
+// NewTrashHandler will initialize the trash/ resources endpoint
+func NewTrashHandler(e *echo.Echo, us domain.TrashUsecase) {
+	handler := &TrashHandler{
+		TUsecase: us,
+	}
+	e.GET("/trash", handler.FetchTrash)
+}
+
+// FetchTrash will fetch the deleted activities and todos, the latest deleted first
+func (a *TrashHandler) FetchTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	trash, err := a.TUsecase.Fetch(ctx)
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, trash)
+}
+
+func getStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	logrus.Error(err)
+	switch err {
+	case domain.ErrInternalServerError:
+		return http.StatusInternalServerError
+	case domain.ErrNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,73 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	trashHTTP "github.com/bxcodec/go-clean-arch/trash/delivery/http"
+)
+
+func TestFetchTrash(t *testing.T) {
+	deletedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockTrash := domain.Trash{
+		Activities: []domain.Activity{{ID: 2, Title: "Work", DeletedAt: &deletedAt}},
+		Todos:      []domain.Todo{{ID: 7, Title: "Hello", DeletedAt: &deletedAt}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUCase := new(mocks.TrashUsecaseMock)
+		mockUCase.On("Fetch", mock.Anything).Return(mockTrash, nil).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/trash", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := trashHTTP.TrashHandler{
+			TUsecase: mockUCase,
+		}
+		err = handler.FetchTrash(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var res domain.Trash
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		if assert.Len(t, res.Todos, 1) && assert.NotNil(t, res.Todos[0].DeletedAt) {
+			assert.True(t, deletedAt.Equal(*res.Todos[0].DeletedAt))
+		}
+		assert.Len(t, res.Activities, 1)
+		mockUCase.AssertExpectations(t)
+	})
+	t.Run("error", func(t *testing.T) {
+		mockUCase := new(mocks.TrashUsecaseMock)
+		mockUCase.On("Fetch", mock.Anything).Return(domain.Trash{}, errors.New("Unexpected Error")).Once()
+
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/trash", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		handler := trashHTTP.TrashHandler{
+			TUsecase: mockUCase,
+		}
+		err = handler.FetchTrash(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		mockUCase.AssertExpectations(t)
+	})
+}
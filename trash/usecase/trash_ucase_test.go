@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+	"github.com/bxcodec/go-clean-arch/domain/mocks"
+	ucase "github.com/bxcodec/go-clean-arch/trash/usecase"
+)
+
+func TestFetch(t *testing.T) {
+	deletedAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockActivityRepo := new(mocks.ActivityRepositoryMock)
+	mockTodoRepo := new(mocks.TodoRepositoryMock)
+	mockActivityRepo.On("FetchDeleted", mock.Anything).Return([]domain.Activity{{ID: 2, DeletedAt: &deletedAt}}, nil).Once()
+	mockTodoRepo.On("FetchDeleted", mock.Anything).Return([]domain.Todo{{ID: 7, DeletedAt: &deletedAt}}, nil).Once()
+
+	u := ucase.NewTrashUsecase(mockActivityRepo, mockTodoRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: deletedAt}, time.Hour, time.Second*2)
+
+	res, err := u.Fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, res.Activities, 1)
+	assert.Len(t, res.Todos, 1)
+	mockActivityRepo.AssertExpectations(t)
+	mockTodoRepo.AssertExpectations(t)
+}
+
+func TestPurge(t *testing.T) {
+	now := time.Date(2021, 3, 31, 10, 0, 0, 0, time.UTC)
+	before := now.Add(-720 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("Purge", mock.Anything, before).Return(int64(4), nil).Once()
+		mockActivityRepo.On("Purge", mock.Anything, before).Return(int64(1), nil).Once()
+
+		u := ucase.NewTrashUsecase(mockActivityRepo, mockTodoRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, 720*time.Hour, time.Second*2)
+
+		purged, err := u.Purge(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), purged)
+		mockActivityRepo.AssertExpectations(t)
+		mockTodoRepo.AssertExpectations(t)
+	})
+	t.Run("todos-fail", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+		mockTodoRepo.On("Purge", mock.Anything, before).Return(int64(0), errors.New("Unexpected Error")).Once()
+
+		u := ucase.NewTrashUsecase(mockActivityRepo, mockTodoRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, 720*time.Hour, time.Second*2)
+
+		purged, err := u.Purge(context.TODO())
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), purged)
+		mockActivityRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+	t.Run("no-retention", func(t *testing.T) {
+		mockActivityRepo := new(mocks.ActivityRepositoryMock)
+		mockTodoRepo := new(mocks.TodoRepositoryMock)
+
+		u := ucase.NewTrashUsecase(mockActivityRepo, mockTodoRepo, new(mocks.TransactorMock), &mocks.ClockMock{Time: now}, 0, time.Second*2)
+
+		purged, err := u.Purge(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), purged)
+		mockTodoRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+}
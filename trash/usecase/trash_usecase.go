@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/bxcodec/go-clean-arch/domain"
+)
+
+type trashUsecase struct {
+	activity       domain.ActivityRepository
+	todo           domain.TodoRepository
+	transactor     domain.Transactor
+	clock          domain.Clock
+	retention      time.Duration
+	contextTimeout time.Duration
+}
+
+// NewTrashUsecase will create new an trashUsecase object representation of domain.TrashUsecase interface,
+// a retention that is not positive keeps the deleted rows forever
+func NewTrashUsecase(ar domain.ActivityRepository, td domain.TodoRepository, tx domain.Transactor, clk domain.Clock, retention time.Duration, timeout time.Duration) domain.TrashUsecase {
+	return &trashUsecase{
+		activity:       ar,
+		todo:           td,
+		transactor:     tx,
+		clock:          clk,
+		retention:      retention,
+		contextTimeout: timeout,
+	}
+}
+
+func (a *trashUsecase) Fetch(c context.Context) (res domain.Trash, err error) {
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+
+	res.Activities, err = a.activity.FetchDeleted(ctx)
+	if err != nil {
+		return domain.Trash{}, err
+	}
+	res.Todos, err = a.todo.FetchDeleted(ctx)
+	if err != nil {
+		return domain.Trash{}, err
+	}
+	return
+}
+
+func (a *trashUsecase) Purge(c context.Context) (purged int64, err error) {
+	if a.retention <= 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(c, a.contextTimeout)
+	defer cancel()
+	before := a.clock.Now().Add(-a.retention)
+
+	// the todos go first, an activity still referred by a todo has to wait for it
+	err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todos, err := a.todo.Purge(ctx, before)
+		if err != nil {
+			return err
+		}
+		activities, err := a.activity.Purge(ctx, before)
+		if err != nil {
+			return err
+		}
+		purged = todos + activities
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return
+}
+
+// PurgeEvery will purge the trash at every interval until the context is done, a failed purge is only logged
+func PurgeEvery(ctx context.Context, uc domain.TrashUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := uc.Purge(ctx)
+			if err != nil {
+				logrus.Error(err)
+				continue
+			}
+			if purged > 0 {
+				logrus.Infof("purged %d rows from the trash", purged)
+			}
+		}
+	}
+}